@@ -0,0 +1,17 @@
+data "minio_iam_policy_document" "reader" {
+  statement {
+    actions   = ["s3:ListBucket", "s3:GetObject"]
+    resources = ["arn:aws:s3:::reports", "arn:aws:s3:::reports/*"]
+  }
+}
+
+data "minio_admin_policy_constraints" "reader" {
+  check_actions = flatten([for statement in jsondecode(data.minio_iam_policy_document.reader.json).Statement : statement.Action])
+
+  lifecycle {
+    postcondition {
+      condition     = length(self.unknown_actions) == 0
+      error_message = "Unknown actions: ${join(", ", self.unknown_actions)}"
+    }
+  }
+}
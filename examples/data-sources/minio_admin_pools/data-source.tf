@@ -0,0 +1,9 @@
+data "minio_admin_pools" "pools" {}
+
+output "pools" {
+  value = data.minio_admin_pools.pools.pools
+}
+
+output "free_space_gib" {
+  value = floor(data.minio_admin_pools.pools.free_space / 1073741824)
+}
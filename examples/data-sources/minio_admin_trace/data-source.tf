@@ -0,0 +1,10 @@
+data "minio_admin_trace" "debug" {
+  duration    = "30s"
+  api_names   = ["PutBucketReplication", "PutBucketPolicy"]
+  only_errors = true
+  output_file = "${path.root}/minio-trace.jsonl"
+}
+
+output "failed_calls" {
+  value = data.minio_admin_trace.debug.entry_count
+}
@@ -0,0 +1,10 @@
+data "minio_admin_user_info" "replication" {
+  access_key = var.replication_access_key
+
+  lifecycle {
+    postcondition {
+      condition     = self.status == "enabled" && contains(self.policies, "replication")
+      error_message = "The replication access key must be enabled and have the replication policy."
+    }
+  }
+}
@@ -0,0 +1,16 @@
+data "minio_cluster_health" "this" {}
+
+resource "minio_s3_bucket" "state" {
+  bucket = "terraform-state"
+
+  lifecycle {
+    precondition {
+      condition     = data.minio_cluster_health.this.ready
+      error_message = "The MinIO cluster is not ready."
+    }
+    precondition {
+      condition     = data.minio_cluster_health.this.release_tag >= "RELEASE.2023-01-01T00-00-00Z"
+      error_message = "MinIO RELEASE.2023-01-01T00-00-00Z or later is required."
+    }
+  }
+}
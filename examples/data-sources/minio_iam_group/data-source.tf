@@ -0,0 +1,8 @@
+data "minio_iam_group" "developers" {
+  name = "developers"
+}
+
+resource "minio_iam_service_account" "ci" {
+  count       = contains(data.minio_iam_group.developers.members, "ci") ? 1 : 0
+  target_user = "ci"
+}
@@ -0,0 +1,12 @@
+data "minio_iam_policy_entities" "readwrite" {
+  policies = ["readwrite"]
+}
+
+output "readwrite_users" {
+  value = data.minio_iam_policy_entities.readwrite.policy_mappings[0].users
+}
+
+data "minio_iam_policy_entities" "ldap_admins" {
+  ldap   = true
+  groups = ["cn=admins,ou=groups,dc=example,dc=com"]
+}
@@ -0,0 +1,25 @@
+data "minio_iam_replication_policy_document" "replication" {
+  target_buckets = ["backup"]
+}
+
+resource "minio_iam_policy" "replication" {
+  provider = minio.target
+  name     = "replication"
+  policy   = data.minio_iam_replication_policy_document.replication.json
+}
+
+resource "minio_iam_user" "replication" {
+  provider = minio.target
+  name     = "replication"
+}
+
+resource "minio_iam_user_policy_attachment" "replication" {
+  provider    = minio.target
+  user_name   = minio_iam_user.replication.name
+  policy_name = minio_iam_policy.replication.id
+}
+
+resource "minio_iam_service_account" "replication" {
+  provider    = minio.target
+  target_user = minio_iam_user.replication.name
+}
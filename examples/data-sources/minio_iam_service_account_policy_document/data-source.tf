@@ -0,0 +1,18 @@
+data "minio_iam_service_account_policy_document" "reporting" {
+  access = "read-only"
+
+  bucket {
+    name     = "reports"
+    prefixes = ["monthly/", "yearly/"]
+  }
+
+  bucket {
+    name    = "exports"
+    actions = ["s3:PutObject", "s3:AbortMultipartUpload"]
+  }
+}
+
+resource "minio_iam_service_account" "reporting" {
+  target_user = "reporting"
+  policy      = data.minio_iam_service_account_policy_document.reporting.json
+}
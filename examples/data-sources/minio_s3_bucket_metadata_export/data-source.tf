@@ -0,0 +1,8 @@
+data "minio_s3_bucket_metadata_export" "assets" {
+  bucket = "assets"
+}
+
+resource "local_file" "assets_metadata" {
+  filename       = "${path.module}/assets-metadata.zip"
+  content_base64 = data.minio_s3_bucket_metadata_export.assets.content_base64
+}
@@ -0,0 +1,12 @@
+data "minio_s3_bucket_objects_manifest" "invoices" {
+  bucket           = "invoices"
+  prefix           = "2023/"
+  include_versions = true
+
+  output_bucket = "audit"
+  output_key    = "manifests/invoices-2023.csv"
+}
+
+output "invoices_size" {
+  value = data.minio_s3_bucket_objects_manifest.invoices.total_size
+}
@@ -0,0 +1,9 @@
+data "minio_s3_bucket_policy" "assets" {
+  bucket = "assets"
+}
+
+output "assets_public_read" {
+  value = contains(flatten([
+    for statement in try(jsondecode(data.minio_s3_bucket_policy.assets.policy).Statement, []) : statement.Action
+  ]), "s3:GetObject")
+}
@@ -0,0 +1,9 @@
+data "minio_s3_bucket_retention_check" "records" {
+  bucket           = "records"
+  prefix           = "2023/"
+  include_versions = true
+}
+
+output "records_released_on" {
+  value = data.minio_s3_bucket_retention_check.records.latest_retain_until_date
+}
@@ -0,0 +1,8 @@
+data "minio_s3_bucket_usage" "usage" {
+  bucket   = "analytics"
+  prefixes = ["raw/", "curated/"]
+}
+
+output "analytics_size" {
+  value = data.minio_s3_bucket_usage.usage.size
+}
@@ -0,0 +1,13 @@
+provider "minio" {
+  minio_server    = "mirror.example.com:9000"
+  minio_anonymous = true
+}
+
+data "minio_s3_object" "release" {
+  bucket_name = "artifacts"
+  object_name = "releases/latest.json"
+}
+
+output "latest_release" {
+  value = jsondecode(data.minio_s3_object.release.content)
+}
@@ -0,0 +1,9 @@
+data "minio_s3_object_query" "prod_hosts" {
+  bucket_name = "inventory"
+  object_name = "hosts.csv"
+  expression  = "SELECT s.name, s.address FROM S3Object s WHERE s.env = 'prod'"
+}
+
+output "prod_hosts" {
+  value = [for row in data.minio_s3_object_query.prod_hosts.rows : jsondecode(row)]
+}
@@ -0,0 +1,11 @@
+data "minio_s3_object_versions" "config" {
+  bucket_name = "configs"
+  object_name = "app/config.json"
+}
+
+# Content of the version preceding the latest one, to roll back to it
+data "minio_s3_object" "previous_config" {
+  bucket_name = "configs"
+  object_name = "app/config.json"
+  version_id  = data.minio_s3_object_versions.config.previous_version_id
+}
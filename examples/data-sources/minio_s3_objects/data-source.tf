@@ -0,0 +1,9 @@
+data "minio_s3_objects" "releases" {
+  bucket_name = "artifacts"
+  prefix      = "releases/"
+  recursive   = false
+}
+
+output "release_directories" {
+  value = data.minio_s3_objects.releases.common_prefixes
+}
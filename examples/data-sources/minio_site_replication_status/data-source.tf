@@ -0,0 +1,12 @@
+data "minio_site_replication_status" "status" {
+  wait_for_sync = true
+  poll_interval = "30s"
+
+  timeouts {
+    read = "30m"
+  }
+}
+
+output "in_sync" {
+  value = data.minio_site_replication_status.status.in_sync
+}
@@ -5,7 +5,9 @@ provider minio {
   minio_password = "..."
 
   // optional
-  minio_region      = "..."
-  minio_api_version = "..."
-  minio_ssl         = "..."
-}
\ No newline at end of file
+  minio_session_token = "..."
+  minio_region        = "..."
+  signature_version   = "..."
+  minio_ssl           = "..."
+  minio_insecure      = "..."
+}
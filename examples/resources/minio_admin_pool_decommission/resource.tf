@@ -0,0 +1,8 @@
+resource "minio_admin_pool_decommission" "old_pool" {
+  pool                = "http://minio{1...4}/data{1...4}"
+  wait_for_completion = true
+
+  timeouts {
+    create = "48h"
+  }
+}
@@ -0,0 +1,11 @@
+# Every user of the Keycloak realm gets the same policies through the role ARN
+resource "minio_iam_openid_role_policy" "keycloak" {
+  name        = "keycloak"
+  role_policy = ["readonly", minio_iam_policy.reports.name]
+}
+
+# Policies are read from the roles the Keycloak client maps to the "minio-roles" claim
+resource "minio_iam_openid_role_policy" "default" {
+  claim_name   = "minio-roles"
+  claim_prefix = "kc-"
+}
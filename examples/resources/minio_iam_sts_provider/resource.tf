@@ -0,0 +1,24 @@
+# The server must serve TLS and be started with MINIO_IDENTITY_TLS_ENABLE=on
+resource "minio_iam_sts_provider" "certificates" {}
+
+# Workloads authenticating with a certificate whose common name is "billing"
+# get the permissions of the policy of the same name
+resource "minio_iam_policy" "billing" {
+  name   = "billing"
+  policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Effect": "Allow",
+      "Action": ["s3:GetObject", "s3:PutObject"],
+      "Resource": ["arn:aws:s3:::billing/*"]
+    }
+  ]
+}
+EOF
+}
+
+output "sts_endpoint" {
+  value = minio_iam_sts_provider.certificates.sts_endpoint
+}
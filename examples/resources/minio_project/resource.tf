@@ -0,0 +1,17 @@
+resource "minio_project" "analytics" {
+  name          = "analytics"
+  force_destroy = true
+}
+
+output "bucket" {
+  value = minio_project.analytics.bucket
+}
+
+output "access_key" {
+  value = minio_project.analytics.access_key
+}
+
+output "secret_key" {
+  value     = minio_project.analytics.secret_key
+  sensitive = true
+}
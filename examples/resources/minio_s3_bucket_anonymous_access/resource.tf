@@ -0,0 +1,19 @@
+resource "minio_s3_bucket" "assets" {
+  bucket = "assets"
+}
+
+resource "minio_s3_bucket_anonymous_access" "assets" {
+  bucket = minio_s3_bucket.assets.bucket
+
+  rule {
+    prefix = "public/"
+    access = "download"
+  }
+
+  rule {
+    prefix = "drop/"
+    access = "upload"
+  }
+
+  allow_public_write = true
+}
@@ -0,0 +1,24 @@
+resource "minio_s3_bucket" "bucket" {
+  bucket = "example-bucket"
+}
+
+resource "minio_s3_bucket_event_rules" "uploads" {
+  bucket = minio_s3_bucket.bucket.bucket
+
+  webhook {
+    name       = "uploads"
+    endpoint   = "https://events.example.com/minio"
+    auth_token = var.events_token
+    queue_dir  = "/data/events"
+  }
+
+  rule {
+    events        = ["s3:ObjectCreated:*"]
+    filter_prefix = "uploads/"
+    filter_suffix = ".png"
+  }
+
+  rule {
+    events = ["s3:ObjectRemoved:Delete"]
+  }
+}
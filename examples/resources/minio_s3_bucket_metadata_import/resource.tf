@@ -0,0 +1,15 @@
+# Clone the configuration of a bucket onto another cluster
+data "minio_s3_bucket_metadata_export" "assets" {
+  bucket = "assets"
+}
+
+resource "minio_s3_bucket" "assets" {
+  provider = minio.dr
+  bucket   = "assets"
+}
+
+resource "minio_s3_bucket_metadata_import" "assets" {
+  provider       = minio.dr
+  bucket         = minio_s3_bucket.assets.bucket
+  content_base64 = data.minio_s3_bucket_metadata_export.assets.content_base64
+}
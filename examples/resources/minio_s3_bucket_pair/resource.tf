@@ -0,0 +1,26 @@
+provider "minio" {
+  minio_server = "minio-eu.example.com:9000"
+
+  clusters {
+    name           = "us"
+    minio_server   = "minio-us.example.com:9000"
+    minio_user     = var.us_user
+    minio_password = var.us_password
+  }
+}
+
+resource "minio_s3_bucket_pair" "assets" {
+  bucket       = "assets"
+  peer_cluster = "us"
+
+  replication {
+    primary {
+      access_key = minio_iam_service_account.replication_eu.access_key
+      secret_key = minio_iam_service_account.replication_eu.secret_key
+    }
+    peer {
+      access_key = minio_iam_service_account.replication_us.access_key
+      secret_key = minio_iam_service_account.replication_us.secret_key
+    }
+  }
+}
@@ -0,0 +1,7 @@
+resource "minio_s3_bucket" "bucket" {
+  bucket = "private-bucket"
+}
+
+resource "minio_s3_bucket_public_access_block" "bucket" {
+  bucket = minio_s3_bucket.bucket.id
+}
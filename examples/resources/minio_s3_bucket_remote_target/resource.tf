@@ -0,0 +1,24 @@
+resource "minio_s3_bucket_remote_target" "backup" {
+  bucket        = "app-data"
+  target_bucket = "app-data-backup"
+  host          = "backup.minio.example.com:9000"
+  access_key    = var.backup_access_key
+  secret_key    = var.backup_secret_key
+
+  bandwidth_limit = "100M"
+}
+
+# Several rules, possibly managed by other teams, replicate to the same target
+resource "minio_s3_bucket_replication" "app_data" {
+  bucket = "app-data"
+
+  rule {
+    prefix            = "reports/"
+    remote_target_arn = minio_s3_bucket_remote_target.backup.arn
+  }
+
+  rule {
+    prefix            = "invoices/"
+    remote_target_arn = minio_s3_bucket_remote_target.backup.arn
+  }
+}
@@ -102,7 +102,7 @@ resource "minio_s3_bucket_replication" "replication_in_b" {
     delete_replication = true
     delete_marker_replication = true
     existing_object_replication = true
-    metadata_sync = true # SHould be false for one-way
+    replica_modifications = true # Should be false for one-way
 
     target = {
       bucket = minio_s3_bucket.my_bucket_in_b.bucket
@@ -141,7 +141,7 @@ resource "minio_s3_bucket_replication" "replication_in_a" {
     delete_replication = true
     delete_marker_replication = true
     existing_object_replication = true
-    metadata_sync = true
+    replica_modifications = true
 
     target = {
       bucket = minio_s3_bucket.my_bucket_in_a.bucket
@@ -0,0 +1,15 @@
+resource "minio_s3_bucket" "docs" {
+  bucket = "docs"
+  acl    = "public-read"
+}
+
+resource "minio_s3_bucket_website" "docs" {
+  bucket         = minio_s3_bucket.docs.bucket
+  index_document = "index.html"
+  error_document = "404.html"
+
+  redirect {
+    key      = "getting-started.html"
+    location = "/guides/getting-started/index.html"
+  }
+}
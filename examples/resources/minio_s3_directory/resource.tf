@@ -0,0 +1,12 @@
+resource "minio_s3_bucket" "bucket" {
+  bucket = "shared-bucket"
+}
+
+resource "minio_s3_directory" "team" {
+  bucket = minio_s3_bucket.bucket.id
+  path   = "teams/data-science/"
+
+  tags = {
+    owner = "data-science"
+  }
+}
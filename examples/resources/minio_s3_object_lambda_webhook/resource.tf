@@ -0,0 +1,9 @@
+resource "minio_s3_object_lambda_webhook" "redact" {
+  name       = "redact"
+  endpoint   = "http://redactor.analytics.svc:8080/transform"
+  auth_token = var.redactor_token
+}
+
+output "redacted_object_url" {
+  value = "https://minio.example.com/reports/2023.csv?lambdaArn=${minio_s3_object_lambda_webhook.redact.arn}"
+}
@@ -0,0 +1,8 @@
+resource "minio_s3_object_tags" "archive" {
+  bucket = "reports"
+  key    = "2023/q1.csv"
+
+  tags = {
+    tier = "cold"
+  }
+}
@@ -0,0 +1,13 @@
+# Capture the configuration of the server, without its credentials
+resource "minio_server_config_snapshot" "current" {}
+
+resource "local_file" "server_config" {
+  filename = "${path.module}/server-config.txt"
+  content  = minio_server_config_snapshot.current.snapshot
+}
+
+# Re-apply a configuration to a rebuilt cluster
+resource "minio_server_config_snapshot" "rebuilt" {
+  provider = minio.rebuilt
+  config   = file("${path.module}/server-config-with-secrets.txt")
+}
@@ -1,8 +1,14 @@
 package minio
 
 import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"golang.org/x/exp/slices"
 )
 
 // BucketConfig creates a new config for minio buckets
@@ -10,13 +16,31 @@ func BucketConfig(d *schema.ResourceData, meta interface{}) *S3MinioBucket {
 	m := meta.(*S3MinioClient)
 
 	return &S3MinioBucket{
+		MinioClient:              m.S3Client,
+		MinioAdmin:               m.S3Admin,
+		MinioRegion:              m.S3Region,
+		MinioAccess:              m.S3UserAccess,
+		MinioBucket:              d.Get("bucket").(string),
+		MinioBucketPrefix:        d.Get("bucket_prefix").(string),
+		MinioACL:                 d.Get("acl").(string),
+		MinioForceDestroy:        d.Get("force_destroy").(bool),
+		MinioForceDestroyWorkers: d.Get("force_destroy_workers").(int),
+		MinioObjectLocking:       d.Get("object_locking").(bool),
+
+		MinioForceDestroyBypassGovernance: d.Get("force_destroy_bypass_governance").(bool),
+	}
+}
+
+// ProjectConfig creates a new config for minio projects
+func ProjectConfig(d *schema.ResourceData, meta interface{}) *S3MinioProjectConfig {
+	m := meta.(*S3MinioClient)
+
+	return &S3MinioProjectConfig{
 		MinioClient:       m.S3Client,
 		MinioAdmin:        m.S3Admin,
 		MinioRegion:       m.S3Region,
-		MinioAccess:       m.S3UserAccess,
-		MinioBucket:       d.Get("bucket").(string),
-		MinioBucketPrefix: d.Get("bucket_prefix").(string),
-		MinioACL:          d.Get("acl").(string),
+		MinioProjectName:  d.Get("name").(string),
+		MinioAccessKey:    d.Get("access_key").(string),
 		MinioForceDestroy: d.Get("force_destroy").(bool),
 	}
 }
@@ -51,11 +75,77 @@ func BucketReplicationConfig(d *schema.ResourceData, meta interface{}) (*S3Minio
 
 	replicationRules, diags := getBucketReplicationConfig(d.Get("rule").([]interface{}))
 
+	if err := applyReplicationPriorityStrategy(replicationRules, replicationPriorityStrategy(d)); err != nil {
+		diags = append(diags, diag.FromErr(err)...)
+	}
+
+	rawConfig := d.GetRawConfig()
+	diags = append(diags, applyReplicationRuleMetadataSync(replicationRules, rawConfig)...)
+	for i := range replicationRules {
+		replicationRules[i].DeleteMarkerReplicationDefault = replicationRuleAttributeIsNull(rawConfig, i, "delete_marker_replication")
+	}
+
+	// Rules known to the state are managed too, in case they were created before the IDs were tracked
+	managedRuleIDs := []string{}
+	for _, id := range d.Get("managed_rule_ids").([]interface{}) {
+		if id, ok := id.(string); ok && id != "" {
+			managedRuleIDs = append(managedRuleIDs, id)
+		}
+	}
+	for _, rule := range replicationRules {
+		if rule.Id != "" && !slices.Contains(managedRuleIDs, rule.Id) {
+			managedRuleIDs = append(managedRuleIDs, rule.Id)
+		}
+	}
+
+	externalRemoteTargetARNs := []string{}
+	oldRules, newRules := d.GetChange("rule")
+	for _, rules := range []interface{}{oldRules, newRules} {
+		rules, _ := rules.([]interface{})
+		for _, rule := range rules {
+			rule, _ := rule.(map[string]interface{})
+			if arn, _ := rule["remote_target_arn"].(string); arn != "" && !slices.Contains(externalRemoteTargetARNs, arn) {
+				externalRemoteTargetARNs = append(externalRemoteTargetARNs, arn)
+			}
+		}
+	}
+
+	// The states written before created_target_arns only know the targets of their rules
+	createdRemoteTargetARNs := []string{}
+	for _, arn := range d.Get("created_target_arns").([]interface{}) {
+		if arn, ok := arn.(string); ok && arn != "" {
+			createdRemoteTargetARNs = append(createdRemoteTargetARNs, arn)
+		}
+	}
+	previousRules, _ := oldRules.([]interface{})
+	for _, rule := range previousRules {
+		rule, _ := rule.(map[string]interface{})
+		if externalARN, _ := rule["remote_target_arn"].(string); externalARN != "" {
+			continue
+		}
+		if arn, _ := rule["arn"].(string); arn != "" && !slices.Contains(createdRemoteTargetARNs, arn) {
+			createdRemoteTargetARNs = append(createdRemoteTargetARNs, arn)
+		}
+	}
+
+	cache := m.ReplicationCache
+	if cache == nil {
+		cache = newReplicationCache(m.S3Client, m.S3Admin, 0)
+	}
+
 	return &S3MinioBucketReplication{
-		MinioClient:      m.S3Client,
-		MinioAdmin:       m.S3Admin,
-		MinioBucket:      d.Get("bucket").(string),
-		ReplicationRules: replicationRules,
+		ReplicationCache:     cache,
+		ServerRelease:        m.ServerRelease,
+		MinioClient:          m.S3Client,
+		MinioAdmin:           m.S3Admin,
+		MinioBucket:          d.Get("bucket").(string),
+		ReplicationRules:     replicationRules,
+		IgnoreUnmanagedRules: d.Get("ignore_unmanaged_rules").(bool),
+		ManagedRuleIDs:       managedRuleIDs,
+		PurgeOrphanTargets:   d.Get("purge_orphan_targets").(bool),
+
+		ExternalRemoteTargetARNs: externalRemoteTargetARNs,
+		CreatedRemoteTargetARNs:  createdRemoteTargetARNs,
 	}, diags
 }
 
@@ -83,19 +173,83 @@ func NewConfig(d *schema.ResourceData) *S3MinioConfig {
 		password = d.Get("minio_secret_key").(string)
 	}
 
+	// Validated by the provider schema
+	waitForCluster, _ := time.ParseDuration(d.Get("wait_for_cluster").(string))
+	dialTimeout, _ := time.ParseDuration(d.Get("dial_timeout").(string))
+
 	return &S3MinioConfig{
-		S3HostPort:      d.Get("minio_server").(string),
-		S3Region:        d.Get("minio_region").(string),
-		S3UserAccess:    user,
-		S3UserSecret:    password,
-		S3SessionToken:  d.Get("minio_session_token").(string),
-		S3APISignature:  d.Get("minio_api_version").(string),
-		S3SSL:           d.Get("minio_ssl").(bool),
-		S3SSLCACertFile: d.Get("minio_cacert_file").(string),
-		S3SSLCertFile:   d.Get("minio_cert_file").(string),
-		S3SSLKeyFile:    d.Get("minio_key_file").(string),
-		S3SSLSkipVerify: d.Get("minio_insecure").(bool),
+		S3HostPort:            d.Get("minio_server").(string),
+		S3Region:              d.Get("minio_region").(string),
+		S3UserAccess:          user,
+		S3UserSecret:          password,
+		S3AdminAccess:         d.Get("minio_admin_user").(string),
+		S3AdminSecret:         d.Get("minio_admin_password").(string),
+		S3SessionToken:        d.Get("minio_session_token").(string),
+		S3UserFile:            d.Get("minio_user_file").(string),
+		S3PasswordFile:        d.Get("minio_password_file").(string),
+		S3SessionTokenFile:    d.Get("minio_session_token_file").(string),
+		S3Anonymous:           d.Get("minio_anonymous").(bool),
+		S3APISignature:        signatureVersion(d),
+		S3SSL:                 d.Get("minio_ssl").(bool),
+		S3SSLCACertFile:       d.Get("minio_cacert_file").(string),
+		S3SSLCertFile:         d.Get("minio_cert_file").(string),
+		S3SSLKeyFile:          d.Get("minio_key_file").(string),
+		S3SSLSkipVerify:       d.Get("minio_insecure").(bool),
+		S3WaitForCluster:      waitForCluster,
+		S3ParallelBucketLimit: d.Get("parallel_bucket_limit").(int),
+		S3RequestsPerSecond:   d.Get("requests_per_second").(float64),
+		S3RequestsBurst:       d.Get("requests_burst").(int),
+
+		S3HTTPProxy:             d.Get("http_proxy").(string),
+		S3MaxIdleConnections:    d.Get("max_idle_connections").(int),
+		S3MaxConnectionsPerHost: d.Get("max_connections_per_host").(int),
+		S3DisableKeepAlives:     d.Get("disable_keep_alives").(bool),
+		S3DialTimeout:           dialTimeout,
+
+		S3StrictBucketPolicies: d.Get("strict_bucket_policies").(bool),
+
+		S3MockStateFile: d.Get("mock_state_file").(string),
+	}
+}
+
+// signatureVersion returns the signature of the S3 requests, from signature_version or its deprecated
+// minio_api_version alias
+func signatureVersion(d *schema.ResourceData) string {
+	if v := d.Get("signature_version").(string); v != "" {
+		return v
 	}
+	if v := d.Get("minio_api_version").(string); v != "" {
+		return v
+	}
+	return "v4"
+}
+
+// loadCredentialFiles replaces the credentials with the content of the files they are sourced from, so they
+// never go through the Terraform configuration
+func (config *S3MinioConfig) loadCredentialFiles() error {
+	for _, credential := range []struct {
+		file  string
+		value *string
+	}{
+		{config.S3UserFile, &config.S3UserAccess},
+		{config.S3PasswordFile, &config.S3UserSecret},
+		{config.S3SessionTokenFile, &config.S3SessionToken},
+	} {
+		if credential.file == "" {
+			continue
+		}
+		content, err := os.ReadFile(credential.file)
+		if err != nil {
+			return fmt.Errorf("unable to read credentials file: %w", err)
+		}
+		// Files written by secret managers or editors usually end with a newline
+		value := strings.TrimSpace(string(content))
+		if value == "" {
+			return fmt.Errorf("credentials file %s is empty", credential.file)
+		}
+		*credential.value = value
+	}
+	return nil
 }
 
 // ServiceAccountConfig creates new service account config
@@ -108,6 +262,7 @@ func ServiceAccountConfig(d *schema.ResourceData, meta interface{}) *S3MinioServ
 		MinioTargetUser:  d.Get("target_user").(string),
 		MinioDisableUser: d.Get("disable_user").(bool),
 		MinioUpdateKey:   d.Get("update_secret").(bool),
+		MinioPolicy:      d.Get("policy").(string),
 	}
 }
 
@@ -116,12 +271,14 @@ func IAMUserConfig(d *schema.ResourceData, meta interface{}) *S3MinioIAMUserConf
 	m := meta.(*S3MinioClient)
 
 	return &S3MinioIAMUserConfig{
-		MinioAdmin:        m.S3Admin,
-		MinioIAMName:      d.Get("name").(string),
-		MinioSecret:       d.Get("secret").(string),
-		MinioDisableUser:  d.Get("disable_user").(bool),
-		MinioUpdateKey:    d.Get("update_secret").(bool),
-		MinioForceDestroy: d.Get("force_destroy").(bool),
+		MinioAdmin:         m.S3Admin,
+		MinioIAMName:       d.Get("name").(string),
+		MinioIAMNamePrefix: d.Get("name_prefix").(string),
+		MinioSecret:        d.Get("secret").(string),
+		MinioDisableUser:   d.Get("disable_user").(bool),
+		MinioUpdateKey:     d.Get("update_secret").(bool),
+		MinioForceDestroy:  d.Get("force_destroy").(bool),
+		MinioExpiration:    d.Get("expiration").(string),
 	}
 }
 
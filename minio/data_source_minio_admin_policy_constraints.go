@@ -0,0 +1,251 @@
+package minio
+
+import (
+	"context"
+	"log"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// policyAction is an action of the MinIO policies, available from a given release. A zero release means the
+// action is supported by every release the provider works with.
+type policyAction struct {
+	Name    string
+	Release time.Time
+}
+
+var policyS3Actions = []policyAction{
+	{Name: "s3:*"},
+	{Name: "s3:AbortMultipartUpload"},
+	{Name: "s3:BypassGovernanceRetention"},
+	{Name: "s3:CreateBucket"},
+	{Name: "s3:DeleteBucket"},
+	{Name: "s3:DeleteBucketPolicy"},
+	{Name: "s3:DeleteObject"},
+	{Name: "s3:DeleteObjectTagging"},
+	{Name: "s3:DeleteObjectVersion"},
+	{Name: "s3:DeleteObjectVersionTagging"},
+	{Name: "s3:ForceDeleteBucket"},
+	{Name: "s3:GetBucketEncryption"},
+	{Name: "s3:GetBucketLocation"},
+	{Name: "s3:GetBucketNotification"},
+	{Name: "s3:GetBucketObjectLockConfiguration"},
+	{Name: "s3:GetBucketPolicy"},
+	{Name: "s3:GetBucketPolicyStatus"},
+	{Name: "s3:GetBucketTagging"},
+	{Name: "s3:GetBucketVersioning"},
+	{Name: "s3:GetEncryptionConfiguration"},
+	{Name: "s3:GetLifecycleConfiguration"},
+	{Name: "s3:GetObject"},
+	{Name: "s3:GetObjectAttributes", Release: time.Date(2023, time.April, 13, 3, 8, 7, 0, time.UTC)},
+	{Name: "s3:GetObjectLegalHold"},
+	{Name: "s3:GetObjectRetention"},
+	{Name: "s3:GetObjectTagging"},
+	{Name: "s3:GetObjectVersion"},
+	{Name: "s3:GetObjectVersionAttributes", Release: time.Date(2023, time.April, 13, 3, 8, 7, 0, time.UTC)},
+	{Name: "s3:GetObjectVersionForReplication"},
+	{Name: "s3:GetObjectVersionTagging"},
+	{Name: "s3:GetReplicationConfiguration"},
+	{Name: "s3:HeadBucket"},
+	{Name: "s3:ListAllMyBuckets"},
+	{Name: "s3:ListBucket"},
+	{Name: "s3:ListBucketMultipartUploads"},
+	{Name: "s3:ListBucketVersions"},
+	{Name: "s3:ListMultipartUploadParts"},
+	{Name: "s3:ListenBucketNotification"},
+	{Name: "s3:ListenNotification"},
+	{Name: "s3:PutBucketEncryption"},
+	{Name: "s3:PutBucketNotification"},
+	{Name: "s3:PutBucketObjectLockConfiguration"},
+	{Name: "s3:PutBucketPolicy"},
+	{Name: "s3:PutBucketTagging"},
+	{Name: "s3:PutBucketVersioning"},
+	{Name: "s3:PutEncryptionConfiguration"},
+	{Name: "s3:PutLifecycleConfiguration"},
+	{Name: "s3:PutObject"},
+	{Name: "s3:PutObjectFanOut", Release: time.Date(2023, time.June, 2, 21, 38, 29, 0, time.UTC)},
+	{Name: "s3:PutObjectLegalHold"},
+	{Name: "s3:PutObjectRetention"},
+	{Name: "s3:PutObjectTagging"},
+	{Name: "s3:PutObjectVersionTagging"},
+	{Name: "s3:PutReplicationConfiguration"},
+	{Name: "s3:ReplicateDelete"},
+	{Name: "s3:ReplicateObject"},
+	{Name: "s3:ReplicateTags"},
+	{Name: "s3:ResetBucketReplicationState", Release: time.Date(2021, time.June, 7, 21, 40, 51, 0, time.UTC)},
+	{Name: "s3:RestoreObject"},
+}
+
+var policyAdminActions = []policyAction{
+	{Name: "admin:*"},
+	{Name: "admin:AddUserToGroup"},
+	{Name: "admin:AttachUserOrGroupPolicy"},
+	{Name: "admin:BandwidthMonitor"},
+	{Name: "admin:CancelBatchJob", Release: time.Date(2023, time.April, 7, 5, 28, 58, 0, time.UTC)},
+	{Name: "admin:ConfigUpdate"},
+	{Name: "admin:ConsoleLog"},
+	{Name: "admin:CreatePolicy"},
+	{Name: "admin:CreateServiceAccount"},
+	{Name: "admin:CreateUser"},
+	{Name: "admin:DataUsageInfo"},
+	{Name: "admin:DeletePolicy"},
+	{Name: "admin:DeleteUser"},
+	{Name: "admin:DescribeBatchJob", Release: time.Date(2022, time.October, 20, 0, 55, 9, 0, time.UTC)},
+	{Name: "admin:DisableGroup"},
+	{Name: "admin:DisableUser"},
+	{Name: "admin:EnableGroup"},
+	{Name: "admin:EnableUser"},
+	{Name: "admin:ExportBucketMetadata"},
+	{Name: "admin:ExportIAM", Release: time.Date(2022, time.August, 2, 23, 59, 16, 0, time.UTC)},
+	{Name: "admin:GetBucketQuota"},
+	{Name: "admin:GetBucketTarget"},
+	{Name: "admin:GetGroup"},
+	{Name: "admin:GetPolicy"},
+	{Name: "admin:GetUser"},
+	{Name: "admin:Heal"},
+	{Name: "admin:ImportBucketMetadata"},
+	{Name: "admin:ImportIAM", Release: time.Date(2022, time.August, 2, 23, 59, 16, 0, time.UTC)},
+	{Name: "admin:KMSCreateKey"},
+	{Name: "admin:KMSKeyStatus"},
+	{Name: "admin:ListBatchJobs", Release: time.Date(2022, time.October, 20, 0, 55, 9, 0, time.UTC)},
+	{Name: "admin:ListGroups"},
+	{Name: "admin:ListServiceAccounts"},
+	{Name: "admin:ListTemporaryAccounts"},
+	{Name: "admin:ListTier"},
+	{Name: "admin:ListUserPolicies"},
+	{Name: "admin:ListUsers"},
+	{Name: "admin:OBDInfo"},
+	{Name: "admin:Profiling"},
+	{Name: "admin:Prometheus"},
+	{Name: "admin:Rebalance", Release: time.Date(2023, time.January, 2, 9, 40, 9, 0, time.UTC)},
+	{Name: "admin:RemoveServiceAccount"},
+	{Name: "admin:RemoveUserFromGroup"},
+	{Name: "admin:ReplicationDiff"},
+	{Name: "admin:ServerInfo"},
+	{Name: "admin:ServerTrace"},
+	{Name: "admin:ServerUpdate"},
+	{Name: "admin:ServiceRestart"},
+	{Name: "admin:ServiceStop"},
+	{Name: "admin:SetBucketQuota"},
+	{Name: "admin:SetBucketTarget"},
+	{Name: "admin:SetTier"},
+	{Name: "admin:SiteReplicationAdd"},
+	{Name: "admin:SiteReplicationDisable"},
+	{Name: "admin:SiteReplicationInfo"},
+	{Name: "admin:SiteReplicationOperation"},
+	{Name: "admin:SiteReplicationRemove"},
+	{Name: "admin:SiteReplicationResync", Release: time.Date(2022, time.July, 8, 0, 5, 23, 0, time.UTC)},
+	{Name: "admin:StartBatchJob", Release: time.Date(2022, time.October, 20, 0, 55, 9, 0, time.UTC)},
+	{Name: "admin:StorageInfo"},
+	{Name: "admin:TopLocksInfo"},
+	{Name: "admin:UpdateServiceAccount"},
+}
+
+func dataSourceMinioAdminPolicyConstraints() *schema.Resource {
+	return &schema.Resource{
+		Description: "Lists the S3 and admin actions the policies accept on the server, and checks a list of actions against them.",
+		ReadContext: dataSourceMinioAdminPolicyConstraintsRead,
+
+		Schema: map[string]*schema.Schema{
+			"check_actions": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Actions to check, wildcards included, e.g. the actions of a policy document",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"s3_actions": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "S3 actions supported by the server release",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"admin_actions": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Admin actions supported by the server release",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"unknown_actions": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Actions of check_actions matching none of the supported actions",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"release_tag": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Release tag of the server the actions are listed for. Empty when it cannot be read, every action known to the provider is listed then",
+			},
+		},
+	}
+}
+
+func dataSourceMinioAdminPolicyConstraintsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	m := meta.(*S3MinioClient)
+	endpoint := m.S3Client.EndpointURL().Host
+
+	var release time.Time
+	if m.ServerRelease != nil {
+		var err error
+		if release, err = m.ServerRelease.Get(ctx); err != nil {
+			log.Printf("[WARN] Unable to read the server version of %s, listing every known action: %v", endpoint, err)
+		}
+	}
+
+	s3Actions := policyActionsForRelease(policyS3Actions, release)
+	adminActions := policyActionsForRelease(policyAdminActions, release)
+
+	checkActions := []string{}
+	for _, action := range d.Get("check_actions").([]interface{}) {
+		if action, ok := action.(string); ok {
+			checkActions = append(checkActions, action)
+		}
+	}
+
+	d.SetId(endpoint)
+	_ = d.Set("s3_actions", s3Actions)
+	_ = d.Set("admin_actions", adminActions)
+	_ = d.Set("unknown_actions", unknownPolicyActions(checkActions, append(append([]string{}, s3Actions...), adminActions...)))
+	_ = d.Set("release_tag", minioReleaseTag(release))
+
+	return nil
+}
+
+// policyActionsForRelease returns the sorted names of the actions available in the release. Every action is
+// returned for an unknown release.
+func policyActionsForRelease(actions []policyAction, release time.Time) []string {
+	names := []string{}
+	for _, action := range actions {
+		if release.IsZero() || !release.Before(action.Release) {
+			names = append(names, action.Name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// unknownPolicyActions returns the actions, possibly holding wildcards, matching none of the supported actions.
+// Like the server, the comparison ignores the case.
+func unknownPolicyActions(actions []string, supported []string) []string {
+	unknown := []string{}
+	for _, action := range actions {
+		pattern := strings.ToLower(action)
+
+		found := false
+		for _, name := range supported {
+			if matched, err := path.Match(pattern, strings.ToLower(name)); err == nil && matched {
+				found = true
+				break
+			}
+		}
+		if !found {
+			unknown = append(unknown, action)
+		}
+	}
+	return unknown
+}
@@ -0,0 +1,55 @@
+package minio
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccMinioDataSourceAdminPolicyConstraints_basic(t *testing.T) {
+	dataSourceName := "data.minio_admin_policy_constraints.constraints"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "minio_admin_policy_constraints" "constraints" {
+  check_actions = ["s3:ListBucket", "s3:ListBuckets", "s3:Get*", "admin:ServerInfo"]
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckTypeSetElemAttr(dataSourceName, "s3_actions.*", "s3:ListBucket"),
+					resource.TestCheckTypeSetElemAttr(dataSourceName, "admin_actions.*", "admin:ServerInfo"),
+					resource.TestCheckResourceAttr(dataSourceName, "unknown_actions.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "unknown_actions.0", "s3:ListBuckets"),
+				),
+			},
+		},
+	})
+}
+
+func TestPolicyActionsForRelease(t *testing.T) {
+	actions := []policyAction{
+		{Name: "s3:GetObject"},
+		{Name: "s3:PutObjectFanOut", Release: time.Date(2023, time.June, 2, 0, 0, 0, 0, time.UTC)},
+	}
+
+	if got := policyActionsForRelease(actions, parseMinioRelease("RELEASE.2023-03-20T20-16-18Z")); !reflect.DeepEqual(got, []string{"s3:GetObject"}) {
+		t.Errorf("unexpected actions for an older release: %v", got)
+	}
+	if got := policyActionsForRelease(actions, time.Time{}); len(got) != 2 {
+		t.Errorf("expected every action for an unknown release, got %v", got)
+	}
+}
+
+func TestUnknownPolicyActions(t *testing.T) {
+	supported := policyActionsForRelease(policyS3Actions, time.Time{})
+	got := unknownPolicyActions([]string{"s3:ListBucket", "S3:getobject", "s3:Put*", "s3:ListBuckets", "s3:*Bucket", "s3:Nothing*"}, supported)
+	if want := []string{"s3:ListBuckets", "s3:Nothing*"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,203 @@
+package minio
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/minio/madmin-go"
+)
+
+// poolCapacity is the usable capacity of a pool, the parity share of its drives excluded, and the status of its drives
+type poolCapacity struct {
+	TotalSpace uint64
+	UsedSpace  uint64
+	FreeSpace  uint64
+
+	DrivesOnline  int
+	DrivesOffline int
+	DrivesHealing int
+}
+
+func dataSourceMinioAdminPools() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceMinioAdminPoolsRead,
+
+		Schema: map[string]*schema.Schema{
+			"pools": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"pool": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"last_update": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"decommission": poolDecommissionStatusSchema(),
+						"total_space": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Usable capacity of the pool in bytes, the parity share of its drives excluded",
+						},
+						"used_space": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Usable space used in the pool in bytes",
+						},
+						"free_space": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Usable space left in the pool in bytes",
+						},
+						"drives_online": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"drives_offline": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Number of drives of the pool which are not online, whatever their state",
+						},
+						"drives_healing": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Number of online drives of the pool being healed",
+						},
+					},
+				},
+			},
+			"total_space": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Usable capacity of the cluster in bytes",
+			},
+			"used_space": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Usable space used in the cluster in bytes",
+			},
+			"free_space": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Usable space left in the cluster in bytes",
+			},
+		},
+	}
+}
+
+func dataSourceMinioAdminPoolsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	admin := meta.(*S3MinioClient).S3Admin
+
+	statuses, err := admin.ListPoolsStatus(ctx)
+	if err != nil {
+		return NewResourceError("unable to list pools", "pools", err)
+	}
+
+	storage, err := admin.StorageInfo(ctx)
+	if err != nil {
+		return NewResourceError("unable to read pools capacity", "pools", err)
+	}
+	capacities := storagePoolCapacities(storage)
+
+	total := poolCapacity{}
+	pools := make([]map[string]interface{}, 0, len(statuses))
+	for _, status := range statuses {
+		capacity := capacities[status.ID]
+		if capacity == nil {
+			capacity = &poolCapacity{}
+		}
+		total.TotalSpace += capacity.TotalSpace
+		total.UsedSpace += capacity.UsedSpace
+		total.FreeSpace += capacity.FreeSpace
+
+		pools = append(pools, map[string]interface{}{
+			"id":             status.ID,
+			"pool":           status.CmdLine,
+			"last_update":    status.LastUpdate.Format(time.RFC3339),
+			"decommission":   flattenPoolDecommissionInfo(status.Decommission),
+			"total_space":    int(capacity.TotalSpace),
+			"used_space":     int(capacity.UsedSpace),
+			"free_space":     int(capacity.FreeSpace),
+			"drives_online":  capacity.DrivesOnline,
+			"drives_offline": capacity.DrivesOffline,
+			"drives_healing": capacity.DrivesHealing,
+		})
+	}
+
+	if err := d.Set("pools", pools); err != nil {
+		return NewResourceError("unable to list pools", "pools", err)
+	}
+	_ = d.Set("total_space", int(total.TotalSpace))
+	_ = d.Set("used_space", int(total.UsedSpace))
+	_ = d.Set("free_space", int(total.FreeSpace))
+
+	d.SetId(meta.(*S3MinioClient).S3Client.EndpointURL().Host)
+
+	return nil
+}
+
+// storagePoolCapacities returns the capacity of the pools by index. The space of the drives is reduced to the share
+// of the data drives of the standard storage class, which is what objects can use. The offline drives report no
+// space.
+func storagePoolCapacities(storage madmin.StorageInfo) map[int]*poolCapacity {
+	capacities := map[int]*poolCapacity{}
+	for _, disk := range storage.Disks {
+		capacity, ok := capacities[disk.PoolIndex]
+		if !ok {
+			capacity = &poolCapacity{}
+			capacities[disk.PoolIndex] = capacity
+		}
+
+		switch {
+		case disk.State != madmin.DriveStateOk:
+			capacity.DrivesOffline++
+			continue
+		case disk.Healing:
+			capacity.DrivesHealing++
+		}
+		capacity.DrivesOnline++
+
+		capacity.TotalSpace += disk.TotalSpace
+		capacity.UsedSpace += disk.UsedSpace
+		capacity.FreeSpace += disk.AvailableSpace
+	}
+
+	backend := storage.Backend
+	for idx, capacity := range capacities {
+		if backend.Type != madmin.Erasure || idx < 0 || idx >= len(backend.StandardSCData) {
+			continue
+		}
+		data := uint64(backend.StandardSCData[idx])
+		drives := data + uint64(backend.StandardSCParity)
+		if data == 0 {
+			continue
+		}
+		capacity.TotalSpace = capacity.TotalSpace * data / drives
+		capacity.UsedSpace = capacity.UsedSpace * data / drives
+		capacity.FreeSpace = capacity.FreeSpace * data / drives
+	}
+
+	return capacities
+}
+
+// clusterFreeSpace returns the usable space left in the cluster and its usable capacity, in bytes
+func clusterFreeSpace(ctx context.Context, admin *madmin.AdminClient) (free uint64, total uint64, err error) {
+	storage, err := admin.StorageInfo(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+	for _, capacity := range storagePoolCapacities(storage) {
+		free += capacity.FreeSpace
+		total += capacity.TotalSpace
+	}
+	return free, total, nil
+}
@@ -0,0 +1,143 @@
+package minio
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/minio/madmin-go"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+func TestAccMinioDataSourceAdminPools_basic(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMinioAdminPoolsConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.minio_admin_pools.test", "pools.#", "1"),
+					resource.TestCheckResourceAttr("data.minio_admin_pools.test", "pools.0.id", "0"),
+					resource.TestCheckResourceAttrSet("data.minio_admin_pools.test", "pools.0.pool"),
+					resource.TestCheckResourceAttr("data.minio_admin_pools.test", "pools.0.decommission.#", "0"),
+					resource.TestCheckResourceAttrSet("data.minio_admin_pools.test", "pools.0.free_space"),
+					resource.TestCheckResourceAttr("data.minio_admin_pools.test", "pools.0.drives_offline", "0"),
+					resource.TestCheckResourceAttrSet("data.minio_admin_pools.test", "total_space"),
+				),
+			},
+		},
+	})
+}
+
+func TestPoolDecommissionState(t *testing.T) {
+	cases := map[string]*madmin.PoolDecommissionInfo{
+		"":                       nil,
+		poolDecommissionActive:   {},
+		poolDecommissionComplete: {Complete: true},
+		poolDecommissionFailed:   {Failed: true},
+		poolDecommissionCanceled: {Canceled: true},
+	}
+
+	for expected, info := range cases {
+		if state := poolDecommissionState(info); state != expected {
+			t.Errorf("poolDecommissionState(%v) = %q, want %q", info, state, expected)
+		}
+	}
+}
+
+func TestPoolDecommissionNotFound(t *testing.T) {
+	canceled := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		pool := r.URL.Query().Get("pool")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/pools/cancel"):
+			canceled = true
+		case pool == "gone":
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, `{"Code":"InvalidArgument","Message":"specified pool '%s' not found, please specify a valid pool"}`, pool)
+		default:
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, `{"Code":"AccessDenied","Message":"Access Denied."}`)
+		}
+	}))
+	defer server.Close()
+
+	admin, err := madmin.NewWithOptions(strings.TrimPrefix(server.URL, "http://"), &madmin.Options{
+		Creds: credentials.NewStaticV4("minio", "minio123", ""),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	meta := &S3MinioClient{S3Admin: admin}
+
+	d := resourceMinioAdminPoolDecommission().TestResourceData()
+	d.SetId("gone")
+	if diags := minioReadPoolDecommission(context.Background(), d, meta); diags.HasError() {
+		t.Errorf("expected a removed pool to be read without error, got %v", diags)
+	}
+	if d.Id() != "" {
+		t.Errorf("expected a removed pool to be removed from state, got %q", d.Id())
+	}
+
+	d = resourceMinioAdminPoolDecommission().TestResourceData()
+	d.SetId("gone")
+	_ = d.Set("cancel_on_destroy", true)
+	if diags := minioDeletePoolDecommission(context.Background(), d, meta); diags.HasError() {
+		t.Errorf("expected a removed pool to be destroyed without error, got %v", diags)
+	}
+	if canceled {
+		t.Error("expected no decommission to be canceled on a removed pool")
+	}
+
+	d.SetId("denied")
+	if diags := minioReadPoolDecommission(context.Background(), d, meta); !diags.HasError() {
+		t.Error("expected the other errors to be reported")
+	}
+}
+
+func TestStoragePoolCapacities(t *testing.T) {
+	const gib = 1 << 30
+	drive := func(pool int, state string, healing bool) madmin.Disk {
+		return madmin.Disk{PoolIndex: pool, State: state, Healing: healing, TotalSpace: 100 * gib, UsedSpace: 40 * gib, AvailableSpace: 60 * gib}
+	}
+	storage := madmin.StorageInfo{
+		Disks: []madmin.Disk{
+			drive(0, madmin.DriveStateOk, false),
+			drive(0, madmin.DriveStateOk, false),
+			drive(0, madmin.DriveStateOk, true),
+			drive(0, madmin.DriveStateOffline, false),
+			drive(1, madmin.DriveStateOk, false),
+			drive(1, madmin.DriveStateOk, false),
+		},
+		Backend: madmin.BackendInfo{
+			Type:             madmin.Erasure,
+			StandardSCData:   []int{2, 1},
+			StandardSCParity: 2,
+		},
+	}
+
+	capacities := storagePoolCapacities(storage)
+	expected := map[int]poolCapacity{
+		// Half of the 3 online drives hold data
+		0: {TotalSpace: 150 * gib, UsedSpace: 60 * gib, FreeSpace: 90 * gib, DrivesOnline: 3, DrivesOffline: 1, DrivesHealing: 1},
+		// A third of the 2 drives hold data
+		1: {TotalSpace: 200 * gib / 3, UsedSpace: 80 * gib / 3, FreeSpace: 120 * gib / 3, DrivesOnline: 2},
+	}
+	if len(capacities) != len(expected) {
+		t.Fatalf("expected %d pools, got %d", len(expected), len(capacities))
+	}
+	for idx, want := range expected {
+		if got := capacities[idx]; got == nil || *got != want {
+			t.Errorf("pool %d: expected %+v, got %+v", idx, want, got)
+		}
+	}
+}
+
+const testAccMinioAdminPoolsConfig = `
+data "minio_admin_pools" "test" {}
+`
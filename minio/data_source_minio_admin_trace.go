@@ -0,0 +1,185 @@
+package minio
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/minio/madmin-go"
+)
+
+// adminTraceMaxDuration bounds the capture, which blocks the plan while it runs
+const adminTraceMaxDuration = 5 * time.Minute
+
+// adminTraceEntry is a call captured by minio_admin_trace. Headers and bodies are left out, as they hold
+// credentials and object content.
+type adminTraceEntry struct {
+	Time       string `json:"time"`
+	NodeName   string `json:"node_name"`
+	API        string `json:"api"`
+	Method     string `json:"method,omitempty"`
+	Path       string `json:"path"`
+	Query      string `json:"query,omitempty"`
+	StatusCode int    `json:"status_code,omitempty"`
+	Duration   string `json:"duration"`
+	Client     string `json:"client,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+func dataSourceMinioAdminTrace() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceMinioAdminTraceRead,
+
+		Schema: map[string]*schema.Schema{
+			"duration": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "10s",
+				ValidateDiagFunc: validateAdminTraceDuration,
+				Description:      "How long to capture the calls for, at most 5m",
+			},
+			"api_names": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "S3 APIs to capture, e.g. PutObject or s3.PutObject (default: every API)",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"only_errors": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Capture only the calls which failed",
+			},
+			"max_entries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1000,
+				ValidateFunc: validation.IntBetween(1, 100000),
+				Description:  "Stop the capture once this many calls were captured",
+			},
+			"output_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Local file to write the captured calls to, one JSON object per line. The entries attribute is then left empty",
+			},
+			"entries": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Captured calls, one JSON object per line, without their headers and bodies",
+			},
+			"entry_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of calls captured",
+			},
+		},
+	}
+}
+
+func dataSourceMinioAdminTraceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	admin := meta.(*S3MinioClient).S3Admin
+
+	// Validated by the schema
+	duration, _ := time.ParseDuration(d.Get("duration").(string))
+	apiNames := []string{}
+	for _, name := range d.Get("api_names").(*schema.Set).List() {
+		apiNames = append(apiNames, name.(string))
+	}
+	maxEntries := d.Get("max_entries").(int)
+
+	traceCtx, cancel := context.WithTimeout(ctx, duration)
+	defer cancel()
+
+	log.Printf("[DEBUG] Capturing the S3 calls for %s", duration)
+	var lines []string
+	for info := range admin.ServiceTrace(traceCtx, madmin.ServiceTraceOpts{S3: true, OnlyErrors: d.Get("only_errors").(bool)}) {
+		if info.Err != nil {
+			if traceCtx.Err() != nil {
+				break
+			}
+			return NewResourceError("unable to capture the trace", "minio_admin_trace", info.Err)
+		}
+		if !adminTraceAPIMatches(info.Trace.FuncName, apiNames) {
+			continue
+		}
+
+		line, err := json.Marshal(newAdminTraceEntry(info.Trace))
+		if err != nil {
+			return NewResourceError("unable to capture the trace", "minio_admin_trace", err)
+		}
+		lines = append(lines, string(line))
+		if len(lines) >= maxEntries {
+			cancel()
+			break
+		}
+	}
+
+	entries := ""
+	if len(lines) != 0 {
+		entries = strings.Join(lines, "\n") + "\n"
+	}
+
+	if outputFile := d.Get("output_file").(string); outputFile != "" {
+		if err := os.WriteFile(outputFile, []byte(entries), 0o600); err != nil {
+			return NewResourceError("unable to write the trace", outputFile, err)
+		}
+		entries = ""
+	}
+
+	d.SetId(time.Now().UTC().Format(time.RFC3339Nano))
+	_ = d.Set("entries", entries)
+	_ = d.Set("entry_count", len(lines))
+
+	return nil
+}
+
+func newAdminTraceEntry(trace madmin.TraceInfo) adminTraceEntry {
+	entry := adminTraceEntry{
+		Time:     trace.Time.UTC().Format(time.RFC3339Nano),
+		NodeName: trace.NodeName,
+		API:      trace.FuncName,
+		Path:     trace.Path,
+		Duration: trace.Duration.String(),
+		Error:    trace.Error,
+	}
+	if trace.HTTP != nil {
+		entry.Method = trace.HTTP.ReqInfo.Method
+		entry.Query = trace.HTTP.ReqInfo.RawQuery
+		entry.StatusCode = trace.HTTP.RespInfo.StatusCode
+		entry.Client = trace.HTTP.ReqInfo.Client
+	}
+	return entry
+}
+
+// adminTraceAPIMatches returns whether a traced function, such as s3.PutObject, is one of the APIs to capture,
+// given with or without their s3. prefix
+func adminTraceAPIMatches(funcName string, apiNames []string) bool {
+	if len(apiNames) == 0 {
+		return true
+	}
+	for _, name := range apiNames {
+		if strings.EqualFold(funcName, name) || strings.EqualFold(strings.TrimPrefix(funcName, "s3."), name) {
+			return true
+		}
+	}
+	return false
+}
+
+func validateAdminTraceDuration(v interface{}, p cty.Path) diag.Diagnostics {
+	duration, err := time.ParseDuration(v.(string))
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("invalid duration %q: %w", v.(string), err))
+	}
+	if duration <= 0 || duration > adminTraceMaxDuration {
+		return diag.Errorf("duration must be positive and at most %s", adminTraceMaxDuration)
+	}
+	return nil
+}
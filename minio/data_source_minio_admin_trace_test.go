@@ -0,0 +1,61 @@
+package minio
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccMinioDataSourceAdminTrace_basic(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "minio_admin_trace" "trace" {
+  duration    = "2s"
+  api_names   = ["ListBuckets"]
+  max_entries = 10
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.minio_admin_trace.trace", "entry_count"),
+					resource.TestCheckResourceAttrSet("data.minio_admin_trace.trace", "id"),
+				),
+			},
+		},
+	})
+}
+
+func TestAdminTraceAPIMatches(t *testing.T) {
+	cases := []struct {
+		funcName string
+		apiNames []string
+		expected bool
+	}{
+		{"s3.PutObject", nil, true},
+		{"s3.PutObject", []string{"PutObject"}, true},
+		{"s3.PutObject", []string{"s3.putobject"}, true},
+		{"s3.GetObject", []string{"PutObject", "ListObjectsV2"}, false},
+	}
+	for _, c := range cases {
+		if matches := adminTraceAPIMatches(c.funcName, c.apiNames); matches != c.expected {
+			t.Errorf("%s in %v: expected %t, got %t", c.funcName, c.apiNames, c.expected, matches)
+		}
+	}
+}
+
+func TestValidateAdminTraceDuration(t *testing.T) {
+	for _, valid := range []string{"1s", "30s", "5m"} {
+		if diags := validateAdminTraceDuration(valid, cty.Path{}); diags.HasError() {
+			t.Errorf("expected %s to be valid, got %v", valid, diags)
+		}
+	}
+	for _, invalid := range []string{"", "0s", "-1s", "6m", "ten seconds"} {
+		if diags := validateAdminTraceDuration(invalid, cty.Path{}); !diags.HasError() {
+			t.Errorf("expected %q to be invalid", invalid)
+		}
+	}
+}
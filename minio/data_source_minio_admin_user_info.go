@@ -0,0 +1,129 @@
+package minio
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/minio/madmin-go"
+)
+
+func dataSourceMinioAdminUserInfo() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceMinioAdminUserInfoRead,
+
+		Schema: map[string]*schema.Schema{
+			"access_key": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+				Description:  "Access key of the user or of the service account",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Status of the account, either enabled or disabled",
+			},
+			"member_of": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Groups the user is a member of, those of its parent user for a service account",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"policies": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Policies attached to the user, those of its parent user for a service account without its own policy",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"service_account": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the access key is the one of a service account",
+			},
+			"parent_user": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "User owning the service account",
+			},
+			"service_account_policy": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Policy restricting the service account, empty when it inherits the policies of its parent user",
+			},
+			"updated_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Last update of the user",
+			},
+		},
+	}
+}
+
+func dataSourceMinioAdminUserInfoRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	admin := meta.(*S3MinioClient).S3Admin
+	accessKey := d.Get("access_key").(string)
+
+	userName := accessKey
+	serviceAccount := false
+	var serviceAccountInfo madmin.InfoServiceAccountResp
+
+	info, err := admin.GetUserInfo(ctx, accessKey)
+	if err != nil {
+		if !isMinioNoSuchUser(err) {
+			return NewResourceError("unable to read user info", accessKey, err)
+		}
+
+		// Service accounts are not users, their groups and policies are those of their parent
+		serviceAccountInfo, err = admin.InfoServiceAccount(ctx, accessKey)
+		if err != nil {
+			return NewResourceError("unable to read user info", accessKey, err)
+		}
+		serviceAccount = true
+		userName = serviceAccountInfo.ParentUser
+
+		if info, err = admin.GetUserInfo(ctx, userName); err != nil {
+			return NewResourceError("unable to read user info", userName, err)
+		}
+	}
+
+	policies := []string{}
+	if !serviceAccount || serviceAccountInfo.ImpliedPolicy {
+		policies = splitPolicyNames(info.PolicyName)
+		sort.Strings(policies)
+	}
+	memberOf := append([]string{}, info.MemberOf...)
+	sort.Strings(memberOf)
+
+	status := string(info.Status)
+	updatedAt := ""
+	if serviceAccount {
+		status = serviceAccountInfo.AccountStatus
+	} else if !info.UpdatedAt.IsZero() {
+		updatedAt = info.UpdatedAt.UTC().Format(time.RFC3339)
+	}
+
+	servicePolicy := ""
+	if serviceAccount && !serviceAccountInfo.ImpliedPolicy {
+		servicePolicy = serviceAccountInfo.Policy
+	}
+
+	_ = d.Set("status", status)
+	if err := d.Set("member_of", memberOf); err != nil {
+		return NewResourceError("unable to read user info", accessKey, err)
+	}
+	if err := d.Set("policies", policies); err != nil {
+		return NewResourceError("unable to read user info", accessKey, err)
+	}
+	_ = d.Set("service_account", serviceAccount)
+	_ = d.Set("parent_user", serviceAccountInfo.ParentUser)
+	_ = d.Set("service_account_policy", servicePolicy)
+	_ = d.Set("updated_at", updatedAt)
+
+	d.SetId(accessKey)
+
+	return nil
+}
@@ -0,0 +1,74 @@
+package minio
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccMinioDataSourceAdminUserInfo_basic(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMinioAdminUserInfoDataSourceConfig(name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.minio_admin_user_info.user", "status", "enabled"),
+					resource.TestCheckResourceAttr("data.minio_admin_user_info.user", "service_account", "false"),
+					resource.TestCheckResourceAttr("data.minio_admin_user_info.user", "policies.#", "1"),
+					resource.TestCheckResourceAttr("data.minio_admin_user_info.user", "policies.0", "readwrite"),
+					resource.TestCheckResourceAttr("data.minio_admin_user_info.user", "member_of.#", "1"),
+					resource.TestCheckResourceAttr("data.minio_admin_user_info.user", "member_of.0", name),
+					resource.TestCheckResourceAttr("data.minio_admin_user_info.service_account", "service_account", "true"),
+					resource.TestCheckResourceAttr("data.minio_admin_user_info.service_account", "parent_user", name),
+					resource.TestCheckResourceAttr("data.minio_admin_user_info.service_account", "policies.0", "readwrite"),
+					resource.TestCheckResourceAttr("data.minio_admin_user_info.service_account", "service_account_policy", ""),
+				),
+			},
+		},
+	})
+}
+
+func testAccMinioAdminUserInfoDataSourceConfig(name string) string {
+	return fmt.Sprintf(`
+resource "minio_iam_user" "user" {
+  name = %[1]q
+}
+
+resource "minio_iam_group" "group" {
+  name = %[1]q
+}
+
+resource "minio_iam_group_membership" "group" {
+  name  = %[1]q
+  group = minio_iam_group.group.name
+  users = [minio_iam_user.user.name]
+}
+
+resource "minio_iam_user_policy_attachment" "user" {
+  user_name   = minio_iam_user.user.id
+  policy_name = "readwrite"
+}
+
+resource "minio_iam_service_account" "service_account" {
+  target_user = minio_iam_user.user.name
+}
+
+data "minio_admin_user_info" "user" {
+  access_key = minio_iam_user.user.id
+
+  depends_on = [minio_iam_group_membership.group, minio_iam_user_policy_attachment.user]
+}
+
+data "minio_admin_user_info" "service_account" {
+  access_key = minio_iam_service_account.service_account.access_key
+
+  depends_on = [minio_iam_user_policy_attachment.user]
+}
+`, name)
+}
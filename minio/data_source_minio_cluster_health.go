@@ -0,0 +1,86 @@
+package minio
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/minio/madmin-go"
+)
+
+func dataSourceMinioClusterHealth() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceMinioClusterHealthRead,
+
+		Schema: map[string]*schema.Schema{
+			"live": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the endpoint answers its liveness check",
+			},
+			"ready": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the endpoint is ready to serve requests",
+			},
+			"healthy": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the cluster has its write quorum",
+			},
+			"version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Version of the oldest server of the cluster, e.g. 2023-03-20T20:16:18Z. Empty when it cannot be read, which requires admin permissions",
+			},
+			"release_tag": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Release tag of the oldest server of the cluster, e.g. RELEASE.2023-03-20T20-16-18Z. Release tags can be compared as strings",
+			},
+		},
+	}
+}
+
+func dataSourceMinioClusterHealthRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	m := meta.(*S3MinioClient)
+	endpoint := m.S3Client.EndpointURL().Host
+
+	live := minioHealthAlive(ctx, m.S3Health, madmin.AliveOpts{})
+	ready := minioHealthAlive(ctx, m.S3Health, madmin.AliveOpts{Readiness: true})
+
+	health, err := m.S3Health.Healthy(ctx, madmin.HealthOpts{})
+	if err != nil {
+		log.Printf("[WARN] Unable to check the health of %s: %v", endpoint, err)
+	}
+
+	version, releaseTag := "", ""
+	if release, err := m.ServerRelease.Get(ctx); err != nil {
+		log.Printf("[WARN] Unable to read the server version of %s: %v", endpoint, err)
+	} else if !release.IsZero() {
+		version, releaseTag = release.UTC().Format(time.RFC3339), minioReleaseTag(release)
+	}
+
+	d.SetId(endpoint)
+	_ = d.Set("live", live)
+	_ = d.Set("ready", ready)
+	_ = d.Set("healthy", health.Healthy)
+	_ = d.Set("version", version)
+	_ = d.Set("release_tag", releaseTag)
+
+	return nil
+}
+
+// minioHealthAlive tells whether the endpoint answers its liveness, or readiness, check
+func minioHealthAlive(ctx context.Context, client *madmin.AnonymousClient, opts madmin.AliveOpts) bool {
+	alive := false
+	for result := range client.Alive(ctx, opts) {
+		if result.Error != nil {
+			log.Printf("[WARN] Health check of %s failed: %v", result.Endpoint, result.Error)
+		}
+		alive = result.Online
+	}
+	return alive
+}
@@ -0,0 +1,26 @@
+package minio
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccMinioDataSourceClusterHealth_basic(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "minio_cluster_health" "health" {}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.minio_cluster_health.health", "live", "true"),
+					resource.TestCheckResourceAttr("data.minio_cluster_health.health", "ready", "true"),
+					resource.TestCheckResourceAttr("data.minio_cluster_health.health", "healthy", "true"),
+					resource.TestMatchResourceAttr("data.minio_cluster_health.health", "release_tag", regexp.MustCompile(`^RELEASE\.\d{4}-\d{2}-\d{2}T\d{2}-\d{2}-\d{2}Z$`)),
+				),
+			},
+		},
+	})
+}
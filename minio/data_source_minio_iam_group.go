@@ -0,0 +1,81 @@
+package minio
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceMinioIAMGroup() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceMinioIAMGroupRead,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+				Description:  "Name of the group",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Status of the group, either enabled or disabled",
+			},
+			"members": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Users member of the group, sorted",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"policies": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Policies attached to the group, sorted",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"updated_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Last update of the group, empty when the server does not report it",
+			},
+		},
+	}
+}
+
+func dataSourceMinioIAMGroupRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	admin := meta.(*S3MinioClient).S3Admin
+	name := d.Get("name").(string)
+
+	group, err := admin.GetGroupDescription(ctx, name)
+	if err != nil {
+		return NewResourceError("unable to read group", name, err)
+	}
+
+	members := append([]string{}, group.Members...)
+	sort.Strings(members)
+	policies := splitPolicyNames(group.Policy)
+	sort.Strings(policies)
+
+	updatedAt := ""
+	if !group.UpdatedAt.IsZero() {
+		updatedAt = group.UpdatedAt.UTC().Format(time.RFC3339)
+	}
+
+	_ = d.Set("status", group.Status)
+	if err := d.Set("members", members); err != nil {
+		return NewResourceError("unable to read group", name, err)
+	}
+	if err := d.Set("policies", policies); err != nil {
+		return NewResourceError("unable to read group", name, err)
+	}
+	_ = d.Set("updated_at", updatedAt)
+
+	d.SetId(name)
+
+	return nil
+}
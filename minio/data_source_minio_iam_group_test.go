@@ -0,0 +1,65 @@
+package minio
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccMinioDataSourceIAMGroup_basic(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-acc-test")
+	dataSourceName := "data.minio_iam_group.group"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMinioIAMGroupDataSourceConfig(name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "status", "enabled"),
+					resource.TestCheckResourceAttr(dataSourceName, "members.#", "2"),
+					resource.TestCheckResourceAttr(dataSourceName, "members.0", name+"-a"),
+					resource.TestCheckResourceAttr(dataSourceName, "members.1", name+"-b"),
+					resource.TestCheckResourceAttr(dataSourceName, "policies.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "policies.0", "readonly"),
+				),
+			},
+		},
+	})
+}
+
+func testAccMinioIAMGroupDataSourceConfig(name string) string {
+	return fmt.Sprintf(`
+resource "minio_iam_user" "a" {
+  name = "%[1]s-a"
+}
+
+resource "minio_iam_user" "b" {
+  name = "%[1]s-b"
+}
+
+resource "minio_iam_group" "group" {
+  name = %[1]q
+}
+
+resource "minio_iam_group_membership" "group" {
+  name  = %[1]q
+  group = minio_iam_group.group.name
+  users = [minio_iam_user.b.name, minio_iam_user.a.name]
+}
+
+resource "minio_iam_group_policy_attachment" "group" {
+  group_name  = minio_iam_group.group.name
+  policy_name = "readonly"
+}
+
+data "minio_iam_group" "group" {
+  name = minio_iam_group.group.name
+
+  depends_on = [minio_iam_group_membership.group, minio_iam_group_policy_attachment.group]
+}
+`, name)
+}
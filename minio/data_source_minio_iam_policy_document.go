@@ -13,6 +13,13 @@ import (
 
 var dataSourceMinioIAMPolicyDocumentReplacer = strings.NewReplacer("&{", "${")
 
+// dataSourceMinioIAMPolicyDocumentPrincipalKeys maps the principals types to the condition key identifying them
+var dataSourceMinioIAMPolicyDocumentPrincipalKeys = map[string]string{
+	"jwt":                    "jwt:preferred_username",
+	"ldap":                   "ldap:user",
+	"service_account_parent": "aws:username",
+}
+
 func dataSourceMinioIAMPolicyDocument() *schema.Resource {
 	stringSet := &schema.Schema{
 		Type:     schema.TypeSet,
@@ -55,11 +62,29 @@ func dataSourceMinioIAMPolicyDocument() *schema.Resource {
 						},
 						"actions":   stringSet,
 						"resources": stringSet,
+						"not_actions": {
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Description: "Actions the statement applies to all but, e.g. s3:DeleteObject to allow everything except deletions. Cannot be combined with actions",
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"not_resources": {
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Description: "Resources the statement applies to all but. Cannot be combined with resources",
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
 						"principal": {
 							Type:         schema.TypeString,
 							Optional:     true,
 							ValidateFunc: validation.StringInSlice([]string{"*"}, false),
 						},
+						"principals":     dataSourceMinioIAMPolicyDocumentPrincipalsSchema("Identities the statement applies to, rendered as conditions on the OpenID, LDAP or service account parent identity"),
+						"not_principals": dataSourceMinioIAMPolicyDocumentPrincipalsSchema("Identities the statement applies to all but, rendered as negated conditions on the OpenID, LDAP or service account parent identity"),
 						"condition": {
 							Type:     schema.TypeSet,
 							Optional: true,
@@ -99,6 +124,35 @@ func dataSourceMinioIAMPolicyDocument() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"example_json": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Policy document with its policy variables, such as ${aws:username} or ${jwt:sub}, replaced with example values",
+			},
+		},
+	}
+}
+
+func dataSourceMinioIAMPolicyDocumentPrincipalsSchema(description string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeSet,
+		Optional:    true,
+		Description: description,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"type": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringInSlice([]string{"jwt", "ldap", "service_account_parent"}, false),
+				},
+				"identifiers": {
+					Type:     schema.TypeSet,
+					Required: true,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+				},
+			},
 		},
 	}
 }
@@ -147,6 +201,13 @@ func dataSourceMinioIAMPolicyDocumentRead(d *schema.ResourceData, meta interface
 				stmt.Actions = minioDecodePolicyStringList(actions)
 			}
 
+			if notActions := cfgStmt["not_actions"].(*schema.Set).List(); len(notActions) > 0 {
+				if stmt.Actions != nil {
+					return fmt.Errorf("statement %d: actions and not_actions cannot be both set", i)
+				}
+				stmt.NotActions = minioDecodePolicyStringList(notActions)
+			}
+
 			if resources := cfgStmt["resources"].(*schema.Set).List(); len(resources) > 0 {
 				var err error
 				stmt.Resources, err = dataSourceMinioIAMPolicyDocumentReplaceVarsInList(
@@ -157,6 +218,19 @@ func dataSourceMinioIAMPolicyDocumentRead(d *schema.ResourceData, meta interface
 				}
 			}
 
+			if notResources := cfgStmt["not_resources"].(*schema.Set).List(); len(notResources) > 0 {
+				if stmt.Resources != nil {
+					return fmt.Errorf("statement %d: resources and not_resources cannot be both set", i)
+				}
+				var err error
+				stmt.NotResources, err = dataSourceMinioIAMPolicyDocumentReplaceVarsInList(
+					minioDecodePolicyStringList(notResources), doc.Version,
+				)
+				if err != nil {
+					return fmt.Errorf("error reading not_resources: %s", err)
+				}
+			}
+
 			if principal := cfgStmt["principal"].(string); principal != "" {
 				stmt.Principal = principal
 			}
@@ -169,6 +243,17 @@ func dataSourceMinioIAMPolicyDocumentRead(d *schema.ResourceData, meta interface
 				}
 			}
 
+			for key, negate := range map[string]bool{"principals": false, "not_principals": true} {
+				if principals := cfgStmt[key].(*schema.Set).List(); len(principals) > 0 {
+					conditions, _ := stmt.Conditions.(ConditionMap)
+					if conditions == nil {
+						conditions = make(ConditionMap)
+					}
+					dataSourceMinioIAMPolicyDocumentAddPrincipals(conditions, principals, negate)
+					stmt.Conditions = conditions
+				}
+			}
+
 			stmts[i] = stmt
 		}
 
@@ -196,7 +281,12 @@ func dataSourceMinioIAMPolicyDocumentRead(d *schema.ResourceData, meta interface
 	}
 	jsonString := string(jsonDoc)
 
+	if _, errs := validatePolicyVariables(jsonString); len(errs) != 0 {
+		return errs[0]
+	}
+
 	_ = d.Set("json", jsonString)
+	_ = d.Set("example_json", expandPolicyVariables(jsonString))
 	d.SetId(strconv.Itoa(HashcodeString(jsonString)))
 
 	return nil
@@ -250,3 +340,29 @@ func dataSourceMinioIAMPolicyDocumentMakeConditions(in []interface{}, version st
 	}
 	return out, nil
 }
+
+// dataSourceMinioIAMPolicyDocumentAddPrincipals adds the conditions matching the principals, using
+// StringLike when one of the identifiers holds a wildcard. The negated conditions, StringNotEquals and
+// StringNotLike, match every other identity when negate is set.
+func dataSourceMinioIAMPolicyDocumentAddPrincipals(conditions ConditionMap, principals []interface{}, negate bool) {
+	for _, principalI := range principals {
+		principal := principalI.(map[string]interface{})
+		identifiers := principal["identifiers"].(*schema.Set).List()
+
+		test := "StringEquals"
+		values := set.NewStringSet()
+		for _, identifier := range identifiers {
+			if strings.ContainsAny(identifier.(string), "*?") {
+				test = "StringLike"
+			}
+			values.Add(identifier.(string))
+		}
+
+		if negate {
+			test = strings.Replace(test, "String", "StringNot", 1)
+		}
+		conditions.Add(test, ConditionKeyMap{
+			dataSourceMinioIAMPolicyDocumentPrincipalKeys[principal["type"].(string)]: values,
+		})
+	}
+}
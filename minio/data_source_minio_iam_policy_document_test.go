@@ -175,6 +175,44 @@ func TestAccMinioDataSourceIAMPolicyDocument_Statement_Principal_Identifiers_Mul
 	})
 }
 
+func TestAccMinioDataSourceIAMPolicyDocument_Statement_Principals(t *testing.T) {
+	dataSourceName := "data.minio_iam_policy_document.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMinioIAMPolicyDocumentConfigStatementPrincipals,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "json", testAccMinioIAMPolicyDocumentExpectedJSONStatementPrincipals),
+				),
+			},
+		},
+	})
+}
+
+func TestAccMinioDataSourceIAMPolicyDocument_Statement_Negations(t *testing.T) {
+	dataSourceName := "data.minio_iam_policy_document.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMinioIAMPolicyDocumentConfigStatementNegations,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "json", testAccMinioIAMPolicyDocumentExpectedJSONStatementNegations),
+				),
+			},
+			{
+				Config:      testAccMinioIAMPolicyDocumentConfigStatementConflictingNegation,
+				ExpectError: regexp.MustCompile("actions and not_actions cannot be both set"),
+			},
+		},
+	})
+}
+
 var testAccMinioIAMPolicyDocumentConfig = `
 data "minio_iam_policy_document" "test" {
     policy_id = "policy_id"
@@ -661,3 +699,116 @@ var testAccMinioIAMPolicyDocumentExpectedJSONStatementPrincipalIdentifiersMultip
     }
   ]
 }`
+
+var testAccMinioIAMPolicyDocumentConfigStatementPrincipals = `
+data "minio_iam_policy_document" "test" {
+  statement {
+    actions   = ["s3:GetObject"]
+    resources = ["arn:aws:s3:::shared/*"]
+    sid       = "StatementPrincipals"
+
+    principals {
+      type        = "jwt"
+      identifiers = ["alice", "bob"]
+    }
+
+    principals {
+      type        = "ldap"
+      identifiers = ["uid=*,ou=ops,dc=example,dc=org"]
+    }
+
+    principals {
+      type        = "service_account_parent"
+      identifiers = ["ci"]
+    }
+  }
+}
+`
+
+var testAccMinioIAMPolicyDocumentExpectedJSONStatementPrincipals = `{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Sid": "StatementPrincipals",
+      "Effect": "Allow",
+      "Action": "s3:GetObject",
+      "Resource": "arn:aws:s3:::shared/*",
+      "Condition": {
+        "StringEquals": {
+          "aws:username": [
+            "ci"
+          ],
+          "jwt:preferred_username": [
+            "alice",
+            "bob"
+          ]
+        },
+        "StringLike": {
+          "ldap:user": [
+            "uid=*,ou=ops,dc=example,dc=org"
+          ]
+        }
+      }
+    }
+  ]
+}`
+
+var testAccMinioIAMPolicyDocumentConfigStatementNegations = `
+data "minio_iam_policy_document" "test" {
+  statement {
+    sid           = "AllowAllButDelete"
+    not_actions   = ["s3:DeleteObject", "s3:DeleteBucket"]
+    not_resources = ["arn:aws:s3:::audit", "arn:aws:s3:::audit/*"]
+
+    not_principals {
+      type        = "jwt"
+      identifiers = ["auditor-*"]
+    }
+
+    not_principals {
+      type        = "service_account_parent"
+      identifiers = ["ci"]
+    }
+  }
+}
+`
+
+var testAccMinioIAMPolicyDocumentExpectedJSONStatementNegations = `{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Sid": "AllowAllButDelete",
+      "Effect": "Allow",
+      "NotAction": [
+        "s3:DeleteObject",
+        "s3:DeleteBucket"
+      ],
+      "NotResource": [
+        "arn:aws:s3:::audit/*",
+        "arn:aws:s3:::audit"
+      ],
+      "Condition": {
+        "StringNotEquals": {
+          "aws:username": [
+            "ci"
+          ]
+        },
+        "StringNotLike": {
+          "jwt:preferred_username": [
+            "auditor-*"
+          ]
+        }
+      }
+    }
+  ]
+}`
+
+var testAccMinioIAMPolicyDocumentConfigStatementConflictingNegation = `
+data "minio_iam_policy_document" "test" {
+  statement {
+    actions     = ["s3:*"]
+    not_actions = ["s3:DeleteObject"]
+    resources   = ["arn:aws:s3:::*"]
+  }
+}
+`
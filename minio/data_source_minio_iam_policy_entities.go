@@ -0,0 +1,250 @@
+package minio
+
+import (
+	"context"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/minio/madmin-go"
+)
+
+func dataSourceMinioIAMPolicyEntities() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceMinioIAMPolicyEntitiesRead,
+
+		Schema: map[string]*schema.Schema{
+			"policies": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Policies to return the users and groups of",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"users": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Users to return the policies of",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"groups": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Groups to return the policies of",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"ldap": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Query the policy mappings of the LDAP users and groups (distinguished names) instead of the built-in ones",
+			},
+			"policy_mappings": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"policy": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"users": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"groups": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+			"user_mappings": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"user": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"policies": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+			"group_mappings": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"group": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"policies": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceMinioIAMPolicyEntitiesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	admin := meta.(*S3MinioClient).S3Admin
+
+	query := madmin.PolicyEntitiesQuery{
+		Users:  policyEntitiesFilter(d.Get("users").([]interface{})),
+		Groups: policyEntitiesFilter(d.Get("groups").([]interface{})),
+		Policy: policyEntitiesFilter(d.Get("policies").([]interface{})),
+	}
+
+	var result madmin.PolicyEntitiesResult
+	if d.Get("ldap").(bool) {
+		var err error
+		if result, err = admin.GetLDAPPolicyEntities(ctx, query); err != nil {
+			return NewResourceError("unable to read LDAP policy entities", "policy_entities", err)
+		}
+	} else {
+		users, err := admin.ListUsers(ctx)
+		if err != nil {
+			return NewResourceError("unable to list users", "policy_entities", err)
+		}
+		userPolicies := map[string]string{}
+		for name, info := range users {
+			userPolicies[name] = info.PolicyName
+		}
+
+		groups, err := admin.ListGroups(ctx)
+		if err != nil {
+			return NewResourceError("unable to list groups", "policy_entities", err)
+		}
+		groupPolicies := map[string]string{}
+		for _, group := range groups {
+			desc, err := admin.GetGroupDescription(ctx, group)
+			if err != nil {
+				return NewResourceError("unable to read group", group, err)
+			}
+			groupPolicies[group] = desc.Policy
+		}
+
+		result = builtinPolicyEntities(userPolicies, groupPolicies, query)
+	}
+
+	policyMappings := make([]map[string]interface{}, 0, len(result.PolicyMappings))
+	for _, mapping := range result.PolicyMappings {
+		policyMappings = append(policyMappings, map[string]interface{}{
+			"policy": mapping.Policy,
+			"users":  mapping.Users,
+			"groups": mapping.Groups,
+		})
+	}
+	userMappings := make([]map[string]interface{}, 0, len(result.UserMappings))
+	for _, mapping := range result.UserMappings {
+		userMappings = append(userMappings, map[string]interface{}{
+			"user":     mapping.User,
+			"policies": mapping.Policies,
+		})
+	}
+	groupMappings := make([]map[string]interface{}, 0, len(result.GroupMappings))
+	for _, mapping := range result.GroupMappings {
+		groupMappings = append(groupMappings, map[string]interface{}{
+			"group":    mapping.Group,
+			"policies": mapping.Policies,
+		})
+	}
+
+	if err := d.Set("policy_mappings", policyMappings); err != nil {
+		return NewResourceError("unable to read policy entities", "policy_entities", err)
+	}
+	if err := d.Set("user_mappings", userMappings); err != nil {
+		return NewResourceError("unable to read policy entities", "policy_entities", err)
+	}
+	if err := d.Set("group_mappings", groupMappings); err != nil {
+		return NewResourceError("unable to read policy entities", "policy_entities", err)
+	}
+
+	d.SetId(meta.(*S3MinioClient).S3Client.EndpointURL().Host)
+
+	return nil
+}
+
+// builtinPolicyEntities builds the policy mappings of the built-in users and groups from their comma separated
+// policies, the way the server does for LDAP entities: without any filter, every mapping is returned.
+func builtinPolicyEntities(userPolicies map[string]string, groupPolicies map[string]string, query madmin.PolicyEntitiesQuery) madmin.PolicyEntitiesResult {
+	all := len(query.Users) == 0 && len(query.Groups) == 0 && len(query.Policy) == 0
+	result := madmin.PolicyEntitiesResult{}
+
+	policies := map[string]*madmin.PolicyEntities{}
+	policyEntities := func(policy string) *madmin.PolicyEntities {
+		if _, ok := policies[policy]; !ok {
+			policies[policy] = &madmin.PolicyEntities{Policy: policy, Users: []string{}, Groups: []string{}}
+		}
+		return policies[policy]
+	}
+
+	for _, user := range sortedKeys(userPolicies) {
+		userPolicyNames := splitPolicyNames(userPolicies[user])
+		sort.Strings(userPolicyNames)
+		for _, policy := range userPolicyNames {
+			entities := policyEntities(policy)
+			entities.Users = append(entities.Users, user)
+		}
+		if all || Contains(query.Users, user) {
+			result.UserMappings = append(result.UserMappings, madmin.UserPolicyEntities{User: user, Policies: userPolicyNames})
+		}
+	}
+
+	for _, group := range sortedKeys(groupPolicies) {
+		groupPolicyNames := splitPolicyNames(groupPolicies[group])
+		sort.Strings(groupPolicyNames)
+		for _, policy := range groupPolicyNames {
+			entities := policyEntities(policy)
+			entities.Groups = append(entities.Groups, group)
+		}
+		if all || Contains(query.Groups, group) {
+			result.GroupMappings = append(result.GroupMappings, madmin.GroupPolicyEntities{Group: group, Policies: groupPolicyNames})
+		}
+	}
+
+	policyNames := query.Policy
+	if all {
+		policyNames = make([]string, 0, len(policies))
+		for policy := range policies {
+			policyNames = append(policyNames, policy)
+		}
+		sort.Strings(policyNames)
+	}
+	for _, policy := range policyNames {
+		result.PolicyMappings = append(result.PolicyMappings, *policyEntities(policy))
+	}
+
+	return result
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func policyEntitiesFilter(values []interface{}) []string {
+	filter := []string{}
+	for _, value := range values {
+		if value, ok := value.(string); ok && value != "" {
+			filter = append(filter, value)
+		}
+	}
+	return filter
+}
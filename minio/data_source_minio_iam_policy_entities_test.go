@@ -0,0 +1,110 @@
+package minio
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/minio/madmin-go"
+)
+
+func TestAccMinioDataSourceIAMPolicyEntities_basic(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMinioIAMPolicyEntitiesDataSourceConfig(name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.minio_iam_policy_entities.policy", "policy_mappings.#", "1"),
+					resource.TestCheckResourceAttr("data.minio_iam_policy_entities.policy", "policy_mappings.0.policy", name),
+					resource.TestCheckResourceAttr("data.minio_iam_policy_entities.policy", "policy_mappings.0.users.#", "1"),
+					resource.TestCheckResourceAttr("data.minio_iam_policy_entities.policy", "policy_mappings.0.users.0", name),
+					resource.TestCheckResourceAttr("data.minio_iam_policy_entities.policy", "policy_mappings.0.groups.#", "1"),
+					resource.TestCheckResourceAttr("data.minio_iam_policy_entities.policy", "policy_mappings.0.groups.0", name),
+					resource.TestCheckResourceAttr("data.minio_iam_policy_entities.user", "user_mappings.#", "1"),
+					resource.TestCheckResourceAttr("data.minio_iam_policy_entities.user", "user_mappings.0.policies.#", "1"),
+					resource.TestCheckResourceAttr("data.minio_iam_policy_entities.user", "user_mappings.0.policies.0", name),
+				),
+			},
+		},
+	})
+}
+
+func TestBuiltinPolicyEntities(t *testing.T) {
+	users := map[string]string{"alice": "readwrite,diagnostics", "bob": "readonly", "carol": ""}
+	groups := map[string]string{"ops": "diagnostics"}
+
+	result := builtinPolicyEntities(users, groups, madmin.PolicyEntitiesQuery{Policy: []string{"diagnostics", "unused"}})
+	expected := []madmin.PolicyEntities{
+		{Policy: "diagnostics", Users: []string{"alice"}, Groups: []string{"ops"}},
+		{Policy: "unused", Users: []string{}, Groups: []string{}},
+	}
+	if !reflect.DeepEqual(result.PolicyMappings, expected) || len(result.UserMappings) != 0 || len(result.GroupMappings) != 0 {
+		t.Errorf("unexpected policy query result %+v", result)
+	}
+
+	result = builtinPolicyEntities(users, groups, madmin.PolicyEntitiesQuery{Users: []string{"alice"}})
+	if !reflect.DeepEqual(result.UserMappings, []madmin.UserPolicyEntities{{User: "alice", Policies: []string{"diagnostics", "readwrite"}}}) || len(result.PolicyMappings) != 0 {
+		t.Errorf("unexpected user query result %+v", result)
+	}
+
+	result = builtinPolicyEntities(users, groups, madmin.PolicyEntitiesQuery{})
+	if len(result.PolicyMappings) != 3 || len(result.UserMappings) != 3 || len(result.GroupMappings) != 1 {
+		t.Errorf("expected every mapping without filter, got %+v", result)
+	}
+}
+
+func testAccMinioIAMPolicyEntitiesDataSourceConfig(name string) string {
+	return fmt.Sprintf(`
+resource "minio_iam_policy" "policy" {
+  name   = %[1]q
+  policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Effect": "Allow",
+      "Action": ["s3:ListAllMyBuckets"],
+      "Resource": ["arn:aws:s3:::*"]
+    }
+  ]
+}
+EOF
+}
+
+resource "minio_iam_user" "user" {
+  name = %[1]q
+}
+
+resource "minio_iam_group" "group" {
+  name = %[1]q
+}
+
+resource "minio_iam_user_policy_attachment" "user" {
+  user_name   = minio_iam_user.user.id
+  policy_name = minio_iam_policy.policy.id
+}
+
+resource "minio_iam_group_policy_attachment" "group" {
+  group_name  = minio_iam_group.group.name
+  policy_name = minio_iam_policy.policy.id
+}
+
+data "minio_iam_policy_entities" "policy" {
+  policies = [minio_iam_policy.policy.id]
+
+  depends_on = [minio_iam_user_policy_attachment.user, minio_iam_group_policy_attachment.group]
+}
+
+data "minio_iam_policy_entities" "user" {
+  users = [minio_iam_user.user.id]
+
+  depends_on = [minio_iam_user_policy_attachment.user]
+}
+`, name)
+}
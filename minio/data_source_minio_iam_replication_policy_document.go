@@ -0,0 +1,181 @@
+package minio
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// replicationTargetBucketActions are the bucket actions the server checks with the target credentials before
+// replicating into a bucket
+var replicationTargetBucketActions = []string{
+	"s3:GetBucketLocation",
+	"s3:GetBucketObjectLockConfiguration",
+	"s3:GetBucketVersioning",
+	"s3:GetEncryptionConfiguration",
+	"s3:GetReplicationConfiguration",
+	"s3:ListBucket",
+	"s3:ListBucketMultipartUploads",
+}
+
+// replicationTargetObjectActions are the object actions used to replicate the objects, their versions, tags,
+// retention and deletes
+var replicationTargetObjectActions = []string{
+	"s3:AbortMultipartUpload",
+	"s3:DeleteObject",
+	"s3:GetObject",
+	"s3:GetObjectVersion",
+	"s3:GetObjectVersionTagging",
+	"s3:GetReplicationConfiguration",
+	"s3:PutBucketObjectLockConfiguration",
+	"s3:PutObject",
+	"s3:PutObjectLegalHold",
+	"s3:PutObjectRetention",
+	"s3:ReplicateDelete",
+	"s3:ReplicateObject",
+	"s3:ReplicateTags",
+}
+
+// replicationSourceBucketActions are the actions needed to configure the replication of a bucket, which
+// minio_s3_bucket_replication uses
+var replicationSourceBucketActions = []string{
+	"admin:GetBucketTarget",
+	"admin:SetBucketTarget",
+	"s3:GetBucketVersioning",
+	"s3:GetReplicationConfiguration",
+	"s3:ListBucket",
+	"s3:PutBucketVersioning",
+	"s3:PutReplicationConfiguration",
+}
+
+func dataSourceMinioIAMReplicationPolicyDocument() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceMinioIAMReplicationPolicyDocumentRead,
+
+		Schema: map[string]*schema.Schema{
+			"target_buckets": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				MinItems:    1,
+				Description: "Buckets the objects are replicated into",
+				Elem: &schema.Schema{
+					Type:             schema.TypeString,
+					ValidateDiagFunc: validateMinioBucketName,
+				},
+			},
+			"source_buckets": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Buckets the replication is configured on",
+				Elem: &schema.Schema{
+					Type:             schema.TypeString,
+					ValidateDiagFunc: validateMinioBucketName,
+				},
+			},
+			"json": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Least privilege policy of the service account whose credentials are set in the replication targets",
+			},
+			"source_json": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Least privilege policy of the user configuring the replication of the source buckets, empty without source_buckets",
+			},
+		},
+	}
+}
+
+func dataSourceMinioIAMReplicationPolicyDocumentRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	targetBuckets := replicationPolicyBuckets(d.Get("target_buckets").(*schema.Set))
+	sourceBuckets := replicationPolicyBuckets(d.Get("source_buckets").(*schema.Set))
+
+	targetJSON, err := renderReplicationPolicy(replicationTargetPolicy(targetBuckets))
+	if err != nil {
+		return NewResourceError("unable to render replication policy", "target_buckets", err)
+	}
+
+	sourceJSON := ""
+	if len(sourceBuckets) != 0 {
+		if sourceJSON, err = renderReplicationPolicy(replicationSourcePolicy(sourceBuckets)); err != nil {
+			return NewResourceError("unable to render replication policy", "source_buckets", err)
+		}
+	}
+
+	_ = d.Set("json", targetJSON)
+	_ = d.Set("source_json", sourceJSON)
+	d.SetId(strconv.Itoa(HashcodeString(targetJSON + sourceJSON)))
+
+	return nil
+}
+
+// replicationTargetPolicy returns the policy of the credentials replicating into the target buckets
+func replicationTargetPolicy(buckets []string) *IAMPolicyDoc {
+	return &IAMPolicyDoc{
+		Version: "2012-10-17",
+		Statements: []*IAMPolicyStatement{
+			{
+				Sid:       "EnableReplicationOnBucket",
+				Effect:    "Allow",
+				Actions:   replicationTargetBucketActions,
+				Resources: replicationPolicyResources(buckets, false),
+			},
+			{
+				Sid:       "EnableReplicatingDataIntoBucket",
+				Effect:    "Allow",
+				Actions:   replicationTargetObjectActions,
+				Resources: replicationPolicyResources(buckets, true),
+			},
+		},
+	}
+}
+
+// replicationSourcePolicy returns the policy of the user configuring the replication of the source buckets
+func replicationSourcePolicy(buckets []string) *IAMPolicyDoc {
+	return &IAMPolicyDoc{
+		Version: "2012-10-17",
+		Statements: []*IAMPolicyStatement{
+			{
+				Sid:       "ConfigureBucketReplication",
+				Effect:    "Allow",
+				Actions:   replicationSourceBucketActions,
+				Resources: replicationPolicyResources(buckets, false),
+			},
+		},
+	}
+}
+
+func renderReplicationPolicy(policy *IAMPolicyDoc) (string, error) {
+	content, err := json.MarshalIndent(policy, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(content), nil
+}
+
+// replicationPolicyResources returns the ARNs of the buckets, or of all their objects
+func replicationPolicyResources(buckets []string, objects bool) []string {
+	resources := make([]string, len(buckets))
+	for i, bucket := range buckets {
+		if objects {
+			resources[i] = objectArn(bucket, "*")
+		} else {
+			resources[i] = bucketArn(bucket)
+		}
+	}
+	return resources
+}
+
+// replicationPolicyBuckets returns the buckets of a set, sorted so the policy is stable
+func replicationPolicyBuckets(set *schema.Set) []string {
+	buckets := []string{}
+	for _, bucket := range set.List() {
+		buckets = append(buckets, bucket.(string))
+	}
+	sort.Strings(buckets)
+	return buckets
+}
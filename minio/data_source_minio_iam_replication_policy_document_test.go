@@ -0,0 +1,72 @@
+package minio
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccMinioDataSourceIAMReplicationPolicyDocument_basic(t *testing.T) {
+	policyName := acctest.RandomWithPrefix("tf-acc-replication")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				// The rendered documents are accepted by the server
+				Config: fmt.Sprintf(`
+data "minio_iam_replication_policy_document" "test" {
+  target_buckets = ["target-b", "target-a"]
+  source_buckets = ["source"]
+}
+
+resource "minio_iam_policy" "target" {
+  name   = "%[1]s-target"
+  policy = data.minio_iam_replication_policy_document.test.json
+}
+
+resource "minio_iam_policy" "source" {
+  name   = "%[1]s-source"
+  policy = data.minio_iam_replication_policy_document.test.source_json
+}
+`, policyName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr("data.minio_iam_replication_policy_document.test", "json", regexp.MustCompile(`"arn:aws:s3:::target-a/\*"`)),
+					resource.TestMatchResourceAttr("data.minio_iam_replication_policy_document.test", "source_json", regexp.MustCompile(`"arn:aws:s3:::source"`)),
+					resource.TestCheckResourceAttr("minio_iam_policy.target", "name", policyName+"-target"),
+					resource.TestCheckResourceAttr("minio_iam_policy.source", "name", policyName+"-source"),
+				),
+			},
+		},
+	})
+}
+
+func TestReplicationTargetPolicy(t *testing.T) {
+	rendered, err := renderReplicationPolicy(replicationTargetPolicy([]string{"target-a", "target-b"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	policy := IAMPolicyDoc{}
+	if err := json.Unmarshal([]byte(rendered), &policy); err != nil {
+		t.Fatal(err)
+	}
+	if len(policy.Statements) != 2 {
+		t.Fatalf("expected a bucket and an object statement, got %s", rendered)
+	}
+	if resources := policy.Statements[0].Resources; !reflect.DeepEqual(resources, []interface{}{"arn:aws:s3:::target-a", "arn:aws:s3:::target-b"}) {
+		t.Errorf("unexpected bucket resources %v", resources)
+	}
+	if resources := policy.Statements[1].Resources; !reflect.DeepEqual(resources, []interface{}{"arn:aws:s3:::target-a/*", "arn:aws:s3:::target-b/*"}) {
+		t.Errorf("unexpected object resources %v", resources)
+	}
+	if _, errs := validateIAMPolicyJSON(rendered, "json"); len(errs) != 0 {
+		t.Errorf("expected a valid policy, got %v", errs)
+	}
+}
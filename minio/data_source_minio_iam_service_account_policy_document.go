@@ -0,0 +1,218 @@
+package minio
+
+import (
+	"context"
+	"encoding/json"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// serviceAccountAccessActions are the actions granted on the buckets which do not list their own
+var serviceAccountAccessActions = map[string][]string{
+	"read-only": {
+		"s3:GetBucketLocation",
+		"s3:GetObject",
+		"s3:ListBucket",
+	},
+	"write-only": {
+		"s3:AbortMultipartUpload",
+		"s3:GetBucketLocation",
+		"s3:ListBucketMultipartUploads",
+		"s3:ListMultipartUploadParts",
+		"s3:PutObject",
+	},
+	"read-write": {
+		"s3:AbortMultipartUpload",
+		"s3:DeleteObject",
+		"s3:GetBucketLocation",
+		"s3:GetObject",
+		"s3:ListBucket",
+		"s3:ListBucketMultipartUploads",
+		"s3:ListMultipartUploadParts",
+		"s3:PutObject",
+	},
+}
+
+// serviceAccountActionPattern matches the S3 actions a session policy can grant, wildcards included
+var serviceAccountActionPattern = regexp.MustCompile(`^(\*|s3:[A-Za-z*]+)$`)
+
+// serviceAccountPolicyBucket is a bucket a service account is restricted to
+type serviceAccountPolicyBucket struct {
+	Name     string
+	Prefixes []string
+	Actions  []string
+}
+
+func dataSourceMinioIAMServiceAccountPolicyDocument() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceMinioIAMServiceAccountPolicyDocumentRead,
+
+		Schema: map[string]*schema.Schema{
+			"access": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "read-only",
+				ValidateFunc: validation.StringInSlice([]string{"read-only", "write-only", "read-write"}, false),
+				Description:  "Access granted on the buckets which do not list their actions: read-only, write-only or read-write",
+			},
+			"bucket": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				MinItems:    1,
+				Description: "Bucket the service account is restricted to",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:             schema.TypeString,
+							Required:         true,
+							ValidateDiagFunc: validateMinioBucketName,
+							Description:      "Name of the bucket",
+						},
+						"prefixes": {
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Description: "Prefixes of the objects the service account is restricted to, e.g. reports/ (default: the whole bucket)",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"actions": {
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Description: "Actions granted on the bucket, e.g. s3:GetObject (default: those of access). The actions naming a bucket apply to the bucket, the others to its objects",
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validation.StringMatch(serviceAccountActionPattern, "must be an action such as s3:GetObject"),
+							},
+						},
+					},
+				},
+			},
+			"json": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Session policy to set as the policy of a minio_iam_service_account",
+			},
+		},
+	}
+}
+
+func dataSourceMinioIAMServiceAccountPolicyDocumentRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	access := d.Get("access").(string)
+
+	buckets := []serviceAccountPolicyBucket{}
+	for _, item := range d.Get("bucket").(*schema.Set).List() {
+		bucket := item.(map[string]interface{})
+		actions := serviceAccountPolicyStrings(bucket["actions"].(*schema.Set))
+		if len(actions) == 0 {
+			actions = serviceAccountAccessActions[access]
+		}
+		buckets = append(buckets, serviceAccountPolicyBucket{
+			Name:     bucket["name"].(string),
+			Prefixes: serviceAccountPolicyStrings(bucket["prefixes"].(*schema.Set)),
+			Actions:  actions,
+		})
+	}
+
+	content, err := json.MarshalIndent(serviceAccountRestrictPolicy(buckets), "", "  ")
+	if err != nil {
+		return NewResourceError("unable to render service account policy", "bucket", err)
+	}
+
+	_ = d.Set("json", string(content))
+	d.SetId(strconv.Itoa(HashcodeString(string(content))))
+
+	return nil
+}
+
+// serviceAccountRestrictPolicy returns the session policy restricting a service account to the buckets, with a
+// statement for the bucket actions, limited to listing the prefixes, and one for the object actions
+func serviceAccountRestrictPolicy(buckets []serviceAccountPolicyBucket) *IAMPolicyDoc {
+	sort.Slice(buckets, func(i, j int) bool { return buckets[i].Name < buckets[j].Name })
+
+	statements := []*IAMPolicyStatement{}
+	for _, bucket := range buckets {
+		bucketActions, objectActions := splitServiceAccountActions(bucket.Actions)
+		sid := serviceAccountPolicySid(bucket.Name)
+
+		if len(bucketActions) != 0 {
+			statement := &IAMPolicyStatement{
+				Sid:       sid + "Bucket",
+				Effect:    "Allow",
+				Actions:   bucketActions,
+				Resources: []string{bucketArn(bucket.Name)},
+			}
+			if len(bucket.Prefixes) != 0 {
+				listed := []string{}
+				for _, prefix := range bucket.Prefixes {
+					listed = append(listed, prefix, prefix+"*")
+				}
+				statement.Conditions = map[string]map[string][]string{
+					"StringLike": {"s3:prefix": listed},
+				}
+			}
+			statements = append(statements, statement)
+		}
+
+		if len(objectActions) != 0 {
+			resources := []string{objectArn(bucket.Name, "*")}
+			if len(bucket.Prefixes) != 0 {
+				resources = []string{}
+				for _, prefix := range bucket.Prefixes {
+					resources = append(resources, objectArn(bucket.Name, prefix+"*"))
+				}
+			}
+			statements = append(statements, &IAMPolicyStatement{
+				Sid:       sid + "Objects",
+				Effect:    "Allow",
+				Actions:   objectActions,
+				Resources: resources,
+			})
+		}
+	}
+
+	return &IAMPolicyDoc{
+		Version:    "2012-10-17",
+		Statements: statements,
+	}
+}
+
+// splitServiceAccountActions splits the actions applying to a bucket, such as s3:ListBucket, from those applying to
+// its objects. Wildcard actions apply to both.
+func splitServiceAccountActions(actions []string) (bucketActions []string, objectActions []string) {
+	for _, action := range actions {
+		switch {
+		case strings.Contains(action, "*"):
+			bucketActions = append(bucketActions, action)
+			objectActions = append(objectActions, action)
+		case strings.Contains(action, "Bucket"):
+			bucketActions = append(bucketActions, action)
+		default:
+			objectActions = append(objectActions, action)
+		}
+	}
+	return bucketActions, objectActions
+}
+
+// serviceAccountPolicySid returns the alphanumeric statement ID prefix of a bucket, e.g. MyBucket for my-bucket
+func serviceAccountPolicySid(bucket string) string {
+	sid := ""
+	for _, part := range strings.FieldsFunc(bucket, func(r rune) bool { return r == '-' || r == '.' }) {
+		sid += strings.ToUpper(part[:1]) + part[1:]
+	}
+	return sid
+}
+
+// serviceAccountPolicyStrings returns the values of a set, sorted so the policy is stable
+func serviceAccountPolicyStrings(set *schema.Set) []string {
+	values := []string{}
+	for _, value := range set.List() {
+		values = append(values, value.(string))
+	}
+	sort.Strings(values)
+	return values
+}
@@ -0,0 +1,77 @@
+package minio
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccMinioDataSourceIAMServiceAccountPolicyDocument_basic(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "minio_iam_service_account_policy_document" "test" {
+  access = "read-write"
+
+  bucket {
+    name     = "reports"
+    prefixes = ["2023/"]
+  }
+
+  bucket {
+    name    = "archive"
+    actions = ["s3:GetObject"]
+  }
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.minio_iam_service_account_policy_document.test", "json"),
+				),
+			},
+		},
+	})
+}
+
+func TestServiceAccountRestrictPolicy(t *testing.T) {
+	rendered, err := json.Marshal(serviceAccountRestrictPolicy([]serviceAccountPolicyBucket{
+		{Name: "my-reports", Prefixes: []string{"2023/"}, Actions: serviceAccountAccessActions["read-only"]},
+		{Name: "archive", Actions: []string{"s3:GetObject"}},
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	policy := IAMPolicyDoc{}
+	if err := json.Unmarshal(rendered, &policy); err != nil {
+		t.Fatal(err)
+	}
+	if len(policy.Statements) != 3 {
+		t.Fatalf("expected an object statement for archive and two statements for my-reports, got %s", rendered)
+	}
+
+	if sid, resources := policy.Statements[0].Sid, policy.Statements[0].Resources; sid != "ArchiveObjects" || !reflect.DeepEqual(resources, []interface{}{"arn:aws:s3:::archive/*"}) {
+		t.Errorf("unexpected archive statement %s on %v", sid, resources)
+	}
+
+	bucketStatement := policy.Statements[1]
+	if bucketStatement.Sid != "MyReportsBucket" || !reflect.DeepEqual(bucketStatement.Actions, []interface{}{"s3:GetBucketLocation", "s3:ListBucket"}) {
+		t.Errorf("unexpected bucket statement %s with %v", bucketStatement.Sid, bucketStatement.Actions)
+	}
+	expectedCondition := map[string]interface{}{"StringLike": map[string]interface{}{"s3:prefix": []interface{}{"2023/", "2023/*"}}}
+	if !reflect.DeepEqual(bucketStatement.Conditions, expectedCondition) {
+		t.Errorf("expected the listing to be limited to the prefixes, got %v", bucketStatement.Conditions)
+	}
+
+	if resources := policy.Statements[2].Resources; !reflect.DeepEqual(resources, []interface{}{"arn:aws:s3:::my-reports/2023/*"}) {
+		t.Errorf("unexpected object resources %v", resources)
+	}
+
+	if _, errs := validateIAMPolicyJSON(string(rendered), "json"); len(errs) != 0 {
+		t.Errorf("expected a valid policy, got %v", errs)
+	}
+}
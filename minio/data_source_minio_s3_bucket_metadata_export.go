@@ -0,0 +1,63 @@
+package minio
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"io"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceMinioS3BucketMetadataExport() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceMinioS3BucketMetadataExportRead,
+
+		Schema: map[string]*schema.Schema{
+			"bucket": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validateMinioBucketName,
+				Description:      "Bucket whose metadata is exported",
+			},
+			"content_base64": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Base64 encoded zip archive of the bucket configurations (policy, lifecycle, notification, replication, encryption, object lock, versioning, tags and quota), as exported by `mc admin cluster bucket export`",
+			},
+			"content_sha256": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Hex encoded SHA256 checksum of the archive",
+			},
+		},
+	}
+}
+
+func dataSourceMinioS3BucketMetadataExportRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	admin := meta.(*S3MinioClient).S3Admin
+	bucket := d.Get("bucket").(string)
+
+	log.Printf("[DEBUG] Exporting metadata of bucket %s", bucket)
+	reader, err := admin.ExportBucketMetadata(ctx, bucket)
+	if err != nil {
+		return NewResourceError("unable to export bucket metadata", bucket, err)
+	}
+	defer reader.Close()
+
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return NewResourceError("unable to export bucket metadata", bucket, err)
+	}
+
+	checksum := sha256.Sum256(content)
+
+	d.SetId(bucket)
+	_ = d.Set("content_base64", base64.StdEncoding.EncodeToString(content))
+	_ = d.Set("content_sha256", hex.EncodeToString(checksum[:]))
+
+	return nil
+}
@@ -0,0 +1,208 @@
+package minio
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/minio/minio-go/v7"
+)
+
+// objectsManifestHeader is the first line of the CSV manifests
+var objectsManifestHeader = []string{"key", "size", "etag", "version_id"}
+
+func dataSourceMinioS3BucketObjectsManifest() *schema.Resource {
+	return &schema.Resource{
+		Description: "Generates the manifest of the objects of a bucket under a prefix, optionally written as a CSV object to feed batch replication or audit workflows.",
+		ReadContext: dataSourceMinioS3BucketObjectsManifestRead,
+
+		Schema: map[string]*schema.Schema{
+			"bucket": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validateMinioBucketName,
+			},
+			"prefix": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"include_versions": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "List every version of the objects instead of their latest one. Delete markers are left out",
+			},
+			"max_keys": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1000,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Maximum number of entries kept in objects, the output object holds all of them",
+			},
+			"output_bucket": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				RequiredWith:     []string{"output_key"},
+				ValidateDiagFunc: validateMinioBucketName,
+				Description:      "Bucket receiving the manifest as a CSV object",
+			},
+			"output_key": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"output_bucket"},
+				Description:  "Key of the CSV object receiving the manifest, overwritten on every read",
+			},
+			"objects": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "First max_keys entries of the manifest",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"size": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"etag": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"version_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"object_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of entries of the manifest",
+			},
+			"total_size": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Sum of the sizes of the entries, in bytes",
+			},
+			"output_etag": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ETag of the CSV object written to output_bucket",
+			},
+		},
+	}
+}
+
+func dataSourceMinioS3BucketObjectsManifestRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	m := meta.(*S3MinioClient)
+	bucket := d.Get("bucket").(string)
+	prefix := d.Get("prefix").(string)
+	maxKeys := d.Get("max_keys").(int)
+	outputBucket := d.Get("output_bucket").(string)
+	outputKey := d.Get("output_key").(string)
+
+	// The CSV is spooled to disk, a manifest may list millions of objects
+	var manifest *os.File
+	var manifestWriter *csv.Writer
+	if outputBucket != "" {
+		var err error
+		if manifest, err = os.CreateTemp("", "minio-manifest-*.csv"); err != nil {
+			return NewResourceError("unable to create the manifest", bucket, err)
+		}
+		defer os.Remove(manifest.Name())
+		defer manifest.Close()
+
+		manifestWriter = csv.NewWriter(manifest)
+		if err := manifestWriter.Write(objectsManifestHeader); err != nil {
+			return NewResourceError("unable to write the manifest", bucket, err)
+		}
+	}
+
+	objects := []map[string]interface{}{}
+	count, totalSize := 0, int64(0)
+	for object := range m.S3Client.ListObjects(ctx, bucket, minio.ListObjectsOptions{
+		Prefix:       prefix,
+		Recursive:    true,
+		WithVersions: d.Get("include_versions").(bool),
+	}) {
+		if object.Err != nil {
+			return NewResourceError("unable to list objects", bucket, object.Err)
+		}
+		if object.IsDeleteMarker {
+			continue
+		}
+
+		count++
+		totalSize += object.Size
+
+		if len(objects) < maxKeys {
+			objects = append(objects, map[string]interface{}{
+				"key":        object.Key,
+				"size":       int(object.Size),
+				"etag":       object.ETag,
+				"version_id": object.VersionID,
+			})
+		}
+
+		if manifestWriter != nil {
+			if err := manifestWriter.Write(objectsManifestRecord(object)); err != nil {
+				return NewResourceError("unable to write the manifest", bucket, err)
+			}
+		}
+	}
+
+	if manifestWriter != nil {
+		etag, err := putObjectsManifest(ctx, m.S3Client, manifest, manifestWriter, outputBucket, outputKey)
+		if err != nil {
+			return NewResourceError("unable to upload the manifest", outputBucket+"/"+outputKey, err)
+		}
+		_ = d.Set("output_etag", etag)
+	}
+
+	d.SetId(bucket + "/" + prefix)
+	if err := d.Set("objects", objects); err != nil {
+		return NewResourceError("unable to set the manifest objects", bucket, err)
+	}
+	_ = d.Set("object_count", count)
+	_ = d.Set("total_size", int(totalSize))
+
+	return nil
+}
+
+// objectsManifestRecord returns the CSV line of an object, in the order of objectsManifestHeader
+func objectsManifestRecord(object minio.ObjectInfo) []string {
+	return []string{object.Key, strconv.FormatInt(object.Size, 10), object.ETag, object.VersionID}
+}
+
+// putObjectsManifest flushes the CSV spooled to the manifest file and uploads it
+func putObjectsManifest(ctx context.Context, client *minio.Client, manifest *os.File, manifestWriter *csv.Writer, bucket string, key string) (string, error) {
+	manifestWriter.Flush()
+	if err := manifestWriter.Error(); err != nil {
+		return "", err
+	}
+
+	size, err := manifest.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return "", err
+	}
+	if _, err := manifest.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+
+	log.Printf("[DEBUG] Uploading the objects manifest to %s/%s (%d bytes)", bucket, key, size)
+	info, err := client.PutObject(ctx, bucket, key, manifest, size, minio.PutObjectOptions{ContentType: "text/csv"})
+	if err != nil {
+		return "", fmt.Errorf("%s/%s: %w", bucket, key, err)
+	}
+	return info.ETag, nil
+}
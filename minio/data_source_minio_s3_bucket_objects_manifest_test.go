@@ -0,0 +1,109 @@
+package minio
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/minio/minio-go/v7"
+)
+
+func TestAccMinioDataSourceS3BucketObjectsManifest_basic(t *testing.T) {
+	bucketName := acctest.RandomWithPrefix("tf-acc-test")
+	dataSourceName := "data.minio_s3_bucket_objects_manifest.manifest"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMinioS3BucketObjectsManifestDataSourceConfig(bucketName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "object_count", "2"),
+					resource.TestCheckResourceAttr(dataSourceName, "total_size", "10"),
+					resource.TestCheckResourceAttr(dataSourceName, "objects.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "objects.0.key", "data/a.txt"),
+					resource.TestCheckResourceAttr(dataSourceName, "objects.0.size", "5"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "output_etag"),
+					testAccCheckMinioS3BucketObjectsManifestOutput(bucketName, "manifests/data.csv", 2),
+				),
+			},
+		},
+	})
+}
+
+func TestObjectsManifestRecord(t *testing.T) {
+	record := objectsManifestRecord(minio.ObjectInfo{Key: "a,b.txt", Size: 42, ETag: "d41d8cd98f00b204e9800998ecf8427e", VersionID: "v1"})
+	if want := []string{"a,b.txt", "42", "d41d8cd98f00b204e9800998ecf8427e", "v1"}; !reflect.DeepEqual(record, want) {
+		t.Errorf("got %v, want %v", record, want)
+	}
+}
+
+func testAccCheckMinioS3BucketObjectsManifestOutput(bucket string, key string, count int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		conn := testAccProvider.Meta().(*S3MinioClient).S3Client
+
+		object, err := conn.GetObject(context.Background(), bucket, key, minio.GetObjectOptions{})
+		if err != nil {
+			return err
+		}
+		defer object.Close()
+
+		content, err := io.ReadAll(object)
+		if err != nil {
+			return fmt.Errorf("unable to read the manifest %s/%s: %w", bucket, key, err)
+		}
+		lines := strings.Split(strings.TrimSuffix(string(content), "\n"), "\n")
+		if lines[0] != "key,size,etag,version_id" {
+			return fmt.Errorf("expected the manifest to start with its header, got %q", content)
+		}
+		if len(lines) != count+1 {
+			return fmt.Errorf("expected %d objects in the manifest, got %q", count, content)
+		}
+		return nil
+	}
+}
+
+func testAccMinioS3BucketObjectsManifestDataSourceConfig(bucketName string) string {
+	return fmt.Sprintf(`
+resource "minio_s3_bucket" "bucket" {
+  bucket        = %q
+  force_destroy = true
+}
+
+resource "minio_s3_object" "a" {
+  bucket_name = minio_s3_bucket.bucket.id
+  object_name = "data/a.txt"
+  content     = "hello"
+}
+
+resource "minio_s3_object" "b" {
+  bucket_name = minio_s3_bucket.bucket.id
+  object_name = "data/b.txt"
+  content     = "world"
+}
+
+resource "minio_s3_object" "other" {
+  bucket_name = minio_s3_bucket.bucket.id
+  object_name = "other/c.txt"
+  content     = "ignored"
+}
+
+data "minio_s3_bucket_objects_manifest" "manifest" {
+  bucket   = minio_s3_bucket.bucket.id
+  prefix   = "data/"
+  max_keys = 1
+
+  output_bucket = minio_s3_bucket.bucket.id
+  output_key    = "manifests/data.csv"
+
+  depends_on = [minio_s3_object.a, minio_s3_object.b, minio_s3_object.other]
+}
+`, bucketName)
+}
@@ -0,0 +1,51 @@
+package minio
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
+)
+
+func dataSourceMinioS3BucketPolicy() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceMinioS3BucketPolicyRead,
+
+		Schema: map[string]*schema.Schema{
+			"bucket": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validateMinioBucketName,
+			},
+			"policy": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Policy of the bucket, in normalized JSON, empty when the bucket has none",
+			},
+		},
+	}
+}
+
+func dataSourceMinioS3BucketPolicyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*S3MinioClient).S3Client
+	bucket := d.Get("bucket").(string)
+
+	log.Printf("[DEBUG] S3 bucket policy, read for bucket: %s", bucket)
+
+	policy, err := client.GetBucketPolicy(ctx, bucket)
+	if err != nil {
+		return NewResourceError("failed to load bucket policy", bucket, err)
+	}
+
+	policy, err = structure.NormalizeJsonString(policy)
+	if err != nil {
+		return NewResourceError("policy is invalid JSON", bucket, err)
+	}
+
+	d.SetId(bucket)
+	_ = d.Set("policy", policy)
+
+	return nil
+}
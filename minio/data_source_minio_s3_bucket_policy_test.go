@@ -0,0 +1,55 @@
+package minio
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccMinioDataSourceS3BucketPolicy_basic(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-acc-test")
+	dataSourceName := "data.minio_s3_bucket_policy.bucket"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckMinioS3BucketDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMinioS3BucketPolicyDataSourceConfig(name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "bucket", name),
+					resource.TestMatchResourceAttr(dataSourceName, "policy", regexp.MustCompile(`"s3:GetObject"`)),
+				),
+			},
+		},
+	})
+}
+
+func testAccMinioS3BucketPolicyDataSourceConfig(bucketName string) string {
+	return fmt.Sprintf(`
+resource "minio_s3_bucket" "bucket" {
+  bucket = %[1]q
+}
+
+resource "minio_s3_bucket_policy" "bucket" {
+  bucket = minio_s3_bucket.bucket.bucket
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [{
+      Effect    = "Allow"
+      Principal = { AWS = ["*"] }
+      Action    = ["s3:GetObject"]
+      Resource  = ["arn:aws:s3:::%[1]s/*"]
+    }]
+  })
+}
+
+data "minio_s3_bucket_policy" "bucket" {
+  bucket = minio_s3_bucket_policy.bucket.bucket
+}
+`, bucketName)
+}
@@ -0,0 +1,159 @@
+package minio
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/minio/minio-go/v7"
+)
+
+// bucketRetentionReport counts the objects protected from deletion by their retention or legal hold
+type bucketRetentionReport struct {
+	Objects      int
+	Retained     int
+	Governance   int
+	Compliance   int
+	LegalHold    int
+	EarliestDate time.Time
+	LatestDate   time.Time
+}
+
+func dataSourceMinioS3BucketRetentionCheck() *schema.Resource {
+	return &schema.Resource{
+		Description: "Reports how many objects of a bucket under a prefix are under retention or legal hold, and when their retention ends, for compliance audits.",
+		ReadContext: dataSourceMinioS3BucketRetentionCheckRead,
+
+		Schema: map[string]*schema.Schema{
+			"bucket": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validateMinioBucketName,
+			},
+			"prefix": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"include_versions": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Check every version of the objects instead of their latest one, the retention applying to each version",
+			},
+			"object_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of objects checked",
+			},
+			"retained_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of objects whose retain until date is not reached",
+			},
+			"governance_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of retained objects in GOVERNANCE mode",
+			},
+			"compliance_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of retained objects in COMPLIANCE mode",
+			},
+			"legal_hold_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of objects under legal hold",
+			},
+			"earliest_retain_until_date": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Earliest date (RFC3339) a retained object is released, empty when no object is retained",
+			},
+			"latest_retain_until_date": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Date (RFC3339) every retained object is released, empty when no object is retained",
+			},
+		},
+	}
+}
+
+func dataSourceMinioS3BucketRetentionCheckRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	m := meta.(*S3MinioClient)
+	bucket := d.Get("bucket").(string)
+	prefix := d.Get("prefix").(string)
+
+	// The listings do not hold the object lock headers, each object is checked
+	report := &bucketRetentionReport{}
+	now := time.Now().UTC()
+	for object := range m.S3Client.ListObjects(ctx, bucket, minio.ListObjectsOptions{
+		Prefix:       prefix,
+		Recursive:    true,
+		WithVersions: d.Get("include_versions").(bool),
+	}) {
+		if object.Err != nil {
+			return NewResourceError("unable to list objects", bucket, object.Err)
+		}
+		if object.IsDeleteMarker {
+			continue
+		}
+
+		info, err := m.S3Client.StatObject(ctx, bucket, object.Key, minio.StatObjectOptions{VersionID: object.VersionID})
+		if err != nil {
+			return NewResourceError("unable to check object retention", fmt.Sprintf("%s/%s", bucket, object.Key), err)
+		}
+		report.add(info.Metadata, now)
+	}
+
+	d.SetId(strconv.Itoa(HashcodeString(bucket + "/" + prefix)))
+	_ = d.Set("object_count", report.Objects)
+	_ = d.Set("retained_count", report.Retained)
+	_ = d.Set("governance_count", report.Governance)
+	_ = d.Set("compliance_count", report.Compliance)
+	_ = d.Set("legal_hold_count", report.LegalHold)
+	_ = d.Set("earliest_retain_until_date", formatRetentionDate(report.EarliestDate))
+	_ = d.Set("latest_retain_until_date", formatRetentionDate(report.LatestDate))
+
+	return nil
+}
+
+// add counts an object from the object lock headers of its metadata. A retention whose date is reached no longer
+// protects the object and is not counted.
+func (report *bucketRetentionReport) add(metadata http.Header, now time.Time) {
+	report.Objects++
+
+	if metadata.Get("X-Amz-Object-Lock-Legal-Hold") == minio.LegalHoldEnabled.String() {
+		report.LegalHold++
+	}
+
+	retainUntil, err := time.Parse(time.RFC3339, metadata.Get("X-Amz-Object-Lock-Retain-Until-Date"))
+	if err != nil || !retainUntil.After(now) {
+		return
+	}
+
+	report.Retained++
+	switch minio.RetentionMode(metadata.Get("X-Amz-Object-Lock-Mode")) {
+	case minio.Governance:
+		report.Governance++
+	case minio.Compliance:
+		report.Compliance++
+	}
+	if report.EarliestDate.IsZero() || retainUntil.Before(report.EarliestDate) {
+		report.EarliestDate = retainUntil
+	}
+	if retainUntil.After(report.LatestDate) {
+		report.LatestDate = retainUntil
+	}
+}
+
+func formatRetentionDate(date time.Time) string {
+	if date.IsZero() {
+		return ""
+	}
+	return date.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,91 @@
+package minio
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccMinioDataSourceS3BucketRetentionCheck_basic(t *testing.T) {
+	bucketName := acctest.RandomWithPrefix("tf-acc-test")
+	dataSourceName := "data.minio_s3_bucket_retention_check.check"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMinioS3BucketRetentionCheckDataSourceConfig(bucketName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "object_count", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "retained_count", "0"),
+					resource.TestCheckResourceAttr(dataSourceName, "legal_hold_count", "0"),
+					resource.TestCheckResourceAttr(dataSourceName, "earliest_retain_until_date", ""),
+				),
+			},
+		},
+	})
+}
+
+func TestBucketRetentionReport(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	object := func(mode string, retainUntil string, legalHold string) http.Header {
+		metadata := http.Header{}
+		metadata.Set("X-Amz-Object-Lock-Mode", mode)
+		metadata.Set("X-Amz-Object-Lock-Retain-Until-Date", retainUntil)
+		metadata.Set("X-Amz-Object-Lock-Legal-Hold", legalHold)
+		return metadata
+	}
+
+	report := &bucketRetentionReport{}
+	report.add(object("GOVERNANCE", "2024-06-01T00:00:00Z", "OFF"), now)
+	report.add(object("COMPLIANCE", "2025-01-01T00:00:00Z", "ON"), now)
+	report.add(object("GOVERNANCE", "2023-06-01T00:00:00Z", ""), now)
+	report.add(object("", "", "ON"), now)
+	report.add(http.Header{}, now)
+
+	expected := bucketRetentionReport{
+		Objects:      5,
+		Retained:     2,
+		Governance:   1,
+		Compliance:   1,
+		LegalHold:    2,
+		EarliestDate: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+		LatestDate:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	if *report != expected {
+		t.Errorf("expected %+v, got %+v", expected, *report)
+	}
+	if date := formatRetentionDate(report.EarliestDate); date != "2024-06-01T00:00:00Z" {
+		t.Errorf("unexpected earliest date %q", date)
+	}
+	if date := formatRetentionDate(time.Time{}); date != "" {
+		t.Errorf("expected no date without retained objects, got %q", date)
+	}
+}
+
+func testAccMinioS3BucketRetentionCheckDataSourceConfig(bucketName string) string {
+	return fmt.Sprintf(`
+resource "minio_s3_bucket" "bucket" {
+  bucket        = %q
+  force_destroy = true
+}
+
+resource "minio_s3_object" "object" {
+  bucket_name = minio_s3_bucket.bucket.id
+  object_name = "records/a.txt"
+  content     = "hello"
+}
+
+data "minio_s3_bucket_retention_check" "check" {
+  bucket = minio_s3_bucket.bucket.id
+  prefix = "records/"
+
+  depends_on = [minio_s3_object.object]
+}
+`, bucketName)
+}
@@ -0,0 +1,142 @@
+package minio
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/minio/minio-go/v7"
+)
+
+func dataSourceMinioS3BucketUsage() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceMinioS3BucketUsageRead,
+
+		Schema: map[string]*schema.Schema{
+			"bucket": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validateMinioBucketName,
+			},
+			"prefixes": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Prefixes to compute the usage of, by listing their objects",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"last_update": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Last time the server scanner updated the usage",
+			},
+			"objects_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"versions_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"size": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"replication_pending_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"replication_failed_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"object_sizes_histogram": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+			},
+			"prefix_usage": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"prefix": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"objects_count": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"size": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceMinioS3BucketUsageRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	m := meta.(*S3MinioClient)
+	bucket := d.Get("bucket").(string)
+
+	if exists, err := m.S3Client.BucketExists(ctx, bucket); err != nil {
+		return NewResourceError("unable to check bucket", bucket, err)
+	} else if !exists {
+		return NewResourceError("unable to read bucket usage", bucket, minio.ErrorResponse{Code: "NoSuchBucket", Message: "The specified bucket does not exist"})
+	}
+
+	usage, err := m.S3Admin.DataUsageInfo(ctx)
+	if err != nil {
+		return NewResourceError("unable to read bucket usage", bucket, err)
+	}
+
+	// Buckets not scanned yet are missing from the usage and reported empty
+	bucketUsage := usage.BucketsUsage[bucket]
+
+	histogram := map[string]interface{}{}
+	for k, v := range bucketUsage.ObjectSizesHistogram {
+		histogram[k] = int(v)
+	}
+
+	prefixUsage := []interface{}{}
+	for _, p := range d.Get("prefixes").([]interface{}) {
+		prefix, _ := p.(string)
+
+		var count, size int64
+		for object := range m.S3Client.ListObjects(ctx, bucket, minio.ListObjectsOptions{Prefix: prefix, Recursive: true}) {
+			if object.Err != nil {
+				return NewResourceError("unable to list objects", bucket+"/"+prefix, object.Err)
+			}
+			count++
+			size += object.Size
+		}
+
+		log.Printf("[DEBUG] Prefix %s of bucket %s holds %d objects (%d bytes)", prefix, bucket, count, size)
+		prefixUsage = append(prefixUsage, map[string]interface{}{
+			"prefix":        prefix,
+			"objects_count": int(count),
+			"size":          int(size),
+		})
+	}
+
+	d.SetId(bucket)
+	_ = d.Set("last_update", usage.LastUpdate.Format(time.RFC3339))
+	_ = d.Set("objects_count", int(bucketUsage.ObjectsCount))
+	_ = d.Set("versions_count", int(bucketUsage.VersionsCount))
+	_ = d.Set("size", int(bucketUsage.Size))
+	_ = d.Set("replication_pending_count", int(bucketUsage.ReplicationPendingCount))
+	_ = d.Set("replication_failed_count", int(bucketUsage.ReplicationFailedCount))
+	if err := d.Set("object_sizes_histogram", histogram); err != nil {
+		return NewResourceError("unable to read bucket usage", bucket, err)
+	}
+	if err := d.Set("prefix_usage", prefixUsage); err != nil {
+		return NewResourceError("unable to read bucket usage", bucket, err)
+	}
+
+	return nil
+}
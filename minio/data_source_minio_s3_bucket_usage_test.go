@@ -0,0 +1,60 @@
+package minio
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccMinioDataSourceS3BucketUsage_basic(t *testing.T) {
+	bucketName := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMinioS3BucketUsageConfig(bucketName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.minio_s3_bucket_usage.usage", "id", bucketName),
+					resource.TestCheckResourceAttrSet("data.minio_s3_bucket_usage.usage", "last_update"),
+					resource.TestCheckResourceAttr("data.minio_s3_bucket_usage.usage", "prefix_usage.#", "2"),
+					resource.TestCheckResourceAttr("data.minio_s3_bucket_usage.usage", "prefix_usage.0.prefix", "logs/"),
+					resource.TestCheckResourceAttr("data.minio_s3_bucket_usage.usage", "prefix_usage.0.objects_count", "2"),
+					resource.TestCheckResourceAttr("data.minio_s3_bucket_usage.usage", "prefix_usage.0.size", "9"),
+					resource.TestCheckResourceAttr("data.minio_s3_bucket_usage.usage", "prefix_usage.1.prefix", "data/"),
+					resource.TestCheckResourceAttr("data.minio_s3_bucket_usage.usage", "prefix_usage.1.objects_count", "1"),
+				),
+			},
+		},
+	})
+}
+
+func testAccMinioS3BucketUsageConfig(bucketName string) string {
+	return fmt.Sprintf(`
+resource "minio_s3_bucket" "bucket" {
+  bucket = %q
+}
+
+resource "minio_s3_object" "objects" {
+  for_each = {
+    "logs/a.txt" = "aaaa"
+    "logs/b.txt" = "bbbbb"
+    "data/c.txt" = "c"
+  }
+
+  bucket_name = minio_s3_bucket.bucket.id
+  object_name = each.key
+  content     = each.value
+}
+
+data "minio_s3_bucket_usage" "usage" {
+  bucket   = minio_s3_bucket.bucket.id
+  prefixes = ["logs/", "data/"]
+
+  depends_on = [minio_s3_object.objects]
+}
+`, bucketName)
+}
@@ -0,0 +1,94 @@
+package minio
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/minio/minio-go/v7"
+)
+
+func dataSourceMinioS3Object() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceMinioS3ObjectRead,
+
+		Schema: map[string]*schema.Schema{
+			"bucket_name": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validateMinioBucketName,
+			},
+			"object_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"content": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"content_type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"etag": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"version_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Version of the object to read, the latest one being read when omitted",
+			},
+			"size": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"last_modified": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceMinioS3ObjectRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	m := meta.(*S3MinioClient)
+	bucketName := d.Get("bucket_name").(string)
+	objectName := d.Get("object_name").(string)
+	versionID := d.Get("version_id").(string)
+	id := bucketName + "/" + objectName
+	if versionID != "" {
+		id += "?versionId=" + versionID
+	}
+
+	object, err := m.S3Client.GetObject(ctx, bucketName, objectName, minio.GetObjectOptions{VersionID: versionID})
+	if err != nil {
+		return NewResourceError("reading object failed", id, err)
+	}
+	defer object.Close()
+
+	content, err := io.ReadAll(object)
+	if err != nil {
+		return NewResourceError("reading object failed", id, err)
+	}
+
+	objInfo, err := object.Stat()
+	if err != nil {
+		return NewResourceError("reading object failed", id, err)
+	}
+
+	d.SetId(id)
+	_ = d.Set("content", string(content))
+	_ = d.Set("content_type", objInfo.ContentType)
+	_ = d.Set("etag", objInfo.ETag)
+	_ = d.Set("version_id", objInfo.VersionID)
+	_ = d.Set("size", int(objInfo.Size))
+	_ = d.Set("last_modified", objInfo.LastModified.Format(time.RFC3339))
+
+	return nil
+}
@@ -0,0 +1,138 @@
+package minio
+
+import (
+	"context"
+	"io"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/minio/minio-go/v7"
+)
+
+func dataSourceMinioS3ObjectQuery() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceMinioS3ObjectQueryRead,
+
+		Schema: map[string]*schema.Schema{
+			"bucket_name": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validateMinioBucketName,
+			},
+			"object_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"expression": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.NoZeroValues,
+				Description:  "SQL expression to run, e.g. SELECT * FROM S3Object s WHERE s.env = 'prod'",
+			},
+			"input_format": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      string(minio.SelectObjectTypeCSV),
+				ValidateFunc: validation.StringInSlice([]string{string(minio.SelectObjectTypeCSV), string(minio.SelectObjectTypeJSON), string(minio.SelectObjectTypeParquet)}, false),
+				Description:  "Format of the object: CSV, JSON or Parquet",
+			},
+			"compression_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      string(minio.SelectCompressionNONE),
+				ValidateFunc: validation.StringInSlice([]string{string(minio.SelectCompressionNONE), string(minio.SelectCompressionGZIP), string(minio.SelectCompressionBZIP)}, false),
+				Description:  "Compression of the object: NONE, GZIP or BZIP2",
+			},
+			"csv_file_header_info": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      string(minio.CSVFileHeaderInfoUse),
+				ValidateFunc: validation.StringInSlice([]string{string(minio.CSVFileHeaderInfoUse), string(minio.CSVFileHeaderInfoIgnore), string(minio.CSVFileHeaderInfoNone)}, false),
+				Description:  "How the first line of a CSV object is used: USE to name the columns, IGNORE or NONE",
+			},
+			"csv_field_delimiter": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      ",",
+				ValidateFunc: validation.NoZeroValues,
+				Description:  "Field delimiter of a CSV object",
+			},
+			"json_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      string(minio.JSONLinesType),
+				ValidateFunc: validation.StringInSlice([]string{string(minio.JSONLinesType), string(minio.JSONDocumentType)}, false),
+				Description:  "Type of a JSON object: LINES for one document per line, or DOCUMENT",
+			},
+			"rows": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Matching rows, each one as a JSON document",
+			},
+		},
+	}
+}
+
+func dataSourceMinioS3ObjectQueryRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	m := meta.(*S3MinioClient)
+	bucketName := d.Get("bucket_name").(string)
+	objectName := d.Get("object_name").(string)
+	id := bucketName + "/" + objectName
+
+	opts := minio.SelectObjectOptions{
+		Expression:     d.Get("expression").(string),
+		ExpressionType: minio.QueryExpressionTypeSQL,
+		InputSerialization: minio.SelectObjectInputSerialization{
+			CompressionType: minio.SelectCompressionType(d.Get("compression_type").(string)),
+		},
+		OutputSerialization: minio.SelectObjectOutputSerialization{
+			JSON: &minio.JSONOutputOptions{},
+		},
+	}
+	opts.OutputSerialization.JSON.SetRecordDelimiter("\n")
+
+	switch minio.SelectObjectType(d.Get("input_format").(string)) {
+	case minio.SelectObjectTypeCSV:
+		opts.InputSerialization.CSV = &minio.CSVInputOptions{}
+		opts.InputSerialization.CSV.SetFileHeaderInfo(minio.CSVFileHeaderInfo(d.Get("csv_file_header_info").(string)))
+		opts.InputSerialization.CSV.SetFieldDelimiter(d.Get("csv_field_delimiter").(string))
+	case minio.SelectObjectTypeJSON:
+		opts.InputSerialization.JSON = &minio.JSONInputOptions{}
+		opts.InputSerialization.JSON.SetType(minio.JSONType(d.Get("json_type").(string)))
+	case minio.SelectObjectTypeParquet:
+		opts.InputSerialization.Parquet = &minio.ParquetInputOptions{}
+	}
+
+	results, err := m.S3Client.SelectObjectContent(ctx, bucketName, objectName, opts)
+	if err != nil {
+		return NewResourceError("querying object failed", id, err)
+	}
+	defer results.Close()
+
+	output, err := io.ReadAll(results)
+	if err != nil {
+		return NewResourceError("querying object failed", id, err)
+	}
+
+	d.SetId(id)
+	if err := d.Set("rows", splitSelectRecords(string(output))); err != nil {
+		return NewResourceError("querying object failed", id, err)
+	}
+
+	return nil
+}
+
+// splitSelectRecords splits the newline delimited records returned by S3 Select
+func splitSelectRecords(output string) []string {
+	rows := []string{}
+	for _, row := range strings.Split(output, "\n") {
+		if strings.TrimSpace(row) != "" {
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
@@ -0,0 +1,76 @@
+package minio
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccMinioDataSourceS3ObjectQuery_basic(t *testing.T) {
+	bucketName := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMinioS3ObjectQueryDataSourceConfig(bucketName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.minio_s3_object_query.prod", "rows.#", "2"),
+					resource.TestCheckResourceAttr("data.minio_s3_object_query.prod", "rows.0", `{"name":"api","env":"prod"}`),
+					resource.TestCheckResourceAttr("data.minio_s3_object_query.prod", "rows.1", `{"name":"web","env":"prod"}`),
+					resource.TestCheckResourceAttr("data.minio_s3_object_query.json", "rows.#", "1"),
+					resource.TestCheckResourceAttr("data.minio_s3_object_query.json", "rows.0", `{"name":"db"}`),
+				),
+			},
+		},
+	})
+}
+
+func TestSplitSelectRecords(t *testing.T) {
+	rows := splitSelectRecords("{\"a\":\"1\"}\n{\"a\":\"2\"}\n\n")
+	if !reflect.DeepEqual(rows, []string{`{"a":"1"}`, `{"a":"2"}`}) {
+		t.Errorf("unexpected rows %v", rows)
+	}
+	if rows := splitSelectRecords(""); len(rows) != 0 {
+		t.Errorf("expected no rows, got %v", rows)
+	}
+}
+
+func testAccMinioS3ObjectQueryDataSourceConfig(bucketName string) string {
+	return fmt.Sprintf(`
+resource "minio_s3_bucket" "bucket" {
+  bucket = %q
+}
+
+resource "minio_s3_object" "csv" {
+  bucket_name  = minio_s3_bucket.bucket.id
+  object_name  = "inventory.csv"
+  content      = "name,env\napi,prod\nworker,staging\nweb,prod\n"
+  content_type = "text/csv"
+}
+
+resource "minio_s3_object" "json" {
+  bucket_name  = minio_s3_bucket.bucket.id
+  object_name  = "inventory.json"
+  content      = "{\"name\":\"db\",\"env\":\"prod\"}\n{\"name\":\"cache\",\"env\":\"staging\"}\n"
+  content_type = "application/json"
+}
+
+data "minio_s3_object_query" "prod" {
+  bucket_name = minio_s3_object.csv.bucket_name
+  object_name = minio_s3_object.csv.object_name
+  expression  = "SELECT * FROM S3Object s WHERE s.env = 'prod'"
+}
+
+data "minio_s3_object_query" "json" {
+  bucket_name  = minio_s3_object.json.bucket_name
+  object_name  = minio_s3_object.json.object_name
+  input_format = "JSON"
+  expression   = "SELECT s.name FROM S3Object s WHERE s.env = 'prod'"
+}
+`, bucketName)
+}
@@ -0,0 +1,74 @@
+package minio
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccMinioDataSourceS3Object_basic(t *testing.T) {
+	bucketName := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMinioS3ObjectDataSourceConfig(bucketName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.minio_s3_object.object", "content", "hello"),
+					resource.TestCheckResourceAttr("data.minio_s3_object.object", "content_type", "text/plain"),
+					resource.TestCheckResourceAttr("data.minio_s3_object.object", "size", "5"),
+					resource.TestCheckResourceAttrPair("data.minio_s3_object.object", "etag", "minio_s3_object.object", "etag"),
+					resource.TestCheckResourceAttr("data.minio_s3_objects.all", "keys.#", "2"),
+					resource.TestCheckResourceAttr("data.minio_s3_objects.all", "keys.0", "dir/nested.txt"),
+					resource.TestCheckResourceAttr("data.minio_s3_objects.all", "keys.1", "hello.txt"),
+					resource.TestCheckResourceAttr("data.minio_s3_objects.top", "keys.#", "1"),
+					resource.TestCheckResourceAttr("data.minio_s3_objects.top", "common_prefixes.#", "1"),
+					resource.TestCheckResourceAttr("data.minio_s3_objects.top", "common_prefixes.0", "dir/"),
+				),
+			},
+		},
+	})
+}
+
+func testAccMinioS3ObjectDataSourceConfig(bucketName string) string {
+	return fmt.Sprintf(`
+resource "minio_s3_bucket" "bucket" {
+  bucket = %q
+}
+
+resource "minio_s3_object" "object" {
+  bucket_name  = minio_s3_bucket.bucket.id
+  object_name  = "hello.txt"
+  content      = "hello"
+  content_type = "text/plain"
+}
+
+resource "minio_s3_object" "nested" {
+  bucket_name = minio_s3_bucket.bucket.id
+  object_name = "dir/nested.txt"
+  content     = "nested"
+}
+
+data "minio_s3_object" "object" {
+  bucket_name = minio_s3_object.object.bucket_name
+  object_name = minio_s3_object.object.object_name
+}
+
+data "minio_s3_objects" "all" {
+  bucket_name = minio_s3_bucket.bucket.id
+
+  depends_on = [minio_s3_object.object, minio_s3_object.nested]
+}
+
+data "minio_s3_objects" "top" {
+  bucket_name = minio_s3_bucket.bucket.id
+  recursive   = false
+
+  depends_on = [minio_s3_object.object, minio_s3_object.nested]
+}
+`, bucketName)
+}
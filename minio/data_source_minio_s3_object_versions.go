@@ -0,0 +1,146 @@
+package minio
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/minio/minio-go/v7"
+)
+
+func dataSourceMinioS3ObjectVersions() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceMinioS3ObjectVersionsRead,
+
+		Schema: map[string]*schema.Schema{
+			"bucket_name": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validateMinioBucketName,
+			},
+			"object_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"include_delete_markers": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "List the delete markers along with the versions",
+			},
+			"max_versions": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1000,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"versions": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Versions of the object, from the most recent to the oldest",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"version_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"is_latest": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"is_delete_marker": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"etag": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"size": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"last_modified": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"version_ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "IDs of the versions, from the most recent to the oldest",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"previous_version_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Version preceding the latest one, empty when there is none, to roll back to it",
+			},
+		},
+	}
+}
+
+func dataSourceMinioS3ObjectVersionsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	m := meta.(*S3MinioClient)
+	bucketName := d.Get("bucket_name").(string)
+	objectName := d.Get("object_name").(string)
+	includeDeleteMarkers := d.Get("include_delete_markers").(bool)
+	maxVersions := d.Get("max_versions").(int)
+	id := bucketName + "/" + objectName
+
+	listCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	versions := []map[string]interface{}{}
+	versionIDs := []string{}
+	previousVersionID := ""
+	for object := range m.S3Client.ListObjects(listCtx, bucketName, minio.ListObjectsOptions{
+		Prefix:       objectName,
+		Recursive:    true,
+		WithVersions: true,
+	}) {
+		if object.Err != nil {
+			return NewResourceError("unable to list object versions", id, object.Err)
+		}
+		// The prefix also matches the keys starting with the object name
+		if object.Key != objectName {
+			continue
+		}
+		if object.IsDeleteMarker && !includeDeleteMarkers {
+			continue
+		}
+		if len(versions) >= maxVersions {
+			break
+		}
+
+		if !object.IsLatest && !object.IsDeleteMarker && previousVersionID == "" {
+			previousVersionID = object.VersionID
+		}
+
+		versions = append(versions, map[string]interface{}{
+			"version_id":       object.VersionID,
+			"is_latest":        object.IsLatest,
+			"is_delete_marker": object.IsDeleteMarker,
+			"etag":             object.ETag,
+			"size":             int(object.Size),
+			"last_modified":    object.LastModified.Format(time.RFC3339),
+		})
+		versionIDs = append(versionIDs, object.VersionID)
+	}
+
+	d.SetId(id)
+	if err := d.Set("versions", versions); err != nil {
+		return NewResourceError("unable to list object versions", id, err)
+	}
+	if err := d.Set("version_ids", versionIDs); err != nil {
+		return NewResourceError("unable to list object versions", id, err)
+	}
+	_ = d.Set("previous_version_id", previousVersionID)
+
+	return nil
+}
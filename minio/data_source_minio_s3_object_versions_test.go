@@ -0,0 +1,69 @@
+package minio
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccMinioDataSourceS3ObjectVersions_basic(t *testing.T) {
+	bucketName := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMinioS3ObjectVersionsDataSourceConfig(bucketName, "v1"),
+			},
+			{
+				Config: testAccMinioS3ObjectVersionsDataSourceConfig(bucketName, "v2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.minio_s3_object_versions.config", "versions.#", "2"),
+					resource.TestCheckResourceAttr("data.minio_s3_object_versions.config", "versions.0.is_latest", "true"),
+					resource.TestCheckResourceAttr("data.minio_s3_object_versions.config", "versions.1.is_latest", "false"),
+					resource.TestCheckResourceAttrPair("data.minio_s3_object_versions.config", "previous_version_id", "data.minio_s3_object_versions.config", "versions.1.version_id"),
+					resource.TestCheckResourceAttr("data.minio_s3_object.previous", "content", "v1"),
+				),
+			},
+		},
+	})
+}
+
+func testAccMinioS3ObjectVersionsDataSourceConfig(bucketName string, content string) string {
+	return fmt.Sprintf(`
+resource "minio_s3_bucket" "bucket" {
+  bucket        = %q
+  force_destroy = true
+}
+
+resource "minio_s3_bucket_versioning" "bucket" {
+  bucket = minio_s3_bucket.bucket.bucket
+
+  versioning_configuration {
+    status = "Enabled"
+  }
+}
+
+resource "minio_s3_object" "config" {
+  bucket_name = minio_s3_bucket_versioning.bucket.bucket
+  object_name = "config.json"
+  content     = %q
+}
+
+data "minio_s3_object_versions" "config" {
+  bucket_name = minio_s3_object.config.bucket_name
+  object_name = minio_s3_object.config.object_name
+
+  depends_on = [minio_s3_object.config]
+}
+
+data "minio_s3_object" "previous" {
+  bucket_name = minio_s3_object.config.bucket_name
+  object_name = minio_s3_object.config.object_name
+  version_id  = data.minio_s3_object_versions.config.previous_version_id
+}
+`, bucketName, content)
+}
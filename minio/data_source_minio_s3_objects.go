@@ -0,0 +1,91 @@
+package minio
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/minio/minio-go/v7"
+)
+
+func dataSourceMinioS3Objects() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceMinioS3ObjectsRead,
+
+		Schema: map[string]*schema.Schema{
+			"bucket_name": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validateMinioBucketName,
+			},
+			"prefix": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"recursive": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "List all the objects under the prefix instead of grouping them by directory",
+			},
+			"max_keys": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1000,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"keys": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"common_prefixes": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourceMinioS3ObjectsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	m := meta.(*S3MinioClient)
+	bucketName := d.Get("bucket_name").(string)
+	prefix := d.Get("prefix").(string)
+	maxKeys := d.Get("max_keys").(int)
+
+	listCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	keys := []string{}
+	commonPrefixes := []string{}
+	for object := range m.S3Client.ListObjects(listCtx, bucketName, minio.ListObjectsOptions{
+		Prefix:    prefix,
+		Recursive: d.Get("recursive").(bool),
+	}) {
+		if object.Err != nil {
+			return NewResourceError("unable to list objects", bucketName, object.Err)
+		}
+		if len(keys)+len(commonPrefixes) >= maxKeys {
+			break
+		}
+
+		// Non recursive listings return the directories as objects without an etag
+		if object.ETag == "" && object.Size == 0 && len(object.Key) > 0 && object.Key[len(object.Key)-1] == '/' {
+			commonPrefixes = append(commonPrefixes, object.Key)
+		} else {
+			keys = append(keys, object.Key)
+		}
+	}
+
+	d.SetId(bucketName + "/" + prefix)
+	if err := d.Set("keys", keys); err != nil {
+		return NewResourceError("unable to list objects", bucketName, err)
+	}
+	if err := d.Set("common_prefixes", commonPrefixes); err != nil {
+		return NewResourceError("unable to list objects", bucketName, err)
+	}
+
+	return nil
+}
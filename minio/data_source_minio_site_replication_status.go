@@ -0,0 +1,234 @@
+package minio
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/minio/madmin-go"
+)
+
+const (
+	siteReplicationInSync    = "in_sync"
+	siteReplicationOutOfSync = "out_of_sync"
+)
+
+func dataSourceMinioSiteReplicationStatus() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceMinioSiteReplicationStatusRead,
+
+		Timeouts: &schema.ResourceTimeout{
+			Read: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"wait_for_sync": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Wait until buckets, policies, users and groups are in sync across all sites",
+			},
+			"poll_interval": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "10s",
+				Description: "Interval between two status checks while waiting for the sites to be in sync",
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					if _, err := time.ParseDuration(v.(string)); err != nil {
+						errors = append(errors, fmt.Errorf("%q must be a valid duration: %v", k, err))
+					}
+					return
+				},
+			},
+			"enabled": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"in_sync": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"sites": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"endpoint": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"deployment_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"out_of_sync_buckets": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"out_of_sync_policies": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"out_of_sync_users": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"out_of_sync_groups": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourceMinioSiteReplicationStatusRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	admin := meta.(*S3MinioClient).S3Admin
+
+	opts := madmin.SRStatusOptions{
+		Buckets:  true,
+		Policies: true,
+		Users:    true,
+		Groups:   true,
+	}
+
+	info, err := admin.SRStatusInfo(ctx, opts)
+	if err != nil {
+		return NewResourceError("unable to read site replication status", "site-replication", err)
+	}
+
+	if d.Get("wait_for_sync").(bool) {
+		if !info.Enabled {
+			return NewResourceError("unable to wait for site replication", "site-replication", fmt.Errorf("site replication is not enabled"))
+		}
+
+		pollInterval, _ := time.ParseDuration(d.Get("poll_interval").(string))
+		stateConf := &retry.StateChangeConf{
+			Pending: []string{siteReplicationOutOfSync},
+			Target:  []string{siteReplicationInSync},
+			Refresh: func() (interface{}, string, error) {
+				info, err := admin.SRStatusInfo(ctx, opts)
+				if err != nil {
+					return nil, "", err
+				}
+				if siteReplicationIsInSync(info) {
+					return info, siteReplicationInSync, nil
+				}
+				return info, siteReplicationOutOfSync, nil
+			},
+			Timeout:      d.Timeout(schema.TimeoutRead),
+			PollInterval: pollInterval,
+		}
+
+		result, err := stateConf.WaitForStateContext(ctx)
+		if err != nil {
+			return NewResourceError("site replication did not converge", "site-replication", err)
+		}
+		info = result.(madmin.SRStatusInfo)
+	}
+
+	sites := make([]interface{}, 0, len(info.Sites))
+	for _, site := range info.Sites {
+		sites = append(sites, map[string]interface{}{
+			"name":          site.Name,
+			"endpoint":      site.Endpoint,
+			"deployment_id": site.DeploymentID,
+		})
+	}
+	sort.Slice(sites, func(i, j int) bool {
+		return sites[i].(map[string]interface{})["name"].(string) < sites[j].(map[string]interface{})["name"].(string)
+	})
+
+	_ = d.Set("enabled", info.Enabled)
+	_ = d.Set("in_sync", siteReplicationIsInSync(info))
+	_ = d.Set("out_of_sync_buckets", outOfSyncBuckets(info))
+	_ = d.Set("out_of_sync_policies", outOfSyncPolicies(info))
+	_ = d.Set("out_of_sync_users", outOfSyncUsers(info))
+	_ = d.Set("out_of_sync_groups", outOfSyncGroups(info))
+	if err := d.Set("sites", sites); err != nil {
+		return NewResourceError("unable to read site replication status", "site-replication", err)
+	}
+
+	d.SetId(meta.(*S3MinioClient).S3Client.EndpointURL().Host)
+
+	return nil
+}
+
+func siteReplicationIsInSync(info madmin.SRStatusInfo) bool {
+	return info.Enabled &&
+		len(outOfSyncBuckets(info)) == 0 &&
+		len(outOfSyncPolicies(info)) == 0 &&
+		len(outOfSyncUsers(info)) == 0 &&
+		len(outOfSyncGroups(info)) == 0
+}
+
+func outOfSyncBuckets(info madmin.SRStatusInfo) []string {
+	names := []string{}
+	for bucket, stats := range info.BucketStats {
+		for _, s := range stats {
+			if !s.HasBucket || s.TagMismatch || s.VersioningConfigMismatch || s.OLockConfigMismatch ||
+				s.PolicyMismatch || s.SSEConfigMismatch || s.ReplicationCfgMismatch || s.QuotaCfgMismatch {
+				names = append(names, bucket)
+				break
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func outOfSyncPolicies(info madmin.SRStatusInfo) []string {
+	names := []string{}
+	for policy, stats := range info.PolicyStats {
+		for _, s := range stats {
+			if !s.HasPolicy || s.PolicyMismatch {
+				names = append(names, policy)
+				break
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func outOfSyncUsers(info madmin.SRStatusInfo) []string {
+	names := []string{}
+	for user, stats := range info.UserStats {
+		for _, s := range stats {
+			if !s.HasUser || s.PolicyMismatch || s.UserInfoMismatch {
+				names = append(names, user)
+				break
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func outOfSyncGroups(info madmin.SRStatusInfo) []string {
+	names := []string{}
+	for group, stats := range info.GroupStats {
+		for _, s := range stats {
+			if !s.HasGroup || s.PolicyMismatch || s.GroupDescMismatch {
+				names = append(names, group)
+				break
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
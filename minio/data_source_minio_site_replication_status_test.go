@@ -0,0 +1,66 @@
+package minio
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/minio/madmin-go"
+)
+
+func TestAccMinioDataSourceSiteReplicationStatus_basic(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMinioSiteReplicationStatusConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.minio_site_replication_status.test", "enabled", "false"),
+					resource.TestCheckResourceAttr("data.minio_site_replication_status.test", "in_sync", "false"),
+					resource.TestCheckResourceAttr("data.minio_site_replication_status.test", "sites.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestSiteReplicationIsInSync(t *testing.T) {
+	info := madmin.SRStatusInfo{
+		Enabled: true,
+		BucketStats: map[string]map[string]madmin.SRBucketStatsSummary{
+			"synced": {
+				"site-a": {HasBucket: true},
+				"site-b": {HasBucket: true},
+			},
+		},
+	}
+	if !siteReplicationIsInSync(info) {
+		t.Errorf("expected sites to be in sync")
+	}
+
+	info.BucketStats["missing"] = map[string]madmin.SRBucketStatsSummary{
+		"site-a": {HasBucket: true},
+		"site-b": {HasBucket: false},
+	}
+	info.BucketStats["tags"] = map[string]madmin.SRBucketStatsSummary{
+		"site-a": {HasBucket: true, TagMismatch: true},
+	}
+	info.UserStats = map[string]map[string]madmin.SRUserStatsSummary{
+		"alice": {"site-b": {HasUser: true, PolicyMismatch: true}},
+	}
+
+	if siteReplicationIsInSync(info) {
+		t.Errorf("expected sites to be out of sync")
+	}
+	if buckets := outOfSyncBuckets(info); !reflect.DeepEqual(buckets, []string{"missing", "tags"}) {
+		t.Errorf("unexpected out of sync buckets: %v", buckets)
+	}
+	if users := outOfSyncUsers(info); !reflect.DeepEqual(users, []string{"alice"}) {
+		t.Errorf("unexpected out of sync users: %v", users)
+	}
+}
+
+const testAccMinioSiteReplicationStatusConfig = `
+data "minio_site_replication_status" "test" {}
+`
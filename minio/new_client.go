@@ -1,14 +1,22 @@
 package minio
 
 import (
+	"context"
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/pem"
 	"fmt"
 	"log"
+	"math"
+	"net"
 	"net/http"
+	"net/url"
 	"os"
+	"strings"
+	"sync"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/minio/madmin-go"
 	"github.com/minio/minio-go/v7"
 	"github.com/minio/minio-go/v7/pkg/credentials"
@@ -26,19 +34,37 @@ func (config *S3MinioConfig) NewClient() (client interface{}, err error) {
 		return nil, err
 	}
 
-	if config.S3APISignature == "v2" {
+	var transport http.RoundTripper = tr
+	if config.S3ParallelBucketLimit > 0 {
+		transport = newBucketLimitTransport(tr, config.S3ParallelBucketLimit)
+	}
+	if config.S3RequestsPerSecond > 0 {
+		transport = newRateLimitTransport(transport, config.S3RequestsPerSecond, config.S3RequestsBurst)
+	}
+
+	if config.S3Anonymous {
+		// Requests are left unsigned, only public buckets can be read
+		minioCredentials = credentials.New(&credentials.Static{
+			Value: credentials.Value{SignerType: credentials.SignatureAnonymous},
+		})
+		minioClient, err = minio.New(config.S3HostPort, &minio.Options{
+			Creds:     minioCredentials,
+			Secure:    config.S3SSL,
+			Transport: transport,
+		})
+	} else if config.S3APISignature == "v2" {
 		minioCredentials = credentials.NewStaticV2(config.S3UserAccess, config.S3UserSecret, config.S3SessionToken)
 		minioClient, err = minio.New(config.S3HostPort, &minio.Options{
 			Creds:     minioCredentials,
 			Secure:    config.S3SSL,
-			Transport: tr,
+			Transport: transport,
 		})
 	} else if config.S3APISignature == "v4" {
 		minioCredentials = credentials.NewStaticV4(config.S3UserAccess, config.S3UserSecret, config.S3SessionToken)
 		minioClient, err = minio.New(config.S3HostPort, &minio.Options{
 			Creds:     minioCredentials,
 			Secure:    config.S3SSL,
-			Transport: tr,
+			Transport: transport,
 		})
 	} else {
 		return nil, fmt.Errorf("unknown S3 API signature: %s, must be v2 or v4", config.S3APISignature)
@@ -48,8 +74,14 @@ func (config *S3MinioConfig) NewClient() (client interface{}, err error) {
 		return nil, err
 	}
 
+	// Admin operations may use dedicated credentials, the S3 ones are used otherwise
+	minioAdminCredentials := minioCredentials
+	if config.S3AdminAccess != "" {
+		minioAdminCredentials = credentials.NewStaticV4(config.S3AdminAccess, config.S3AdminSecret, "")
+	}
+
 	minioAdmin, err := madmin.NewWithOptions(config.S3HostPort, &madmin.Options{
-		Creds:  minioCredentials,
+		Creds:  minioAdminCredentials,
 		Secure: config.S3SSL,
 	})
 	//minioAdmin.TraceOn(nil)
@@ -57,16 +89,161 @@ func (config *S3MinioConfig) NewClient() (client interface{}, err error) {
 		log.Println("[FATAL] Error building admin client for S3 server.")
 		return nil, err
 	}
-	minioAdmin.SetCustomTransport(tr)
+	minioAdmin.SetCustomTransport(transport)
+
+	minioHealth, err := madmin.NewAnonymousClient(config.S3HostPort, config.S3SSL)
+	if err != nil {
+		log.Println("[FATAL] Error building health check client for S3 server.")
+		return nil, err
+	}
+	minioHealth.SetCustomTransport(transport)
 
 	return &S3MinioClient{
 		S3UserAccess: config.S3UserAccess,
 		S3Region:     config.S3Region,
 		S3Client:     minioClient,
 		S3Admin:      minioAdmin,
+		S3Health:     minioHealth,
+
+		ReplicationCache: newReplicationCache(minioClient, minioAdmin, replicationCacheTTL),
+		ServerRelease:    newServerRelease(minioAdmin),
+		Endpoints:        newEndpointClients(config),
+
+		StrictBucketPolicies: config.S3StrictBucketPolicies,
+
+		Mock: config.S3MockStateFile != "",
 	}, nil
 }
 
+// waitForCluster polls the readiness endpoint until the cluster can serve requests
+func (config *S3MinioConfig) waitForCluster(ctx context.Context) error {
+	tr, err := config.customTransport()
+	if err != nil {
+		return err
+	}
+
+	scheme := "http"
+	if config.S3SSL {
+		scheme = "https"
+	}
+	readyURL := fmt.Sprintf("%s://%s/minio/health/ready", scheme, config.S3HostPort)
+	httpClient := &http.Client{Transport: tr, Timeout: 10 * time.Second}
+
+	log.Printf("[DEBUG] Waiting up to %s for %s to be ready", config.S3WaitForCluster, readyURL)
+
+	return retry.RetryContext(ctx, config.S3WaitForCluster, func() *retry.RetryError {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, readyURL, nil)
+		if err != nil {
+			return retry.NonRetryableError(err)
+		}
+
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			return retry.RetryableError(err)
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return retry.RetryableError(fmt.Errorf("%s returned %s", readyURL, resp.Status))
+		}
+		return nil
+	})
+}
+
+// bucketLimitTransport limits the number of bucket-mutating requests in flight
+type bucketLimitTransport struct {
+	http.RoundTripper
+	slots chan struct{}
+}
+
+func newBucketLimitTransport(tr http.RoundTripper, limit int) *bucketLimitTransport {
+	return &bucketLimitTransport{
+		RoundTripper: tr,
+		slots:        make(chan struct{}, limit),
+	}
+}
+
+func (t *bucketLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !isBucketMutatingRequest(req) {
+		return t.RoundTripper.RoundTrip(req)
+	}
+
+	select {
+	case t.slots <- struct{}{}:
+	case <-req.Context().Done():
+		return nil, req.Context().Err()
+	}
+	defer func() { <-t.slots }()
+
+	return t.RoundTripper.RoundTrip(req)
+}
+
+// rateLimitTransport spreads the requests with a token bucket: up to burst requests are sent at once,
+// then one more every 1/rate second.
+type rateLimitTransport struct {
+	http.RoundTripper
+	rate  float64
+	burst float64
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+func newRateLimitTransport(tr http.RoundTripper, rate float64, burst int) *rateLimitTransport {
+	if burst <= 0 {
+		burst = int(math.Ceil(rate))
+	}
+	return &rateLimitTransport{
+		RoundTripper: tr,
+		rate:         rate,
+		burst:        float64(burst),
+		tokens:       float64(burst),
+		last:         time.Now(),
+	}
+}
+
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if delay := t.reserve(time.Now()); delay > 0 {
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+	}
+
+	return t.RoundTripper.RoundTrip(req)
+}
+
+// reserve takes a token and returns how long to wait for it. Tokens go below zero while requests are
+// queued, so they are sent in the order they arrived.
+func (t *rateLimitTransport) reserve(now time.Time) time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.tokens = math.Min(t.burst, t.tokens+now.Sub(t.last).Seconds()*t.rate)
+	t.last = now
+	t.tokens--
+
+	if t.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-t.tokens / t.rate * float64(time.Second))
+}
+
+// isBucketMutatingRequest tells whether a path-style request creates, deletes
+// or configures a bucket, as opposed to an object or an admin API call.
+func isBucketMutatingRequest(req *http.Request) bool {
+	if req.Method != http.MethodPut && req.Method != http.MethodDelete {
+		return false
+	}
+
+	path := strings.Trim(req.URL.Path, "/")
+	return path != "" && !strings.Contains(path, "/")
+}
+
 func isValidCertificate(c []byte) bool {
 	p, _ := pem.Decode(c)
 	if p == nil {
@@ -79,7 +256,11 @@ func isValidCertificate(c []byte) bool {
 func (config *S3MinioConfig) customTransport() (*http.Transport, error) {
 
 	if !config.S3SSL {
-		return minio.DefaultTransport(config.S3SSL)
+		tr, err := minio.DefaultTransport(config.S3SSL)
+		if err != nil {
+			return nil, err
+		}
+		return tr, config.configureConnections(tr)
 	}
 
 	tlsConfig := &tls.Config{
@@ -130,7 +311,64 @@ func (config *S3MinioConfig) customTransport() (*http.Transport, error) {
 
 	tr.TLSClientConfig = tlsConfig
 
+	if err := config.configureConnections(tr); err != nil {
+		return nil, err
+	}
+
 	log.Printf("[DEBUG] S3 SSL client initialized")
 
 	return tr, nil
 }
+
+// configureConnections applies the proxy and connection settings of the provider to a transport, those left
+// unset keeping the minio-go defaults
+func (config *S3MinioConfig) configureConnections(tr *http.Transport) error {
+	if config.S3HTTPProxy != "" {
+		proxyURL, err := url.Parse(config.S3HTTPProxy)
+		if err != nil {
+			return fmt.Errorf("invalid HTTP proxy %q: %w", config.S3HTTPProxy, err)
+		}
+		tr.Proxy = http.ProxyURL(proxyURL)
+	}
+	if config.S3MaxIdleConnections > 0 {
+		tr.MaxIdleConns = config.S3MaxIdleConnections
+		if tr.MaxIdleConnsPerHost > tr.MaxIdleConns {
+			tr.MaxIdleConnsPerHost = tr.MaxIdleConns
+		}
+	}
+	if config.S3MaxConnectionsPerHost > 0 {
+		tr.MaxConnsPerHost = config.S3MaxConnectionsPerHost
+	}
+	tr.DisableKeepAlives = config.S3DisableKeepAlives
+	if config.S3DialTimeout > 0 {
+		tr.DialContext = (&net.Dialer{
+			Timeout:   config.S3DialTimeout,
+			KeepAlive: 15 * time.Second,
+		}).DialContext
+	}
+	return nil
+}
+
+// validateProxyURL checks a proxy is an absolute URL of a scheme supported by the HTTP transport
+func validateProxyURL(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if value == "" {
+		return
+	}
+
+	proxyURL, err := url.Parse(value)
+	if err != nil {
+		errors = append(errors, fmt.Errorf("%q must be a valid URL: %v", k, err))
+		return
+	}
+	switch proxyURL.Scheme {
+	case "http", "https", "socks5":
+	default:
+		errors = append(errors, fmt.Errorf("%q must be an http, https or socks5 URL, got %q", k, value))
+		return
+	}
+	if proxyURL.Host == "" {
+		errors = append(errors, fmt.Errorf("%q must have a host, got %q", k, value))
+	}
+	return
+}
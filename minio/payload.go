@@ -12,17 +12,37 @@ import (
 
 // S3MinioConfig defines variable for minio
 type S3MinioConfig struct {
-	S3HostPort      string
-	S3UserAccess    string
-	S3UserSecret    string
-	S3Region        string
-	S3SessionToken  string
-	S3APISignature  string
-	S3SSL           bool
-	S3SSLCACertFile string
-	S3SSLCertFile   string
-	S3SSLKeyFile    string
-	S3SSLSkipVerify bool
+	S3HostPort            string
+	S3UserAccess          string
+	S3UserSecret          string
+	S3AdminAccess         string
+	S3AdminSecret         string
+	S3Region              string
+	S3SessionToken        string
+	S3UserFile            string
+	S3PasswordFile        string
+	S3SessionTokenFile    string
+	S3Anonymous           bool
+	S3APISignature        string
+	S3SSL                 bool
+	S3SSLCACertFile       string
+	S3SSLCertFile         string
+	S3SSLKeyFile          string
+	S3SSLSkipVerify       bool
+	S3WaitForCluster      time.Duration
+	S3ParallelBucketLimit int
+	S3RequestsPerSecond   float64
+	S3RequestsBurst       int
+
+	S3HTTPProxy             string
+	S3MaxIdleConnections    int
+	S3MaxConnectionsPerHost int
+	S3DisableKeepAlives     bool
+	S3DialTimeout           time.Duration
+
+	S3StrictBucketPolicies bool
+
+	S3MockStateFile string
 }
 
 // S3MinioClient defines default minio
@@ -31,17 +51,53 @@ type S3MinioClient struct {
 	S3Region     string
 	S3Client     *minio.Client
 	S3Admin      *madmin.AdminClient
+
+	// S3Health calls the unauthenticated health check endpoints
+	S3Health *madmin.AnonymousClient
+
+	// Clusters holds the clients of the additional clusters, by name
+	Clusters map[string]*S3MinioClient
+
+	// Endpoints holds the clients of the endpoints overridden by resources
+	Endpoints *endpointClients
+
+	// ReplicationCache shares the replication reads between the steps of a resource operation
+	ReplicationCache *replicationCache
+
+	// ServerRelease is the release of the cluster, read once to check the features it supports
+	ServerRelease *serverRelease
+
+	// StrictBucketPolicies rejects the bucket policies granting write actions to everyone
+	StrictBucketPolicies bool
+
+	// Mock tells the client is served by the mock server, which does not serve the admin API
+	Mock bool
 }
 
 // S3MinioBucket defines minio config
 type S3MinioBucket struct {
+	MinioClient              *minio.Client
+	MinioAdmin               *madmin.AdminClient
+	MinioRegion              string
+	MinioBucket              string
+	MinioBucketPrefix        string
+	MinioACL                 string
+	MinioAccess              string
+	MinioForceDestroy        bool
+	MinioForceDestroyWorkers int
+	MinioObjectLocking       bool
+
+	// MinioForceDestroyBypassGovernance removes the object versions under GOVERNANCE retention too
+	MinioForceDestroyBypassGovernance bool
+}
+
+// S3MinioProjectConfig defines project config
+type S3MinioProjectConfig struct {
 	MinioClient       *minio.Client
 	MinioAdmin        *madmin.AdminClient
 	MinioRegion       string
-	MinioBucket       string
-	MinioBucketPrefix string
-	MinioACL          string
-	MinioAccess       string
+	MinioProjectName  string
+	MinioAccessKey    string
 	MinioForceDestroy bool
 }
 
@@ -86,19 +142,26 @@ type S3MinioBucketReplicationRule struct {
 	Enabled  bool
 	Priority int
 
+	// Set when the rule references a remote target managed by a minio_s3_bucket_remote_target instead of its Target
+	RemoteTargetArn string
+
 	Prefix string
 	Tags   map[string]string
 
 	DeleteReplication         bool
 	DeleteMarkerReplication   bool
 	ExistingObjectReplication bool
-	MetadataSync              bool
+	ReplicaModifications      bool
+
+	// Set when delete_marker_replication is omitted, so the server default applies
+	DeleteMarkerReplicationDefault bool
 
 	Target S3MinioBucketReplicationRuleTarget
 }
 
 // S3MinioBucketReplicationRuleTarget defines bucket replication rule target
 type S3MinioBucketReplicationRuleTarget struct {
+	Type              string
 	Bucket            string
 	StorageClass      string
 	Host              string
@@ -111,6 +174,10 @@ type S3MinioBucketReplicationRuleTarget struct {
 	Region            string
 	AccessKey         string
 	SecretKey         string
+
+	// TLS settings of the connections of the provider to the target only, the remote target API has none
+	ProbeInsecureSkipVerify bool
+	ProbeCACertPEM          string
 }
 
 // S3MinioBucketVersioning defines bucket versioning
@@ -126,6 +193,25 @@ type S3MinioBucketReplication struct {
 	MinioClient      *minio.Client
 	MinioBucket      string
 	ReplicationRules []S3MinioBucketReplicationRule
+
+	// Only the rules with these IDs are managed when IgnoreUnmanagedRules is set
+	IgnoreUnmanagedRules bool
+	ManagedRuleIDs       []string
+
+	// Remote targets created by the resource but referenced by no rule are removed on apply when set, and
+	// reported otherwise
+	PurgeOrphanTargets bool
+
+	// Remote targets the rules reference with remote_target_arn, now or in the state. They are managed by
+	// minio_s3_bucket_remote_target resources and never removed
+	ExternalRemoteTargetARNs []string
+
+	// Remote targets created by the resource, which a failed apply may have left unreferenced. The new ones
+	// are added on apply, and no other target is ever removed
+	CreatedRemoteTargetARNs []string
+
+	ReplicationCache *replicationCache
+	ServerRelease    *serverRelease
 }
 
 // S3MinioBucketNotification
@@ -145,17 +231,20 @@ type S3MinioServiceAccountConfig struct {
 	MinioForceDestroy bool
 	MinioUpdateKey    bool
 	MinioIAMTags      map[string]string
+	MinioPolicy       string
 }
 
 // S3MinioIAMUserConfig defines IAM config
 type S3MinioIAMUserConfig struct {
-	MinioAdmin        *madmin.AdminClient
-	MinioIAMName      string
-	MinioSecret       string
-	MinioDisableUser  bool
-	MinioForceDestroy bool
-	MinioUpdateKey    bool
-	MinioIAMTags      map[string]string
+	MinioAdmin         *madmin.AdminClient
+	MinioIAMName       string
+	MinioIAMNamePrefix string
+	MinioSecret        string
+	MinioDisableUser   bool
+	MinioForceDestroy  bool
+	MinioUpdateKey     bool
+	MinioExpiration    string
+	MinioIAMTags       map[string]string
 }
 
 // S3MinioIAMGroupConfig defines IAM Group config
@@ -220,12 +309,14 @@ type IAMPolicyDoc struct {
 
 // IAMPolicyStatement returns IAM policy statement
 type IAMPolicyStatement struct {
-	Sid        string
-	Effect     string      `json:",omitempty"`
-	Actions    interface{} `json:"Action,omitempty"`
-	Resources  interface{} `json:"Resource,omitempty"`
-	Principal  string      `json:"Principal,omitempty"`
-	Conditions interface{} `json:"Condition,omitempty"`
+	Sid          string
+	Effect       string      `json:",omitempty"`
+	Actions      interface{} `json:"Action,omitempty"`
+	NotActions   interface{} `json:"NotAction,omitempty"`
+	Resources    interface{} `json:"Resource,omitempty"`
+	NotResources interface{} `json:"NotResource,omitempty"`
+	Principal    string      `json:"Principal,omitempty"`
+	Conditions   interface{} `json:"Condition,omitempty"`
 }
 
 // IAMPolicyStatementCondition returns IAM policy condition
@@ -0,0 +1,200 @@
+package minio
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// policyVariables are the variables MinIO substitutes in the resources and conditions of a policy, with the
+// example value they expand to in example_json
+var policyVariables = map[string]string{
+	"aws:CurrentTime":        "2023-01-01T00:00:00Z",
+	"aws:EpochTime":          "1672531200",
+	"aws:Referer":            "https://example.com/",
+	"aws:SecureTransport":    "true",
+	"aws:SourceIp":           "192.0.2.10",
+	"aws:UserAgent":          "MinIO (linux; amd64) minio-go/v7",
+	"aws:groups":             "developers",
+	"aws:principaltype":      "User",
+	"aws:userid":             "alice",
+	"aws:username":           "alice",
+	"jwt:address":            "1 Example Street",
+	"jwt:aud":                "minio",
+	"jwt:birthdate":          "1970-01-01",
+	"jwt:client_id":          "minio",
+	"jwt:email":              "alice@example.com",
+	"jwt:family_name":        "Liddell",
+	"jwt:gender":             "female",
+	"jwt:given_name":         "Alice",
+	"jwt:groups":             "developers",
+	"jwt:iss":                "https://idp.example.com",
+	"jwt:jti":                "2c1e7a5f",
+	"jwt:middle_name":        "Pleasance",
+	"jwt:name":               "Alice Liddell",
+	"jwt:nickname":           "alice",
+	"jwt:phone_number":       "+1 555 0100",
+	"jwt:picture":            "https://idp.example.com/alice.png",
+	"jwt:preferred_username": "alice",
+	"jwt:profile":            "https://idp.example.com/alice",
+	"jwt:scope":              "openid",
+	"jwt:sub":                "5f2b1c9e-4d3a-4c8b-9e1f-0a7d6b5c4e3f",
+	"jwt:upn":                "alice@example.com",
+	"jwt:website":            "https://example.com/",
+	"ldap:groups":            "cn=developers,ou=groups,dc=example,dc=com",
+	"ldap:user":              "uid=alice,ou=people,dc=example,dc=com",
+	"ldap:username":          "alice",
+	"s3:LocationConstraint":  "us-east-1",
+	"s3:authType":            "REST-HEADER",
+	"s3:delimiter":           "/",
+	"s3:max-keys":            "1000",
+	"s3:prefix":              "home/alice/",
+	"s3:signatureversion":    "AWS4-HMAC-SHA256",
+	"s3:versionid":           "3b0c5b1e-7f4d-4d6a-8c2b-1e9f0a7d6c5b",
+}
+
+// policyVariablePrefixes are the variables taking the name of a tag or a header after their prefix
+var policyVariablePrefixes = []string{
+	"s3:ExistingObjectTag/",
+	"s3:RequestObjectTag/",
+	"s3:x-amz-",
+}
+
+// policyVariableEscapes are the variables standing for a literal character, where it would be a wildcard or
+// start a variable
+var policyVariableEscapes = map[string]string{
+	"*": "*",
+	"?": "?",
+	"$": "$",
+}
+
+// policyVariableClaimPattern matches the claims of the identity providers, which may not be standard ones
+var policyVariableClaimPattern = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+// policyVariableReferences returns the names of the variables of a policy, in order of appearance, and fails
+// on a variable not closed
+func policyVariableReferences(policy string) ([]string, error) {
+	names := []string{}
+	for rest := policy; ; {
+		start := strings.Index(rest, "${")
+		if start < 0 {
+			return names, nil
+		}
+		rest = rest[start+2:]
+		end := strings.IndexAny(rest, "}\"")
+		if end < 0 || rest[end] != '}' {
+			return nil, fmt.Errorf("policy variable ${%s is not closed", strings.SplitN(rest, "\"", 2)[0])
+		}
+		names = append(names, rest[:end])
+		rest = rest[end+1:]
+	}
+}
+
+// validatePolicyVariables checks the variables of a policy are supported by MinIO. The claims of the identity
+// providers which are not standard ones only raise a warning, as they may be set by the provider.
+func validatePolicyVariables(policy string) (ws []string, errors []error) {
+	names, err := policyVariableReferences(policy)
+	if err != nil {
+		return nil, []error{err}
+	}
+
+	for _, name := range names {
+		if _, ok := policyVariableEscapes[name]; ok {
+			continue
+		}
+		if _, ok := policyVariables[name]; ok || isPrefixedPolicyVariable(name) {
+			continue
+		}
+
+		namespace, key, found := strings.Cut(name, ":")
+		if !found || key == "" {
+			errors = append(errors, fmt.Errorf("policy variable ${%s} must be in the form ${namespace:key}", name))
+			continue
+		}
+
+		switch namespace {
+		case "aws", "ldap", "s3":
+			if suggestion := policyVariableSuggestion(name); suggestion != "" {
+				errors = append(errors, fmt.Errorf("unsupported policy variable ${%s}, did you mean ${%s}?", name, suggestion))
+			} else {
+				errors = append(errors, fmt.Errorf("unsupported policy variable ${%s}", name))
+			}
+		case "jwt":
+			if !policyVariableClaimPattern.MatchString(key) {
+				errors = append(errors, fmt.Errorf("policy variable ${%s} is not a valid claim name", name))
+			} else if suggestion := policyVariableSuggestion(name); suggestion != "" {
+				errors = append(errors, fmt.Errorf("unsupported policy variable ${%s}, did you mean ${%s}?", name, suggestion))
+			} else {
+				ws = append(ws, fmt.Sprintf("policy variable ${%s} is not a standard claim, it expands to an empty value when the identity provider does not set it", name))
+			}
+		default:
+			errors = append(errors, fmt.Errorf("policy variable ${%s} has an unsupported namespace %q, expected one of aws, jwt, ldap or s3", name, namespace))
+		}
+	}
+	return
+}
+
+// expandPolicyVariables replaces the variables of a policy with example values, to show what they expand to
+func expandPolicyVariables(policy string) string {
+	var expanded strings.Builder
+	for rest := policy; ; {
+		start := strings.Index(rest, "${")
+		if start < 0 {
+			expanded.WriteString(rest)
+			return expanded.String()
+		}
+		end := strings.Index(rest[start:], "}")
+		if end < 0 {
+			expanded.WriteString(rest)
+			return expanded.String()
+		}
+		end += start
+
+		expanded.WriteString(rest[:start])
+		expanded.WriteString(policyVariableExample(rest[start+2 : end]))
+		rest = rest[end+1:]
+	}
+}
+
+func policyVariableExample(name string) string {
+	if value, ok := policyVariableEscapes[name]; ok {
+		return value
+	}
+	if value, ok := policyVariables[name]; ok {
+		return value
+	}
+	_, key, _ := strings.Cut(name, ":")
+	if i := strings.LastIndexAny(key, "/-"); i >= 0 {
+		key = key[i+1:]
+	}
+	return "example-" + key
+}
+
+func isPrefixedPolicyVariable(name string) bool {
+	for _, prefix := range policyVariablePrefixes {
+		if strings.HasPrefix(name, prefix) && len(name) > len(prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// policyVariableSuggestion returns the supported variable differing only by its case or its separators from
+// a variable, the most common typos
+func policyVariableSuggestion(name string) string {
+	normalize := strings.NewReplacer("_", "", "-", "").Replace
+	wanted := strings.ToLower(normalize(name))
+
+	suggestions := []string{}
+	for known := range policyVariables {
+		if strings.ToLower(normalize(known)) == wanted {
+			suggestions = append(suggestions, known)
+		}
+	}
+	if len(suggestions) == 0 {
+		return ""
+	}
+	sort.Strings(suggestions)
+	return suggestions[0]
+}
@@ -0,0 +1,48 @@
+package minio
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidatePolicyVariables(t *testing.T) {
+	cases := []struct {
+		policy   string
+		warnings int
+		err      string
+	}{
+		{policy: `{"Resource":["arn:aws:s3:::home/${aws:username}/*"]}`},
+		{policy: `{"Condition":{"StringEquals":{"s3:prefix":["${jwt:sub}/${jwt:preferred_username}"]}}}`},
+		{policy: `{"Condition":{"StringEquals":{"s3:ExistingObjectTag/owner":["${s3:ExistingObjectTag/team}"]}}}`},
+		{policy: `{"Resource":["arn:aws:s3:::bucket/${*}literal"]}`},
+		{policy: `{"Resource":["arn:aws:s3:::bucket/${jwt:tenant}/*"]}`, warnings: 1},
+		{policy: `{"Resource":["arn:aws:s3:::bucket/${aws:userName}/*"]}`, err: "did you mean ${aws:username}?"},
+		{policy: `{"Resource":["arn:aws:s3:::bucket/${jwt:preferred-username}/*"]}`, err: "did you mean ${jwt:preferred_username}?"},
+		{policy: `{"Resource":["arn:aws:s3:::bucket/${aws:user}/*"]}`, err: "unsupported policy variable ${aws:user}"},
+		{policy: `{"Resource":["arn:aws:s3:::bucket/${oidc:sub}/*"]}`, err: "unsupported namespace \"oidc\""},
+		{policy: `{"Resource":["arn:aws:s3:::bucket/${username}/*"]}`, err: "must be in the form ${namespace:key}"},
+		{policy: `{"Resource":["arn:aws:s3:::bucket/${jwt:sub/*"]}`, err: "${jwt:sub/* is not closed"},
+	}
+
+	for _, c := range cases {
+		ws, errs := validatePolicyVariables(c.policy)
+		if c.err == "" {
+			if len(errs) != 0 {
+				t.Errorf("%s: unexpected errors %v", c.policy, errs)
+			}
+		} else if len(errs) != 1 || !strings.Contains(errs[0].Error(), c.err) {
+			t.Errorf("%s: expected error %q, got %v", c.policy, c.err, errs)
+		}
+		if len(ws) != c.warnings {
+			t.Errorf("%s: expected %d warnings, got %v", c.policy, c.warnings, ws)
+		}
+	}
+}
+
+func TestExpandPolicyVariables(t *testing.T) {
+	policy := `{"Resource":["arn:aws:s3:::home/${aws:username}/${jwt:tenant}/${*}"]}`
+	expected := `{"Resource":["arn:aws:s3:::home/alice/example-tenant/*"]}`
+	if expanded := expandPolicyVariables(policy); expanded != expected {
+		t.Errorf("expected %s, got %s", expected, expanded)
+	}
+}
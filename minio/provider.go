@@ -2,9 +2,14 @@ package minio
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"golang.org/x/exp/slices"
 )
 
 // Provider creates a new provider
@@ -13,12 +18,12 @@ func Provider(envvarPrefixed ...string) *schema.Provider {
 	if len(envvarPrefixed) != 0 {
 		envVarPrefix = envvarPrefixed[0]
 	}
-	return &schema.Provider{
+	provider := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"minio_server": {
 				Type:        schema.TypeString,
-				Required:    true,
-				Description: "Minio Host and Port",
+				Optional:    true,
+				Description: "Minio Host and Port, required unless mock_state_file is set",
 				DefaultFunc: schema.MultiEnvDefaultFunc([]string{
 					envVarPrefix + "MINIO_ENDPOINT",
 				}, nil),
@@ -65,19 +70,82 @@ func Provider(envvarPrefixed ...string) *schema.Provider {
 				}, nil),
 				ConflictsWith: []string{"minio_secret_key"},
 			},
+			"minio_user_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "File holding the Minio User, e.g. mounted by a secret manager",
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{
+					envVarPrefix + "MINIO_USER_FILE",
+				}, nil),
+				ConflictsWith: []string{"minio_user", "minio_access_key"},
+			},
+			"minio_password_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "File holding the Minio Password, e.g. mounted by a secret manager",
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{
+					envVarPrefix + "MINIO_PASSWORD_FILE",
+				}, nil),
+				ConflictsWith: []string{"minio_password", "minio_secret_key"},
+			},
+			"minio_admin_user": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Minio User used for admin operations (default: minio_user)",
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{
+					envVarPrefix + "MINIO_ADMIN_USER",
+				}, nil),
+				RequiredWith: []string{"minio_admin_password"},
+			},
+			"minio_admin_password": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Minio Password used for admin operations (default: minio_password)",
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{
+					envVarPrefix + "MINIO_ADMIN_PASSWORD",
+				}, nil),
+				RequiredWith: []string{"minio_admin_user"},
+			},
+			"minio_anonymous": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Send unauthenticated requests, to read public buckets without credentials (default: false)",
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{
+					envVarPrefix + "MINIO_ANONYMOUS",
+				}, false),
+				ConflictsWith: []string{"minio_user", "minio_password", "minio_access_key", "minio_secret_key", "minio_admin_user", "minio_session_token", "minio_user_file", "minio_password_file", "minio_session_token_file"},
+			},
 			"minio_session_token": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				Description: "Minio Session Token",
+				Description: "Minio Session Token of temporary credentials, e.g. issued by the MinIO STS API",
 				DefaultFunc: schema.MultiEnvDefaultFunc([]string{
 					envVarPrefix + "MINIO_SESSION_TOKEN",
 				}, ""),
 			},
-			"minio_api_version": {
+			"minio_session_token_file": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				Default:     "v4",
-				Description: "Minio API Version (type: string, options: v2 or v4, default: v4)",
+				Description: "File holding the Minio Session Token, e.g. mounted by a secret manager",
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{
+					envVarPrefix + "MINIO_SESSION_TOKEN_FILE",
+				}, nil),
+				ConflictsWith: []string{"minio_session_token"},
+			},
+			"signature_version": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "Signature used for the S3 requests (type: string, options: v2 or v4, default: v4)",
+				ValidateFunc:  validation.StringInSlice([]string{"v2", "v4"}, false),
+				ConflictsWith: []string{"minio_api_version"},
+			},
+			"minio_api_version": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Minio API Version (type: string, options: v2 or v4, default: v4)",
+				ValidateFunc: validation.StringInSlice([]string{"v2", "v4"}, false),
+				Deprecated:   "use signature_version instead",
 			},
 			"minio_ssl": {
 				Type:        schema.TypeBool,
@@ -116,41 +184,249 @@ func Provider(envvarPrefixed ...string) *schema.Provider {
 					envVarPrefix + "MINIO_KEY_FILE",
 				}, nil),
 			},
+			"wait_for_cluster": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Wait up to this duration for the MinIO cluster to report ready before making any API call (e.g. 5m)",
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{
+					envVarPrefix + "MINIO_WAIT_FOR_CLUSTER",
+				}, ""),
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					if value := v.(string); value != "" {
+						if _, err := time.ParseDuration(value); err != nil {
+							errors = append(errors, fmt.Errorf("%q must be a valid duration: %v", k, err))
+						}
+					}
+					return
+				},
+			},
+			"clusters": clusterSchema(),
+			"parallel_bucket_limit": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Maximum number of concurrent requests creating, deleting or configuring buckets (default: 0, unlimited)",
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{
+					envVarPrefix + "MINIO_PARALLEL_BUCKET_LIMIT",
+				}, 0),
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"requests_per_second": {
+				Type:        schema.TypeFloat,
+				Optional:    true,
+				Description: "Maximum number of requests per second sent to each cluster, S3 and admin APIs together (default: 0, unlimited)",
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{
+					envVarPrefix + "MINIO_REQUESTS_PER_SECOND",
+				}, 0.0),
+				ValidateFunc: validation.FloatAtLeast(0),
+			},
+			"requests_burst": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Number of requests which can be sent at once above requests_per_second (default: requests_per_second rounded up)",
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{
+					envVarPrefix + "MINIO_REQUESTS_BURST",
+				}, 0),
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"http_proxy": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "URL of the proxy the requests are sent through, instead of the one of the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables",
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{
+					envVarPrefix + "MINIO_HTTP_PROXY",
+				}, ""),
+				ValidateFunc: validateProxyURL,
+			},
+			"max_idle_connections": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Maximum number of idle connections kept open to each cluster (default: 256)",
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{
+					envVarPrefix + "MINIO_MAX_IDLE_CONNECTIONS",
+				}, 0),
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"max_connections_per_host": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Maximum number of connections opened to each host, idle or not (default: 0, unlimited)",
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{
+					envVarPrefix + "MINIO_MAX_CONNECTIONS_PER_HOST",
+				}, 0),
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"disable_keep_alives": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Open a new connection for every request, for proxies or load balancers dropping idle connections",
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{
+					envVarPrefix + "MINIO_DISABLE_KEEP_ALIVES",
+				}, false),
+			},
+			"dial_timeout": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Maximum duration to open a connection (e.g. 10s, default: 30s)",
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{
+					envVarPrefix + "MINIO_DIAL_TIMEOUT",
+				}, ""),
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					if value := v.(string); value != "" {
+						if d, err := time.ParseDuration(value); err != nil {
+							errors = append(errors, fmt.Errorf("%q must be a valid duration: %v", k, err))
+						} else if d <= 0 {
+							errors = append(errors, fmt.Errorf("%q must be positive", k))
+						}
+					}
+					return
+				},
+			},
+			"strict_bucket_policies": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Reject the bucket policies granting write actions to everyone, unless their allow_public_write is set",
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{
+					envVarPrefix + "MINIO_STRICT_BUCKET_POLICIES",
+				}, false),
+			},
+			"mock_state_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Serve the provider from an in-process mock of the S3 API instead of minio_server, to test modules with `terraform test`. The mock supports buckets, their configurations and objects, but not the admin API, and saves its content to this file, created when missing",
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{
+					envVarPrefix + "MINIO_MOCK_STATE_FILE",
+				}, nil),
+			},
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
-			"minio_iam_policy_document": dataSourceMinioIAMPolicyDocument(),
+			"minio_iam_group":                           dataSourceMinioIAMGroup(),
+			"minio_iam_policy_document":                 dataSourceMinioIAMPolicyDocument(),
+			"minio_iam_policy_entities":                 dataSourceMinioIAMPolicyEntities(),
+			"minio_iam_replication_policy_document":     dataSourceMinioIAMReplicationPolicyDocument(),
+			"minio_iam_service_account_policy_document": dataSourceMinioIAMServiceAccountPolicyDocument(),
+			"minio_admin_pools":                         dataSourceMinioAdminPools(),
+			"minio_admin_user_info":                     dataSourceMinioAdminUserInfo(),
+			"minio_admin_trace":                         dataSourceMinioAdminTrace(),
+			"minio_admin_policy_constraints":            dataSourceMinioAdminPolicyConstraints(),
+			"minio_cluster_health":                      dataSourceMinioClusterHealth(),
+			"minio_site_replication_status":             dataSourceMinioSiteReplicationStatus(),
+			"minio_s3_bucket_usage":                     dataSourceMinioS3BucketUsage(),
+			"minio_s3_bucket_metadata_export":           dataSourceMinioS3BucketMetadataExport(),
+			"minio_s3_bucket_policy":                    dataSourceMinioS3BucketPolicy(),
+			"minio_s3_bucket_objects_manifest":          dataSourceMinioS3BucketObjectsManifest(),
+			"minio_s3_bucket_retention_check":           dataSourceMinioS3BucketRetentionCheck(),
+			"minio_s3_object":                           dataSourceMinioS3Object(),
+			"minio_s3_objects":                          dataSourceMinioS3Objects(),
+			"minio_s3_object_versions":                  dataSourceMinioS3ObjectVersions(),
+			"minio_s3_object_query":                     dataSourceMinioS3ObjectQuery(),
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
-			"minio_s3_bucket":                   resourceMinioBucket(),
-			"minio_s3_bucket_policy":            resourceMinioBucketPolicy(),
-			"minio_s3_bucket_versioning":        resourceMinioBucketVersioning(),
-			"minio_s3_bucket_replication":       resourceMinioBucketReplication(),
-			"minio_s3_bucket_notification":      resourceMinioBucketNotification(),
-			"minio_s3_object":                   resourceMinioObject(),
-			"minio_iam_group":                   resourceMinioIAMGroup(),
-			"minio_iam_group_membership":        resourceMinioIAMGroupMembership(),
-			"minio_iam_user":                    resourceMinioIAMUser(),
-			"minio_iam_service_account":         resourceMinioServiceAccount(),
-			"minio_iam_group_policy":            resourceMinioIAMGroupPolicy(),
-			"minio_iam_policy":                  resourceMinioIAMPolicy(),
-			"minio_iam_user_policy_attachment":  resourceMinioIAMUserPolicyAttachment(),
-			"minio_iam_group_policy_attachment": resourceMinioIAMGroupPolicyAttachment(),
-			"minio_iam_group_user_attachment":   resourceMinioIAMGroupUserAttachment(),
-			"minio_ilm_policy":                  resourceMinioILMPolicy(),
+			"minio_s3_bucket":                     resourceMinioBucket(),
+			"minio_s3_bucket_policy":              resourceMinioBucketPolicy(),
+			"minio_s3_bucket_metadata_import":     resourceMinioS3BucketMetadataImport(),
+			"minio_s3_bucket_versioning":          resourceMinioBucketVersioning(),
+			"minio_s3_bucket_replication":         resourceMinioBucketReplication(),
+			"minio_s3_bucket_remote_target":       resourceMinioS3BucketRemoteTarget(),
+			"minio_s3_bucket_pair":                resourceMinioBucketPair(),
+			"minio_s3_bucket_notification":        resourceMinioBucketNotification(),
+			"minio_s3_bucket_event_rules":         resourceMinioBucketEventRules(),
+			"minio_s3_bucket_public_access_block": resourceMinioBucketPublicAccessBlock(),
+			"minio_s3_bucket_website":             resourceMinioBucketWebsite(),
+			"minio_s3_bucket_anonymous_access":    resourceMinioBucketAnonymousAccess(),
+			"minio_s3_object_lambda_webhook":      resourceMinioS3ObjectLambdaWebhook(),
+			"minio_s3_object":                     resourceMinioObject(),
+			"minio_s3_object_tags":                resourceMinioObjectTags(),
+			"minio_iam_group":                     resourceMinioIAMGroup(),
+			"minio_iam_group_membership":          resourceMinioIAMGroupMembership(),
+			"minio_iam_user":                      resourceMinioIAMUser(),
+			"minio_iam_service_account":           resourceMinioServiceAccount(),
+			"minio_iam_group_policy":              resourceMinioIAMGroupPolicy(),
+			"minio_iam_policy":                    resourceMinioIAMPolicy(),
+			"minio_iam_user_policy_attachment":    resourceMinioIAMUserPolicyAttachment(),
+			"minio_iam_group_policy_attachment":   resourceMinioIAMGroupPolicyAttachment(),
+			"minio_iam_group_user_attachment":     resourceMinioIAMGroupUserAttachment(),
+			"minio_iam_openid_role_policy":        resourceMinioIAMOpenIDRolePolicy(),
+			"minio_iam_sts_provider":              resourceMinioIAMSTSProvider(),
+			"minio_ilm_policy":                    resourceMinioILMPolicy(),
+			"minio_project":                       resourceMinioProject(),
+			"minio_admin_pool_decommission":       resourceMinioAdminPoolDecommission(),
+			"minio_server_config_snapshot":        resourceMinioServerConfigSnapshot(),
 		},
 
 		ConfigureContextFunc: providerConfigure,
 	}
+
+	for name, r := range provider.ResourcesMap {
+		if slices.Contains(endpointOverrideResources, name) {
+			withEndpointOverride(r)
+		}
+		if slices.Contains(mockUnsupportedResources, name) {
+			withMockRejection(name, r)
+		}
+		withClusterSelection(r, true)
+	}
+	for name, r := range provider.DataSourcesMap {
+		if slices.Contains(mockUnsupportedResources, name) {
+			withMockRejection(name, r)
+		}
+		withClusterSelection(r, false)
+	}
+
+	return provider
 }
 
 func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
 	minioConfig := NewConfig(d)
+	if err := minioConfig.loadCredentialFiles(); err != nil {
+		return nil, NewResourceError("unable to read credentials", "provider", err)
+	}
+
+	if minioConfig.S3MockStateFile != "" {
+		if err := minioConfig.startMockServer(ctx); err != nil {
+			return nil, NewResourceError("unable to start mock server", minioConfig.S3MockStateFile, err)
+		}
+	} else if minioConfig.S3HostPort == "" {
+		return nil, NewResourceError("invalid provider configuration", "minio_server", errors.New("minio_server is required unless mock_state_file is set"))
+	}
+
 	client, err := minioConfig.NewClient()
 	if err != nil {
 		return nil, NewResourceError("client creation failed", "client", err)
 	}
 
+	if minioConfig.S3WaitForCluster > 0 {
+		if err := minioConfig.waitForCluster(ctx); err != nil {
+			return nil, NewResourceError("cluster is not ready", minioConfig.S3HostPort, err)
+		}
+	}
+
+	clusterConfigs, err := newClusterConfigs(d, minioConfig)
+	if err != nil {
+		return nil, NewResourceError("invalid clusters configuration", "clusters", err)
+	}
+
+	clusters := map[string]*S3MinioClient{}
+	for name, clusterConfig := range clusterConfigs {
+		clusterClient, err := clusterConfig.NewClient()
+		if err != nil {
+			return nil, NewResourceError("client creation failed", name, err)
+		}
+
+		if clusterConfig.S3WaitForCluster > 0 {
+			if err := clusterConfig.waitForCluster(ctx); err != nil {
+				return nil, NewResourceError("cluster is not ready", clusterConfig.S3HostPort, err)
+			}
+		}
+
+		clusters[name] = clusterClient.(*S3MinioClient)
+	}
+	client.(*S3MinioClient).Clusters = clusters
+	// Resources working on two clusters look their peer up from the client of the selected cluster
+	for _, clusterClient := range clusters {
+		clusterClient.Clusters = clusters
+	}
+
 	return client, nil
 }
@@ -0,0 +1,209 @@
+package minio
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// clusterSchema returns the provider block defining the additional clusters resources can select
+func clusterSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		Description: "Additional MinIO clusters, selected by name with the cluster argument of resources and data sources",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Type:     schema.TypeString,
+					Required: true,
+				},
+				"minio_server": {
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: "Minio Host and Port",
+				},
+				"minio_region": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Default:     "us-east-1",
+					Description: "Minio Region (default: us-east-1)",
+				},
+				"minio_user": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Minio User",
+				},
+				"minio_password": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Sensitive:   true,
+					Description: "Minio Password",
+				},
+				"minio_session_token": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Sensitive:   true,
+					Description: "Minio Session Token",
+				},
+				"minio_ssl": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     false,
+					Description: "Minio SSL enabled (default: false)",
+				},
+				"minio_insecure": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     false,
+					Description: "Disable SSL certificate verification (default: false)",
+				},
+				"minio_cacert_file": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+			},
+		},
+	}
+}
+
+// newClusterConfigs returns the configuration of every additional cluster. Settings which are not
+// specific to a cluster are inherited from the provider configuration.
+func newClusterConfigs(d *schema.ResourceData, providerConfig *S3MinioConfig) (map[string]*S3MinioConfig, error) {
+	configs := map[string]*S3MinioConfig{}
+
+	for i, clusterI := range d.Get("clusters").([]interface{}) {
+		cluster, ok := clusterI.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("clusters[%d] is empty", i)
+		}
+
+		name := cluster["name"].(string)
+		if _, exists := configs[name]; exists {
+			return nil, fmt.Errorf("clusters[%d]: cluster %q is defined more than once", i, name)
+		}
+
+		config := *providerConfig
+		config.S3HostPort = cluster["minio_server"].(string)
+		config.S3Region = cluster["minio_region"].(string)
+		config.S3UserAccess = cluster["minio_user"].(string)
+		config.S3UserSecret = cluster["minio_password"].(string)
+		config.S3SessionToken = cluster["minio_session_token"].(string)
+		config.S3SSL = cluster["minio_ssl"].(bool)
+		config.S3SSLSkipVerify = cluster["minio_insecure"].(bool)
+		config.S3SSLCACertFile = cluster["minio_cacert_file"].(string)
+		config.S3AdminAccess = ""
+		config.S3AdminSecret = ""
+		config.S3Anonymous = false
+
+		configs[name] = &config
+	}
+
+	return configs, nil
+}
+
+// clusterMeta returns the client of the cluster selected by name, the provider client being used when it is empty
+func clusterMeta(name string, meta interface{}) (interface{}, error) {
+	if name == "" {
+		return meta, nil
+	}
+
+	client, ok := meta.(*S3MinioClient)
+	if !ok {
+		return nil, fmt.Errorf("the provider is not configured")
+	}
+	cluster, ok := client.Clusters[name]
+	if !ok {
+		return nil, fmt.Errorf("cluster %q is not defined in the provider clusters", name)
+	}
+	return cluster, nil
+}
+
+// withClusterSelection adds the cluster argument to a resource or data source and makes its
+// functions use the client of the selected cluster.
+func withClusterSelection(r *schema.Resource, forceNew bool) {
+	r.Schema["cluster"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		ForceNew:    forceNew,
+		Description: "Name of the provider cluster to manage this object on (default: the provider cluster)",
+	}
+
+	wrap := func(f func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+		if f == nil {
+			return nil
+		}
+		return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			clusterClient, err := clusterMeta(d.Get("cluster").(string), meta)
+			if err != nil {
+				return NewResourceError("unable to select cluster", d.Id(), err)
+			}
+			return f(ctx, d, clusterClient)
+		}
+	}
+
+	r.CreateContext = wrap(r.CreateContext)
+	r.ReadContext = wrap(r.ReadContext)
+	r.UpdateContext = wrap(r.UpdateContext)
+	r.DeleteContext = wrap(r.DeleteContext)
+
+	if r.Read != nil {
+		read := r.Read
+		r.Read = func(d *schema.ResourceData, meta interface{}) error {
+			clusterClient, err := clusterMeta(d.Get("cluster").(string), meta)
+			if err != nil {
+				return err
+			}
+			return read(d, clusterClient)
+		}
+	}
+
+	// The configuration is not available on import, the cluster is selected by the import ID
+	if r.Importer != nil && r.Importer.StateContext != nil {
+		importState := r.Importer.StateContext
+		r.Importer.StateContext = func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			id, cluster := clusterImportID(d.Id(), meta)
+			clusterClient, err := clusterMeta(cluster, meta)
+			if err != nil {
+				return nil, err
+			}
+			d.SetId(id)
+			results, err := importState(ctx, d, clusterClient)
+			for _, result := range results {
+				_ = result.Set("cluster", cluster)
+			}
+			return results, err
+		}
+	}
+
+	if r.CustomizeDiff != nil {
+		customizeDiff := r.CustomizeDiff
+		r.CustomizeDiff = func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+			if !d.NewValueKnown("cluster") {
+				return nil
+			}
+			clusterClient, err := clusterMeta(d.Get("cluster").(string), meta)
+			if err != nil {
+				return err
+			}
+			return customizeDiff(ctx, d, clusterClient)
+		}
+	}
+}
+
+// clusterImportID splits an import ID suffixed with @cluster. The suffix is only taken as a cluster when it names
+// one of the provider clusters, as some IDs, such as user names, can contain an @.
+func clusterImportID(id string, meta interface{}) (string, string) {
+	client, ok := meta.(*S3MinioClient)
+	i := strings.LastIndex(id, "@")
+	if !ok || i < 0 {
+		return id, ""
+	}
+	if _, ok := client.Clusters[id[i+1:]]; !ok {
+		return id, ""
+	}
+	return id[:i], id[i+1:]
+}
@@ -0,0 +1,176 @@
+package minio
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// endpointOverrideResources are the bucket level resources which can be managed on another endpoint than the
+// one of their cluster, for gateways routing buckets to different backends
+var endpointOverrideResources = []string{
+	"minio_ilm_policy",
+	"minio_s3_bucket",
+	"minio_s3_bucket_anonymous_access",
+	"minio_s3_bucket_event_rules",
+	"minio_s3_bucket_notification",
+	"minio_s3_bucket_policy",
+	"minio_s3_bucket_public_access_block",
+	"minio_s3_bucket_remote_target",
+	"minio_s3_bucket_replication",
+	"minio_s3_bucket_versioning",
+	"minio_s3_bucket_website",
+	"minio_s3_object",
+	"minio_s3_object_tags",
+}
+
+// endpointClients holds the clients of the endpoints overridden by resources, sharing the configuration of
+// the cluster they derive from
+type endpointClients struct {
+	config *S3MinioConfig
+
+	mu      sync.Mutex
+	clients map[string]*S3MinioClient
+}
+
+func newEndpointClients(config *S3MinioConfig) *endpointClients {
+	return &endpointClients{config: config, clients: map[string]*S3MinioClient{}}
+}
+
+// Get returns the client of an endpoint and region, an empty value keeping the one of the cluster
+func (e *endpointClients) Get(endpoint string, region string, clusters map[string]*S3MinioClient) (*S3MinioClient, error) {
+	config := *e.config
+	if endpoint != "" {
+		config.S3HostPort = endpoint
+	}
+	if region != "" {
+		config.S3Region = region
+	}
+	key := config.S3HostPort + "/" + config.S3Region
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if client, ok := e.clients[key]; ok {
+		return client, nil
+	}
+
+	client, err := config.NewClient()
+	if err != nil {
+		return nil, fmt.Errorf("unable to create the client of endpoint %q: %w", config.S3HostPort, err)
+	}
+	endpointClient := client.(*S3MinioClient)
+	endpointClient.Clusters = clusters
+	e.clients[key] = endpointClient
+
+	return endpointClient, nil
+}
+
+// endpointMeta returns the client of the endpoint and region overridden by a resource, the client of its
+// cluster being used when none is
+func endpointMeta(endpoint string, region string, meta interface{}) (interface{}, error) {
+	if endpoint == "" && region == "" {
+		return meta, nil
+	}
+
+	client, ok := meta.(*S3MinioClient)
+	if !ok || client.Endpoints == nil {
+		return nil, fmt.Errorf("the provider is not configured")
+	}
+	return client.Endpoints.Get(endpoint, region, client.Clusters)
+}
+
+// withEndpointOverride adds the endpoint and region arguments to a resource and makes its functions use a
+// client of this endpoint, with the credentials of the selected cluster.
+func withEndpointOverride(r *schema.Resource) {
+	r.Schema["endpoint"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		ForceNew:    true,
+		Description: "Host and port of the MinIO server to manage this object on, with the credentials of the cluster (default: the cluster server)",
+	}
+	r.Schema["region"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		ForceNew:    true,
+		Description: "Region of the MinIO server to manage this object on (default: the cluster region)",
+	}
+
+	wrap := func(f func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+		if f == nil {
+			return nil
+		}
+		return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			endpointClient, err := endpointMeta(d.Get("endpoint").(string), d.Get("region").(string), meta)
+			if err != nil {
+				return NewResourceError("unable to select endpoint", d.Id(), err)
+			}
+			return f(ctx, d, endpointClient)
+		}
+	}
+
+	r.CreateContext = wrap(r.CreateContext)
+	r.ReadContext = wrap(r.ReadContext)
+	r.UpdateContext = wrap(r.UpdateContext)
+	r.DeleteContext = wrap(r.DeleteContext)
+
+	// The configuration is not available on import, the endpoint and region are selected by the import ID
+	if r.Importer != nil && r.Importer.StateContext != nil {
+		importState := r.Importer.StateContext
+		r.Importer.StateContext = func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			id, endpoint, region := endpointImportID(d.Id())
+			endpointClient, err := endpointMeta(endpoint, region, meta)
+			if err != nil {
+				return nil, err
+			}
+			d.SetId(id)
+			results, err := importState(ctx, d, endpointClient)
+			for _, result := range results {
+				_ = result.Set("endpoint", endpoint)
+				_ = result.Set("region", region)
+			}
+			return results, err
+		}
+	}
+
+	if r.CustomizeDiff != nil {
+		customizeDiff := r.CustomizeDiff
+		r.CustomizeDiff = func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+			if !d.NewValueKnown("endpoint") || !d.NewValueKnown("region") {
+				return nil
+			}
+			endpointClient, err := endpointMeta(d.Get("endpoint").(string), d.Get("region").(string), meta)
+			if err != nil {
+				return err
+			}
+			return customizeDiff(ctx, d, endpointClient)
+		}
+	}
+}
+
+// endpointImportID splits an import ID suffixed with @endpoint=<host:port>, @region=<region> or both separated by
+// a comma, e.g. archive@endpoint=archive-backend:9000,region=eu-west-1. Any other suffix is part of the ID.
+func endpointImportID(id string) (string, string, string) {
+	i := strings.LastIndex(id, "@")
+	if i < 0 {
+		return id, "", ""
+	}
+
+	var endpoint, region string
+	for _, setting := range strings.Split(id[i+1:], ",") {
+		key, value, _ := strings.Cut(setting, "=")
+		switch {
+		case key == "endpoint" && value != "" && endpoint == "":
+			endpoint = value
+		case key == "region" && value != "" && region == "":
+			region = value
+		default:
+			return id, "", ""
+		}
+	}
+	return id[:i], endpoint, region
+}
@@ -0,0 +1,655 @@
+package minio
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/tags"
+)
+
+// mockS3BucketConfigs are the bucket subresources the mock server stores as they are sent, with the error code
+// returned while they are not set, or their default content
+var mockS3BucketConfigs = map[string]struct {
+	missing string
+	empty   string
+}{
+	"cors":         {missing: "NoSuchCORSConfiguration"},
+	"encryption":   {missing: "ServerSideEncryptionConfigurationNotFoundError"},
+	"lifecycle":    {missing: "NoSuchLifecycleConfiguration"},
+	"notification": {empty: `<NotificationConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"></NotificationConfiguration>`},
+	"object-lock":  {missing: "ObjectLockConfigurationNotFoundError"},
+	"policy":       {missing: "NoSuchBucketPolicy"},
+	"replication":  {missing: "ReplicationConfigurationNotFoundError"},
+	"tagging":      {missing: "NoSuchTagSet"},
+	"versioning":   {empty: `<VersioningConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"></VersioningConfiguration>`},
+}
+
+// mockS3ObjectHeaders are the headers of an upload the mock server returns with the object, besides the user
+// metadata and checksums
+var mockS3ObjectHeaders = []string{"Cache-Control", "Content-Disposition", "Content-Encoding", "Content-Language", "Content-Type", "Expires"}
+
+// mockS3Server is an in-process fake of the S3 API serving buckets, their configurations and objects, so modules
+// can be tested with `terraform test` without a MinIO server. Terraform running each plan and apply with a new
+// provider process, its content is saved to a file after every change.
+type mockS3Server struct {
+	mu    sync.Mutex
+	file  string
+	state mockS3State
+}
+
+type mockS3State struct {
+	Buckets map[string]*mockS3Bucket `json:"buckets"`
+}
+
+type mockS3Bucket struct {
+	Created time.Time                `json:"created"`
+	Configs map[string][]byte        `json:"configs"`
+	Objects map[string]*mockS3Object `json:"objects"`
+}
+
+type mockS3Object struct {
+	Content  []byte            `json:"content"`
+	ETag     string            `json:"etag"`
+	Modified time.Time         `json:"modified"`
+	Headers  map[string]string `json:"headers"`
+	Tagging  []byte            `json:"tagging,omitempty"`
+}
+
+type mockS3ListedBucket struct {
+	Name         string    `xml:"Name"`
+	CreationDate time.Time `xml:"CreationDate"`
+}
+
+type mockS3ListObjectsResult struct {
+	XMLName        xml.Name             `xml:"http://s3.amazonaws.com/doc/2006-03-01/ ListBucketResult"`
+	Name           string               `xml:"Name"`
+	Prefix         string               `xml:"Prefix"`
+	Delimiter      string               `xml:"Delimiter,omitempty"`
+	KeyCount       int                  `xml:"KeyCount"`
+	MaxKeys        int                  `xml:"MaxKeys"`
+	IsTruncated    bool                 `xml:"IsTruncated"`
+	Contents       []mockS3ListedObject `xml:"Contents"`
+	CommonPrefixes []mockS3ListedPrefix `xml:"CommonPrefixes"`
+}
+
+type mockS3ListedObject struct {
+	Key          string    `xml:"Key"`
+	LastModified time.Time `xml:"LastModified"`
+	ETag         string    `xml:"ETag"`
+	Size         int       `xml:"Size"`
+	StorageClass string    `xml:"StorageClass"`
+}
+
+type mockS3ListedPrefix struct {
+	Prefix string `xml:"Prefix"`
+}
+
+type mockS3DeleteRequest struct {
+	Objects []struct {
+		Key string `xml:"Key"`
+	} `xml:"Object"`
+}
+
+type mockS3DeleteResult struct {
+	XMLName xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ DeleteResult"`
+	Deleted []struct {
+		Key string `xml:"Key"`
+	} `xml:"Deleted"`
+}
+
+// mockUnsupportedResources are the resources and data sources relying on the admin API, which the mock server does
+// not serve. They are rejected when the provider is served by the mock server.
+var mockUnsupportedResources = []string{
+	"minio_admin_policy_constraints",
+	"minio_admin_pool_decommission",
+	"minio_admin_pools",
+	"minio_admin_trace",
+	"minio_admin_user_info",
+	"minio_iam_group",
+	"minio_iam_group_membership",
+	"minio_iam_group_policy",
+	"minio_iam_group_policy_attachment",
+	"minio_iam_group_user_attachment",
+	"minio_iam_openid_role_policy",
+	"minio_iam_policy",
+	"minio_iam_policy_entities",
+	"minio_iam_service_account",
+	"minio_iam_sts_provider",
+	"minio_iam_user",
+	"minio_iam_user_policy_attachment",
+	"minio_project",
+	"minio_s3_bucket_event_rules",
+	"minio_s3_bucket_metadata_export",
+	"minio_s3_bucket_metadata_import",
+	"minio_s3_bucket_pair",
+	"minio_s3_bucket_remote_target",
+	"minio_s3_bucket_replication",
+	"minio_s3_bucket_usage",
+	"minio_s3_object_lambda_webhook",
+	"minio_server_config_snapshot",
+	"minio_site_replication_status",
+}
+
+// withMockRejection makes a resource or data source unsupported by the mock server fail its plan and reads with a
+// clear error, instead of the NotImplemented error of its first admin request. The client checked is the one of the
+// selected cluster, so the resource can still be managed on an additional cluster.
+func withMockRejection(name string, r *schema.Resource) {
+	check := func(meta interface{}) error {
+		if client, ok := meta.(*S3MinioClient); ok && client.Mock {
+			return fmt.Errorf("%s is not supported with mock_state_file: the mock server does not serve the admin API", name)
+		}
+		return nil
+	}
+
+	wrap := func(f func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+		if f == nil {
+			return nil
+		}
+		return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			if err := check(meta); err != nil {
+				return diag.FromErr(err)
+			}
+			return f(ctx, d, meta)
+		}
+	}
+
+	r.CreateContext = wrap(r.CreateContext)
+	r.ReadContext = wrap(r.ReadContext)
+
+	if r.Read != nil {
+		read := r.Read
+		r.Read = func(d *schema.ResourceData, meta interface{}) error {
+			if err := check(meta); err != nil {
+				return err
+			}
+			return read(d, meta)
+		}
+	}
+
+	// Data sources have no plan of their own, they are rejected by their read
+	if r.CreateContext == nil {
+		return
+	}
+	customizeDiff := r.CustomizeDiff
+	r.CustomizeDiff = func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		if err := check(meta); err != nil {
+			return err
+		}
+		if customizeDiff == nil {
+			return nil
+		}
+		return customizeDiff(ctx, d, meta)
+	}
+}
+
+// startMockServer serves the provider from a mock server holding the content of the mock state file, in place of
+// the configured server. The server is closed when Terraform stops the provider, or when ctx is done outside of a
+// provider configuration.
+func (config *S3MinioConfig) startMockServer(ctx context.Context) error {
+	server, err := newMockS3Server(config.S3MockStateFile)
+	if err != nil {
+		return err
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return err
+	}
+	httpServer := &http.Server{Handler: server}
+	go func() {
+		_ = httpServer.Serve(listener)
+	}()
+
+	// The context of the provider configuration ends with the request, the stop context with the provider
+	stop := ctx
+	if stopContext, ok := ctx.Value(schema.StopContextKey).(context.Context); ok {
+		stop = stopContext
+	}
+	go func() {
+		<-stop.Done()
+		log.Printf("[DEBUG] Closing the mock server %s", listener.Addr())
+		_ = httpServer.Close()
+	}()
+
+	log.Printf("[DEBUG] Serving the provider from the mock server %s, saved to %s", listener.Addr(), config.S3MockStateFile)
+	config.S3HostPort = listener.Addr().String()
+	config.S3SSL = false
+	return nil
+}
+
+// newMockS3Server returns a mock server loaded from its state file, which is created on the first change when
+// missing
+func newMockS3Server(file string) (*mockS3Server, error) {
+	server := &mockS3Server{file: file}
+
+	content, err := os.ReadFile(file)
+	if err == nil {
+		if err := json.Unmarshal(content, &server.state); err != nil {
+			return nil, fmt.Errorf("invalid mock state file %s: %w", file, err)
+		}
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	if server.state.Buckets == nil {
+		server.state.Buckets = map[string]*mockS3Bucket{}
+	}
+	return server, nil
+}
+
+func (s *mockS3Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if strings.HasPrefix(r.URL.Path, "/minio/health/") {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	if strings.HasPrefix(r.URL.Path, "/minio/") {
+		// madmin decodes the errors from JSON
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotImplemented)
+		_ = json.NewEncoder(w).Encode(minio.ErrorResponse{
+			Code:     "NotImplemented",
+			Message:  "The mock server does not support the admin API",
+			Resource: r.URL.Path,
+		})
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// The response is only sent once the change is saved, so a failure to save it fails the request
+	recorder := httptest.NewRecorder()
+	bucket, key, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	switch {
+	case bucket == "":
+		s.listBuckets(recorder, r)
+	case key == "":
+		s.serveBucket(recorder, r, bucket)
+	default:
+		s.serveObject(recorder, r, bucket, key)
+	}
+
+	if r.Method != http.MethodGet && r.Method != http.MethodHead && recorder.Code < http.StatusMultipleChoices {
+		if err := s.save(); err != nil {
+			writeMockS3Error(w, http.StatusInternalServerError, "InternalError", err.Error(), bucket, key)
+			return
+		}
+	}
+
+	for name, values := range recorder.Header() {
+		w.Header()[name] = values
+	}
+	w.WriteHeader(recorder.Code)
+	_, _ = w.Write(recorder.Body.Bytes())
+}
+
+func (s *mockS3Server) listBuckets(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeMockS3Error(w, http.StatusNotImplemented, "NotImplemented", "The mock server does not support this request", "", "")
+		return
+	}
+
+	result := struct {
+		XMLName xml.Name             `xml:"http://s3.amazonaws.com/doc/2006-03-01/ ListAllMyBucketsResult"`
+		Buckets []mockS3ListedBucket `xml:"Buckets>Bucket"`
+	}{}
+	names := make([]string, 0, len(s.state.Buckets))
+	for name := range s.state.Buckets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		result.Buckets = append(result.Buckets, mockS3ListedBucket{Name: name, CreationDate: s.state.Buckets[name].Created})
+	}
+	writeMockS3XML(w, http.StatusOK, result)
+}
+
+func (s *mockS3Server) serveBucket(w http.ResponseWriter, r *http.Request, name string) {
+	query := r.URL.Query()
+	bucket := s.state.Buckets[name]
+
+	if r.Method == http.MethodPut && len(query) == 0 {
+		if bucket != nil {
+			writeMockS3Error(w, http.StatusConflict, "BucketAlreadyOwnedByYou", "Your previous request to create the named bucket succeeded and you already own it.", name, "")
+			return
+		}
+		bucket = &mockS3Bucket{
+			Created: time.Now().UTC().Truncate(time.Second),
+			Configs: map[string][]byte{},
+			Objects: map[string]*mockS3Object{},
+		}
+		// Object lock is only enabled on creation, and enables versioning
+		if r.Header.Get("X-Amz-Bucket-Object-Lock-Enabled") == "true" {
+			bucket.Configs["object-lock"] = []byte(`<ObjectLockConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><ObjectLockEnabled>Enabled</ObjectLockEnabled></ObjectLockConfiguration>`)
+			bucket.Configs["versioning"] = []byte(`<VersioningConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Status>Enabled</Status></VersioningConfiguration>`)
+		}
+		s.state.Buckets[name] = bucket
+		w.Header().Set("Location", "/"+name)
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	if bucket == nil {
+		writeMockS3Error(w, http.StatusNotFound, "NoSuchBucket", "The specified bucket does not exist", name, "")
+		return
+	}
+
+	for config := range mockS3BucketConfigs {
+		if query.Has(config) {
+			s.serveBucketConfig(w, r, name, bucket, config)
+			return
+		}
+	}
+
+	switch {
+	case r.Method == http.MethodHead && len(query) == 0:
+		w.WriteHeader(http.StatusOK)
+	case r.Method == http.MethodDelete && len(query) == 0:
+		if len(bucket.Objects) != 0 {
+			writeMockS3Error(w, http.StatusConflict, "BucketNotEmpty", "The bucket you tried to delete is not empty", name, "")
+			return
+		}
+		delete(s.state.Buckets, name)
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodGet && query.Has("location"):
+		writeMockS3XML(w, http.StatusOK, struct {
+			XMLName  xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ LocationConstraint"`
+			Location string   `xml:",chardata"`
+		}{Location: "us-east-1"})
+	case r.Method == http.MethodGet && query.Get("list-type") == "2":
+		writeMockS3XML(w, http.StatusOK, mockS3ListObjects(name, bucket, query.Get("prefix"), query.Get("delimiter")))
+	case r.Method == http.MethodPost && query.Has("delete"):
+		var request mockS3DeleteRequest
+		if err := xml.NewDecoder(r.Body).Decode(&request); err != nil {
+			writeMockS3Error(w, http.StatusBadRequest, "MalformedXML", err.Error(), name, "")
+			return
+		}
+		result := mockS3DeleteResult{}
+		for _, object := range request.Objects {
+			delete(bucket.Objects, object.Key)
+			result.Deleted = append(result.Deleted, struct {
+				Key string `xml:"Key"`
+			}{object.Key})
+		}
+		writeMockS3XML(w, http.StatusOK, result)
+	default:
+		writeMockS3Error(w, http.StatusNotImplemented, "NotImplemented", "The mock server does not support this request", name, "")
+	}
+}
+
+func (s *mockS3Server) serveBucketConfig(w http.ResponseWriter, r *http.Request, name string, bucket *mockS3Bucket, config string) {
+	switch r.Method {
+	case http.MethodGet:
+		content, ok := bucket.Configs[config]
+		if !ok {
+			if mockS3BucketConfigs[config].missing != "" {
+				writeMockS3Error(w, http.StatusNotFound, mockS3BucketConfigs[config].missing, fmt.Sprintf("The bucket %s configuration does not exist", config), name, "")
+				return
+			}
+			content = []byte(mockS3BucketConfigs[config].empty)
+		}
+		if config == "policy" {
+			w.Header().Set("Content-Type", "application/json")
+		} else {
+			w.Header().Set("Content-Type", "application/xml")
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(content)
+	case http.MethodPut:
+		content, err := mockS3RequestBody(r)
+		if err != nil {
+			writeMockS3Error(w, http.StatusBadRequest, "IncompleteBody", err.Error(), name, "")
+			return
+		}
+		bucket.Configs[config] = content
+		w.WriteHeader(http.StatusOK)
+	case http.MethodDelete:
+		delete(bucket.Configs, config)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeMockS3Error(w, http.StatusNotImplemented, "NotImplemented", "The mock server does not support this request", name, "")
+	}
+}
+
+func (s *mockS3Server) serveObject(w http.ResponseWriter, r *http.Request, name string, key string) {
+	bucket := s.state.Buckets[name]
+	if bucket == nil {
+		writeMockS3Error(w, http.StatusNotFound, "NoSuchBucket", "The specified bucket does not exist", name, key)
+		return
+	}
+
+	query := r.URL.Query()
+	object := bucket.Objects[key]
+	if object == nil && r.Method != http.MethodPut && r.Method != http.MethodDelete {
+		writeMockS3Error(w, http.StatusNotFound, "NoSuchKey", "The specified key does not exist.", name, key)
+		return
+	}
+
+	switch {
+	case query.Has("tagging"):
+		s.serveObjectTagging(w, r, name, key, object)
+	case len(query) != 0 || r.Header.Get("X-Amz-Copy-Source") != "":
+		writeMockS3Error(w, http.StatusNotImplemented, "NotImplemented", "The mock server does not support this request", name, key)
+	case r.Method == http.MethodPut:
+		content, err := mockS3RequestBody(r)
+		if err != nil {
+			writeMockS3Error(w, http.StatusBadRequest, "IncompleteBody", err.Error(), name, key)
+			return
+		}
+
+		checksum := md5.Sum(content)
+		object = &mockS3Object{
+			Content:  content,
+			ETag:     hex.EncodeToString(checksum[:]),
+			Modified: time.Now().UTC().Truncate(time.Second),
+			Headers:  map[string]string{},
+		}
+		for header := range r.Header {
+			lower := strings.ToLower(header)
+			if strings.HasPrefix(lower, "x-amz-meta-") || strings.HasPrefix(lower, "x-amz-checksum-") {
+				object.Headers[header] = r.Header.Get(header)
+			}
+		}
+		for _, header := range mockS3ObjectHeaders {
+			if value := r.Header.Get(header); value != "" {
+				object.Headers[header] = value
+			}
+		}
+		if value := r.Header.Get("X-Amz-Tagging"); value != "" {
+			objectTags, err := tags.ParseObjectTags(value)
+			if err != nil {
+				writeMockS3Error(w, http.StatusBadRequest, "InvalidTag", err.Error(), name, key)
+				return
+			}
+			if object.Tagging, err = xml.Marshal(objectTags); err != nil {
+				writeMockS3Error(w, http.StatusInternalServerError, "InternalError", err.Error(), name, key)
+				return
+			}
+		}
+		bucket.Objects[key] = object
+
+		w.Header().Set("ETag", strconv.Quote(object.ETag))
+		w.WriteHeader(http.StatusOK)
+	case r.Method == http.MethodGet || r.Method == http.MethodHead:
+		for header, value := range object.Headers {
+			w.Header().Set(header, value)
+		}
+		if w.Header().Get("Content-Type") == "" {
+			w.Header().Set("Content-Type", "application/octet-stream")
+		}
+		w.Header().Set("ETag", strconv.Quote(object.ETag))
+		http.ServeContent(w, r, key, object.Modified, bytes.NewReader(object.Content))
+	case r.Method == http.MethodDelete:
+		delete(bucket.Objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeMockS3Error(w, http.StatusNotImplemented, "NotImplemented", "The mock server does not support this request", name, key)
+	}
+}
+
+func (s *mockS3Server) serveObjectTagging(w http.ResponseWriter, r *http.Request, name string, key string, object *mockS3Object) {
+	if object == nil {
+		writeMockS3Error(w, http.StatusNotFound, "NoSuchKey", "The specified key does not exist.", name, key)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		content := object.Tagging
+		if content == nil {
+			content = []byte(`<Tagging><TagSet></TagSet></Tagging>`)
+		}
+		w.Header().Set("Content-Type", "application/xml")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(content)
+	case http.MethodPut:
+		content, err := mockS3RequestBody(r)
+		if err != nil {
+			writeMockS3Error(w, http.StatusBadRequest, "IncompleteBody", err.Error(), name, key)
+			return
+		}
+		object.Tagging = content
+		w.WriteHeader(http.StatusOK)
+	case http.MethodDelete:
+		object.Tagging = nil
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeMockS3Error(w, http.StatusNotImplemented, "NotImplemented", "The mock server does not support this request", name, key)
+	}
+}
+
+// save writes the content of the mock server to its state file, through a temporary file so an interrupted
+// provider never leaves it truncated
+func (s *mockS3Server) save() error {
+	content, err := json.Marshal(s.state)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(s.file+".tmp", content, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(s.file+".tmp", s.file)
+}
+
+// mockS3ListObjects lists the objects of a bucket in a single page, grouping the keys under the delimiter
+func mockS3ListObjects(name string, bucket *mockS3Bucket, prefix string, delimiter string) mockS3ListObjectsResult {
+	result := mockS3ListObjectsResult{
+		Name:      name,
+		Prefix:    prefix,
+		Delimiter: delimiter,
+		MaxKeys:   1000,
+	}
+
+	keys := make([]string, 0, len(bucket.Objects))
+	for key := range bucket.Objects {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	listedPrefixes := map[string]bool{}
+	for _, key := range keys {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		if delimiter != "" {
+			if i := strings.Index(key[len(prefix):], delimiter); i >= 0 {
+				commonPrefix := key[:len(prefix)+i+len(delimiter)]
+				if !listedPrefixes[commonPrefix] {
+					listedPrefixes[commonPrefix] = true
+					result.CommonPrefixes = append(result.CommonPrefixes, mockS3ListedPrefix{Prefix: commonPrefix})
+				}
+				continue
+			}
+		}
+
+		object := bucket.Objects[key]
+		result.Contents = append(result.Contents, mockS3ListedObject{
+			Key:          key,
+			LastModified: object.Modified,
+			ETag:         strconv.Quote(object.ETag),
+			Size:         len(object.Content),
+			StorageClass: "STANDARD",
+		})
+	}
+	result.KeyCount = len(result.Contents) + len(result.CommonPrefixes)
+
+	return result
+}
+
+// mockS3RequestBody returns the content sent by a request, decoding the chunks minio-go streams uploads in when
+// signing them over plain HTTP
+func mockS3RequestBody(r *http.Request) ([]byte, error) {
+	if !strings.HasPrefix(r.Header.Get("X-Amz-Content-Sha256"), "STREAMING-") {
+		return io.ReadAll(r.Body)
+	}
+
+	reader := bufio.NewReader(r.Body)
+	content := []byte{}
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return nil, fmt.Errorf("unable to read chunk: %w", err)
+		}
+		sizeHex, _, _ := strings.Cut(strings.TrimSpace(line), ";")
+		size, err := strconv.ParseInt(sizeHex, 16, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid chunk size %q", sizeHex)
+		}
+		if size == 0 {
+			return content, nil
+		}
+
+		// Each chunk ends with a CRLF
+		chunk := make([]byte, size+2)
+		if _, err := io.ReadFull(reader, chunk); err != nil {
+			return nil, fmt.Errorf("unable to read chunk: %w", err)
+		}
+		content = append(content, chunk[:size]...)
+	}
+}
+
+func writeMockS3XML(w http.ResponseWriter, status int, value interface{}) {
+	content, err := xml.Marshal(value)
+	if err != nil {
+		writeMockS3Error(w, http.StatusInternalServerError, "InternalError", err.Error(), "", "")
+		return
+	}
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	_, _ = w.Write([]byte(xml.Header))
+	_, _ = w.Write(content)
+}
+
+func writeMockS3Error(w http.ResponseWriter, status int, code string, message string, bucket string, key string) {
+	content, _ := xml.Marshal(minio.ErrorResponse{
+		Code:       code,
+		Message:    message,
+		BucketName: bucket,
+		Key:        key,
+	})
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	_, _ = w.Write(content)
+}
@@ -0,0 +1,170 @@
+package minio
+
+import (
+	"context"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/minio/madmin-go"
+	"github.com/minio/minio-go/v7"
+)
+
+func testMockS3Client(t *testing.T, file string) *S3MinioClient {
+	config := &S3MinioConfig{
+		S3Region:        "us-east-1",
+		S3UserAccess:    "minio",
+		S3UserSecret:    "minio123",
+		S3APISignature:  "v4",
+		S3MockStateFile: file,
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	if err := config.startMockServer(ctx); err != nil {
+		t.Fatal(err)
+	}
+	client, err := config.NewClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client.(*S3MinioClient)
+}
+
+func TestMockS3Server(t *testing.T) {
+	ctx := context.Background()
+	file := filepath.Join(t.TempDir(), "mock.json")
+	client := testMockS3Client(t, file)
+
+	d := schema.TestResourceDataRaw(t, resourceMinioBucket().Schema, map[string]interface{}{
+		"bucket": "mock-bucket",
+		"acl":    "public-read",
+	})
+	if diags := minioCreateBucket(ctx, d, client); diags.HasError() {
+		t.Fatalf("unable to create bucket: %v", diags)
+	}
+	if d.Get("creation_date").(string) == "" {
+		t.Error("expected the creation date of the bucket to be read")
+	}
+	if policy, err := client.S3Client.GetBucketPolicy(ctx, "mock-bucket"); err != nil || !strings.Contains(policy, "s3:GetObject") {
+		t.Errorf("expected the public-read policy to be set, got %q: %v", policy, err)
+	}
+
+	content := "hello from the mock server"
+	_, err := client.S3Client.PutObject(ctx, "mock-bucket", "reports/hello.txt", strings.NewReader(content), int64(len(content)), minio.PutObjectOptions{
+		ContentType:  "text/plain",
+		UserMetadata: map[string]string{"Owner": "team"},
+		UserTags:     map[string]string{"env": "test"},
+	})
+	if err != nil {
+		t.Fatalf("unable to put object: %v", err)
+	}
+
+	// The content is saved, a new provider process finds it
+	client = testMockS3Client(t, file)
+
+	info, err := client.S3Client.StatObject(ctx, "mock-bucket", "reports/hello.txt", minio.StatObjectOptions{})
+	if err != nil {
+		t.Fatalf("unable to stat object: %v", err)
+	}
+	if info.Size != int64(len(content)) || info.ContentType != "text/plain" || info.UserMetadata["Owner"] != "team" {
+		t.Errorf("unexpected object info %+v", info)
+	}
+
+	object, err := client.S3Client.GetObject(ctx, "mock-bucket", "reports/hello.txt", minio.GetObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if read, err := io.ReadAll(object); err != nil || string(read) != content {
+		t.Errorf("expected content %q, got %q: %v", content, read, err)
+	}
+
+	objectTags, err := client.S3Client.GetObjectTagging(ctx, "mock-bucket", "reports/hello.txt", minio.GetObjectTaggingOptions{})
+	if err != nil || objectTags.ToMap()["env"] != "test" {
+		t.Errorf("expected the object tags to be set, got %v: %v", objectTags, err)
+	}
+
+	listed := []string{}
+	for object := range client.S3Client.ListObjects(ctx, "mock-bucket", minio.ListObjectsOptions{}) {
+		if object.Err != nil {
+			t.Fatal(object.Err)
+		}
+		listed = append(listed, object.Key)
+	}
+	if len(listed) != 1 || listed[0] != "reports/" {
+		t.Errorf("expected the reports/ prefix to be listed, got %v", listed)
+	}
+
+	if _, err := client.S3Admin.ServerInfo(ctx); madmin.ToErrorResponse(err).Code != "NotImplemented" {
+		t.Errorf("expected the admin API to be unsupported, got %v", err)
+	}
+
+	// A bucket holding objects is only removed with force_destroy
+	if err := client.S3Client.RemoveBucket(ctx, "mock-bucket"); err == nil || !strings.Contains(err.Error(), "empty") {
+		t.Errorf("expected the removal of a bucket holding objects to fail, got %v", err)
+	}
+	_ = d.Set("force_destroy", true)
+	if diags := minioDeleteBucket(ctx, d, client); diags.HasError() {
+		t.Fatalf("unable to remove bucket: %v", diags)
+	}
+	if found, err := client.S3Client.BucketExists(ctx, "mock-bucket"); err != nil || found {
+		t.Errorf("expected the bucket to be removed, found: %t: %v", found, err)
+	}
+}
+
+func TestMockS3ServerObjectLocking(t *testing.T) {
+	ctx := context.Background()
+	client := testMockS3Client(t, filepath.Join(t.TempDir(), "mock.json"))
+
+	d := schema.TestResourceDataRaw(t, resourceMinioBucket().Schema, map[string]interface{}{
+		"bucket":         "mock-locked-bucket",
+		"object_locking": true,
+	})
+	if diags := minioCreateBucket(ctx, d, client); diags.HasError() {
+		t.Fatalf("unable to create bucket: %v", diags)
+	}
+	if !d.Get("object_locking").(bool) {
+		t.Error("expected object lock to be read as enabled")
+	}
+
+	versioning, err := client.S3Client.GetBucketVersioning(ctx, "mock-locked-bucket")
+	if err != nil || !versioning.Enabled() {
+		t.Errorf("expected object lock to enable versioning, got %+v: %v", versioning, err)
+	}
+}
+
+func TestMockS3ServerRejectsAdminResources(t *testing.T) {
+	ctx := context.Background()
+	client := testMockS3Client(t, filepath.Join(t.TempDir(), "mock.json"))
+	provider := Provider()
+
+	for _, name := range []string{"minio_iam_user", "minio_project"} {
+		r := provider.ResourcesMap[name]
+		d := r.TestResourceData()
+		d.SetId("test")
+		diags := r.ReadContext(ctx, d, client)
+		if !diags.HasError() || !strings.Contains(diags[0].Summary, "not supported with mock_state_file") {
+			t.Errorf("%s: expected the read to be rejected by the mock server, got %v", name, diags)
+		}
+	}
+
+	// The plan is rejected before the diff is looked at
+	r := resourceMinioProject()
+	withMockRejection("minio_project", r)
+	if err := r.CustomizeDiff(ctx, nil, client); err == nil || !strings.Contains(err.Error(), "not supported with mock_state_file") {
+		t.Errorf("minio_project: expected the plan to be rejected by the mock server, got %v", err)
+	}
+
+	r = provider.DataSourcesMap["minio_iam_group"]
+	if diags := r.ReadContext(ctx, r.TestResourceData(), client); !diags.HasError() {
+		t.Errorf("minio_iam_group: expected the read to be rejected by the mock server")
+	}
+
+	r = provider.ResourcesMap["minio_s3_bucket"]
+	d := r.TestResourceData()
+	d.SetId("missing")
+	if diags := r.ReadContext(ctx, d, client); diags.HasError() {
+		t.Errorf("minio_s3_bucket: expected the read to be served by the mock server, got %v", diags)
+	}
+}
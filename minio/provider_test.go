@@ -1,10 +1,20 @@
 package minio
 
 import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 var testAccProviders map[string]func() (*schema.Provider, error)
@@ -44,6 +54,33 @@ func TestProvider_impl(t *testing.T) {
 	var _ *schema.Provider = Provider()
 }
 
+func TestProviderWaitForCluster(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/minio/health/ready" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	config := &S3MinioConfig{
+		S3HostPort:       strings.TrimPrefix(server.URL, "http://"),
+		S3WaitForCluster: time.Minute,
+	}
+	if err := config.waitForCluster(context.Background()); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if calls != 3 {
+		t.Errorf("expected 3 readiness checks, got %d", calls)
+	}
+}
+
 var kEnvVarNeeded = []string{
 	"MINIO_ENDPOINT",
 	"MINIO_USER",
@@ -81,3 +118,506 @@ func testAccPreCheck(t *testing.T) {
 		t.Fatal("you must to set env variables for integration tests!")
 	}
 }
+
+func TestProviderParallelBucketLimit(t *testing.T) {
+	var inFlight, maxInFlight int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if current <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, current) {
+				break
+			}
+		}
+		time.Sleep(50 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: newBucketLimitTransport(http.DefaultTransport, 2)}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			req, _ := http.NewRequest(http.MethodPut, fmt.Sprintf("%s/bucket-%d", server.URL, i), nil)
+			resp, err := client.Do(req)
+			if err != nil {
+				t.Errorf("err: %s", err)
+				return
+			}
+			resp.Body.Close()
+		}(i)
+	}
+	wg.Wait()
+
+	if maxInFlight != 2 {
+		t.Errorf("expected at most 2 concurrent bucket requests, got %d", maxInFlight)
+	}
+}
+
+func TestProviderTransportOptions(t *testing.T) {
+	var proxied string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = r.URL.String()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer proxy.Close()
+
+	config := &S3MinioConfig{
+		S3HostPort:           "minio.invalid:9000",
+		S3HTTPProxy:          proxy.URL,
+		S3MaxIdleConnections: 8,
+		S3DisableKeepAlives:  true,
+		S3DialTimeout:        5 * time.Second,
+	}
+	tr, err := config.customTransport()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tr.MaxIdleConns != 8 || tr.MaxIdleConnsPerHost != 8 || !tr.DisableKeepAlives {
+		t.Errorf("expected the connection settings to be applied, got %d idle connections, %d per host, keep-alives disabled %t", tr.MaxIdleConns, tr.MaxIdleConnsPerHost, tr.DisableKeepAlives)
+	}
+
+	resp, err := (&http.Client{Transport: tr}).Get("http://minio.invalid:9000/minio/health/live")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if proxied != "http://minio.invalid:9000/minio/health/live" {
+		t.Errorf("expected the request to go through the proxy, got %q", proxied)
+	}
+
+	for value, valid := range map[string]bool{
+		"":                           true,
+		"http://proxy.internal:3128": true,
+		"socks5://127.0.0.1:1080":    true,
+		"proxy.internal:3128":        false,
+		"ftp://proxy.internal":       false,
+		"http://":                    false,
+	} {
+		if _, errs := validateProxyURL(value, "http_proxy"); (len(errs) == 0) != valid {
+			t.Errorf("%q: expected valid to be %t, got %v", value, valid, errs)
+		}
+	}
+}
+
+func TestProviderCredentialFiles(t *testing.T) {
+	dir := t.TempDir()
+	userFile := filepath.Join(dir, "user")
+	passwordFile := filepath.Join(dir, "password")
+	if err := os.WriteFile(userFile, []byte("minio\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(passwordFile, []byte("minio123\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	config := &S3MinioConfig{S3UserAccess: "ignored", S3UserFile: userFile, S3PasswordFile: passwordFile, S3SessionToken: "token"}
+	if err := config.loadCredentialFiles(); err != nil {
+		t.Fatal(err)
+	}
+	if config.S3UserAccess != "minio" || config.S3UserSecret != "minio123" || config.S3SessionToken != "token" {
+		t.Errorf("expected the credentials of the files, got %q, %q and %q", config.S3UserAccess, config.S3UserSecret, config.S3SessionToken)
+	}
+
+	if err := (&S3MinioConfig{S3SessionTokenFile: filepath.Join(dir, "missing")}).loadCredentialFiles(); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+
+	emptyFile := filepath.Join(dir, "empty")
+	if err := os.WriteFile(emptyFile, []byte("\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := (&S3MinioConfig{S3PasswordFile: emptyFile}).loadCredentialFiles(); err == nil {
+		t.Error("expected an error for an empty file")
+	}
+}
+
+func TestRateLimitTransport(t *testing.T) {
+	tr := newRateLimitTransport(http.DefaultTransport, 2, 0)
+	now := tr.last
+
+	for i := 0; i < 2; i++ {
+		if delay := tr.reserve(now); delay != 0 {
+			t.Fatalf("request #%d: expected the burst to be sent at once, got a %s delay", i, delay)
+		}
+	}
+	if delay := tr.reserve(now); delay != 500*time.Millisecond {
+		t.Errorf("expected the third request to wait 500ms, got %s", delay)
+	}
+	if delay := tr.reserve(now); delay != time.Second {
+		t.Errorf("expected the fourth request to queue behind the third one, got %s", delay)
+	}
+	if delay := tr.reserve(now.Add(5 * time.Second)); delay != 0 {
+		t.Errorf("expected the bucket to be refilled, got a %s delay", delay)
+	}
+
+	if burst := newRateLimitTransport(http.DefaultTransport, 0.5, 0).burst; burst != 1 {
+		t.Errorf("expected the default burst to be at least one request, got %v", burst)
+	}
+	if burst := newRateLimitTransport(http.DefaultTransport, 10, 3).burst; burst != 3 {
+		t.Errorf("expected the configured burst, got %v", burst)
+	}
+}
+
+func TestIsBucketMutatingRequest(t *testing.T) {
+	cases := []struct {
+		method   string
+		path     string
+		expected bool
+	}{
+		{http.MethodPut, "/bucket", true},
+		{http.MethodPut, "/bucket/?versioning=", true},
+		{http.MethodDelete, "/bucket/", true},
+		{http.MethodGet, "/bucket", false},
+		{http.MethodPut, "/bucket/object", false},
+		{http.MethodPut, "/minio/admin/v3/add-user", false},
+		{http.MethodPut, "/", false},
+	}
+
+	for _, c := range cases {
+		req, _ := http.NewRequest(c.method, "http://localhost:9000"+c.path, nil)
+		if actual := isBucketMutatingRequest(req); actual != c.expected {
+			t.Errorf("%s %s: expected %t, got %t", c.method, c.path, c.expected, actual)
+		}
+	}
+}
+
+func TestProviderClusters(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"minio_server":      "primary:9000",
+		"minio_user":        "primary-user",
+		"minio_password":    "primary-password",
+		"minio_api_version": "v2",
+		"clusters": []interface{}{
+			map[string]interface{}{
+				"name":           "eu",
+				"minio_server":   "eu:9000",
+				"minio_user":     "eu-user",
+				"minio_password": "eu-password",
+				"minio_ssl":      true,
+			},
+		},
+	})
+
+	configs, err := newClusterConfigs(d, NewConfig(d))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	eu, ok := configs["eu"]
+	if !ok {
+		t.Fatalf("expected the eu cluster to be configured, got %v", configs)
+	}
+	if eu.S3HostPort != "eu:9000" || eu.S3UserAccess != "eu-user" || eu.S3UserSecret != "eu-password" || !eu.S3SSL {
+		t.Errorf("expected the eu cluster settings to be used, got %+v", eu)
+	}
+	if eu.S3APISignature != "v2" {
+		t.Errorf("expected the API signature to be inherited from the provider, got %q", eu.S3APISignature)
+	}
+
+	client := &S3MinioClient{Clusters: map[string]*S3MinioClient{"eu": {S3Region: "eu-west-1"}}}
+	if meta, err := clusterMeta("", client); err != nil || meta != client {
+		t.Errorf("expected the provider client without cluster, got %v, %v", meta, err)
+	}
+	if meta, err := clusterMeta("eu", client); err != nil || meta.(*S3MinioClient).S3Region != "eu-west-1" {
+		t.Errorf("expected the eu cluster client, got %v, %v", meta, err)
+	}
+	if _, err := clusterMeta("us", client); err == nil {
+		t.Error("expected an unknown cluster to be rejected")
+	}
+}
+
+func TestProviderSignatureVersion(t *testing.T) {
+	cases := []struct {
+		config   map[string]interface{}
+		expected string
+	}{
+		{map[string]interface{}{}, "v4"},
+		{map[string]interface{}{"signature_version": "v2"}, "v2"},
+		{map[string]interface{}{"minio_api_version": "v2"}, "v2"},
+	}
+
+	for _, c := range cases {
+		d := schema.TestResourceDataRaw(t, Provider().Schema, c.config)
+		if actual := NewConfig(d).S3APISignature; actual != c.expected {
+			t.Errorf("%v: expected signature %q, got %q", c.config, c.expected, actual)
+		}
+	}
+
+	for _, config := range []map[string]interface{}{
+		{"signature_version": "v3"},
+		{"minio_api_version": "v3"},
+		{"signature_version": "v2", "minio_api_version": "v4"},
+	} {
+		if diags := Provider().Validate(terraform.NewResourceConfigRaw(config)); !diags.HasError() {
+			t.Errorf("%v: expected the configuration to be rejected", config)
+		}
+	}
+}
+
+func TestProviderClustersImport(t *testing.T) {
+	client := &S3MinioClient{S3Region: "us-east-1"}
+	client.Clusters = map[string]*S3MinioClient{"eu": {S3Region: "eu-west-1"}}
+
+	var importedRegion string
+	r := &schema.Resource{
+		Schema: map[string]*schema.Schema{},
+		Importer: &schema.ResourceImporter{
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				importedRegion = meta.(*S3MinioClient).S3Region
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+	}
+	withClusterSelection(r, true)
+
+	cases := []struct {
+		id       string
+		expected string
+		cluster  string
+		region   string
+	}{
+		{"bucket", "bucket", "", "us-east-1"},
+		{"bucket@eu", "bucket", "eu", "eu-west-1"},
+		{"user@example.com", "user@example.com", "", "us-east-1"},
+	}
+
+	for _, c := range cases {
+		d := r.TestResourceData()
+		d.SetId(c.id)
+		results, err := r.Importer.StateContext(context.Background(), d, client)
+		if err != nil {
+			t.Fatalf("%s: err: %s", c.id, err)
+		}
+		if results[0].Id() != c.expected || results[0].Get("cluster").(string) != c.cluster || importedRegion != c.region {
+			t.Errorf("%s: expected %s on cluster %q (%s), got %s on cluster %q (%s)", c.id, c.expected, c.cluster, c.region, results[0].Id(), results[0].Get("cluster"), importedRegion)
+		}
+	}
+}
+
+func TestProviderEndpointOverride(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"minio_server":   "primary:9000",
+		"minio_user":     "primary-user",
+		"minio_password": "primary-password",
+		"minio_region":   "us-east-1",
+	})
+
+	raw, err := NewConfig(d).NewClient()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	client := raw.(*S3MinioClient)
+
+	if meta, err := endpointMeta("", "", client); err != nil || meta != client {
+		t.Errorf("expected the provider client without override, got %v, %v", meta, err)
+	}
+
+	meta, err := endpointMeta("gateway:9000", "", client)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	gateway := meta.(*S3MinioClient)
+	if gateway.S3Client.EndpointURL().Host != "gateway:9000" || gateway.S3Region != "us-east-1" || gateway.S3UserAccess != "primary-user" {
+		t.Errorf("expected the gateway client to use the provider settings, got %s, %q, %q", gateway.S3Client.EndpointURL().Host, gateway.S3Region, gateway.S3UserAccess)
+	}
+	if meta, _ := endpointMeta("gateway:9000", "", client); meta != gateway {
+		t.Error("expected the endpoint client to be reused")
+	}
+
+	meta, err = endpointMeta("", "eu-west-1", client)
+	if err != nil || meta.(*S3MinioClient).S3Region != "eu-west-1" || meta.(*S3MinioClient).S3Client.EndpointURL().Host != "primary:9000" {
+		t.Errorf("expected the region to be overridden on the provider server, got %v, %v", meta, err)
+	}
+
+	if _, err := endpointMeta("gateway:9000", "", &S3MinioClient{}); err == nil {
+		t.Error("expected an unconfigured provider to be rejected")
+	}
+}
+
+func TestProviderEndpointOverrideImport(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"minio_server":   "primary:9000",
+		"minio_user":     "primary-user",
+		"minio_password": "primary-password",
+		"minio_region":   "us-east-1",
+	})
+
+	raw, err := NewConfig(d).NewClient()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	client := raw.(*S3MinioClient)
+
+	var imported *S3MinioClient
+	r := &schema.Resource{
+		Schema: map[string]*schema.Schema{},
+		Importer: &schema.ResourceImporter{
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				imported = meta.(*S3MinioClient)
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+	}
+	withEndpointOverride(r)
+
+	cases := []struct {
+		id       string
+		expected string
+		endpoint string
+		region   string
+		host     string
+	}{
+		{"bucket", "bucket", "", "", "primary:9000"},
+		{"bucket@endpoint=gateway:9000", "bucket", "gateway:9000", "", "gateway:9000"},
+		{"bucket@region=eu-west-1", "bucket", "", "eu-west-1", "primary:9000"},
+		{"bucket@endpoint=gateway:9000,region=eu-west-1", "bucket", "gateway:9000", "eu-west-1", "gateway:9000"},
+		{"user@example.com", "user@example.com", "", "", "primary:9000"},
+		{"key@endpoint=gateway:9000,acl=private", "key@endpoint=gateway:9000,acl=private", "", "", "primary:9000"},
+	}
+
+	for _, c := range cases {
+		d := r.TestResourceData()
+		d.SetId(c.id)
+		results, err := r.Importer.StateContext(context.Background(), d, client)
+		if err != nil {
+			t.Fatalf("%s: err: %s", c.id, err)
+		}
+		result := results[0]
+		if result.Id() != c.expected || result.Get("endpoint").(string) != c.endpoint || result.Get("region").(string) != c.region {
+			t.Errorf("%s: expected %s with endpoint %q and region %q, got %s with endpoint %q and region %q", c.id, c.expected, c.endpoint, c.region, result.Id(), result.Get("endpoint"), result.Get("region"))
+		}
+		if host := imported.S3Client.EndpointURL().Host; host != c.host {
+			t.Errorf("%s: expected the import to use %s, got %s", c.id, c.host, host)
+		}
+	}
+}
+
+func TestProviderSessionToken(t *testing.T) {
+	var mu sync.Mutex
+	tokens := map[string]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		api := "S3"
+		if strings.HasPrefix(r.URL.Path, "/minio/admin/") {
+			api = "admin"
+		}
+		mu.Lock()
+		tokens[api] = r.Header.Get("X-Amz-Security-Token")
+		mu.Unlock()
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	config := &S3MinioConfig{
+		S3HostPort:     strings.TrimPrefix(server.URL, "http://"),
+		S3Region:       "us-east-1",
+		S3UserAccess:   "ASIATEMPORARY",
+		S3UserSecret:   "secret",
+		S3SessionToken: "sts-session-token",
+		S3APISignature: "v4",
+	}
+	client, err := config.NewClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	minioClient := client.(*S3MinioClient)
+
+	// The temporary credentials sign both the S3 and the admin requests
+	_, _ = minioClient.S3Client.ListBuckets(context.Background())
+	_, _ = minioClient.S3Admin.ServerInfo(context.Background())
+
+	mu.Lock()
+	defer mu.Unlock()
+	for _, api := range []string{"S3", "admin"} {
+		if token, ok := tokens[api]; !ok || token != "sts-session-token" {
+			t.Errorf("expected the %s requests to carry the session token, got %q", api, token)
+		}
+	}
+}
+
+func TestProviderAdminCredentials(t *testing.T) {
+	var mu sync.Mutex
+	accessKeys := map[string]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		api := "S3"
+		if strings.HasPrefix(r.URL.Path, "/minio/admin/") {
+			api = "admin"
+		}
+		_, credential, _ := strings.Cut(r.Header.Get("Authorization"), "Credential=")
+		accessKey, _, _ := strings.Cut(credential, "/")
+		mu.Lock()
+		accessKeys[api] = accessKey
+		mu.Unlock()
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	cases := []struct {
+		adminAccess string
+		expected    string
+	}{
+		{"", "s3-user"},
+		{"admin-user", "admin-user"},
+	}
+
+	for _, c := range cases {
+		config := &S3MinioConfig{
+			S3HostPort:     strings.TrimPrefix(server.URL, "http://"),
+			S3Region:       "us-east-1",
+			S3UserAccess:   "s3-user",
+			S3UserSecret:   "s3-secret",
+			S3AdminAccess:  c.adminAccess,
+			S3AdminSecret:  "admin-secret",
+			S3APISignature: "v4",
+		}
+		client, err := config.NewClient()
+		if err != nil {
+			t.Fatal(err)
+		}
+		minioClient := client.(*S3MinioClient)
+
+		_, _ = minioClient.S3Client.ListBuckets(context.Background())
+		_, _ = minioClient.S3Admin.ServerInfo(context.Background())
+
+		mu.Lock()
+		if accessKeys["S3"] != "s3-user" {
+			t.Errorf("admin user %q: expected the S3 requests to be signed by s3-user, got %q", c.adminAccess, accessKeys["S3"])
+		}
+		if accessKeys["admin"] != c.expected {
+			t.Errorf("admin user %q: expected the admin requests to be signed by %s, got %q", c.adminAccess, c.expected, accessKeys["admin"])
+		}
+		mu.Unlock()
+	}
+
+	// The admin credentials of the provider are not used for the additional clusters
+	d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"minio_server":         "primary:9000",
+		"minio_user":           "primary-user",
+		"minio_password":       "primary-password",
+		"minio_admin_user":     "admin-user",
+		"minio_admin_password": "admin-password",
+		"clusters": []interface{}{
+			map[string]interface{}{
+				"name":           "eu",
+				"minio_server":   "eu:9000",
+				"minio_user":     "eu-user",
+				"minio_password": "eu-password",
+			},
+		},
+	})
+	configs, err := newClusterConfigs(d, NewConfig(d))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if configs["eu"].S3AdminAccess != "" || configs["eu"].S3AdminSecret != "" {
+		t.Errorf("expected the eu cluster not to inherit the admin credentials, got %q", configs["eu"].S3AdminAccess)
+	}
+
+	if diags := Provider().Validate(terraform.NewResourceConfigRaw(map[string]interface{}{"minio_admin_user": "admin-user"})); !diags.HasError() {
+		t.Error("expected minio_admin_user without minio_admin_password to be rejected")
+	}
+}
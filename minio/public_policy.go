@@ -1,7 +1,6 @@
 package minio
 
 import (
-	"fmt"
 	"github.com/minio/minio-go/v7/pkg/policy"
 
 	"github.com/minio/minio-go/v7/pkg/set"
@@ -17,7 +16,7 @@ func PublicPolicy(bucket *S3MinioBucket) BucketPolicy {
 				Effect:    "Allow",
 				Principal: policy.User{AWS: set.CreateStringSet("*")},
 				Actions:   allBucketActions,
-				Resources: set.CreateStringSet([]string{fmt.Sprintf("%s%s", awsResourcePrefix, bucket.MinioBucket), fmt.Sprintf("%s%s/*", awsResourcePrefix, bucket.MinioBucket)}...),
+				Resources: set.CreateStringSet([]string{bucketArn(bucket.MinioBucket), objectArn(bucket.MinioBucket, "*")}...),
 			},
 		},
 	}
@@ -24,7 +24,7 @@ func ReadOnlyPolicy(bucket *S3MinioBucket) BucketPolicy {
 				Actions:   readListMyObjectActions,
 				Effect:    "Allow",
 				Principal: policy.User{AWS: set.CreateStringSet("*")},
-				Resources: set.CreateStringSet([]string{fmt.Sprintf("%s%s", awsResourcePrefix, bucket.MinioBucket), fmt.Sprintf("%s%s/*", awsResourcePrefix, bucket.MinioBucket)}...),
+				Resources: set.CreateStringSet([]string{bucketArn(bucket.MinioBucket), objectArn(bucket.MinioBucket, "*")}...),
 			},
 		},
 	}
@@ -0,0 +1,108 @@
+package minio
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/minio/madmin-go"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/replication"
+)
+
+// replicationCacheTTL bounds how long a cached read is reused. It covers the plan checks, the apply
+// and the read of a single resource operation, without hiding changes made between two runs.
+const replicationCacheTTL = 30 * time.Second
+
+// replicationCache keeps the bucket replication configurations and remote targets read from the
+// server, so the CustomizeDiff, Create and Read steps of a replication resource do not fetch them
+// several times. Every write done through the provider invalidates the bucket entries.
+type replicationCache struct {
+	client *minio.Client
+	admin  *madmin.AdminClient
+	ttl    time.Duration
+
+	mu      sync.Mutex
+	configs map[string]replicationCacheConfig
+	targets map[string]replicationCacheTargets
+}
+
+type replicationCacheConfig struct {
+	config    replication.Config
+	fetchedAt time.Time
+}
+
+type replicationCacheTargets struct {
+	targets   []madmin.BucketTarget
+	fetchedAt time.Time
+}
+
+func newReplicationCache(client *minio.Client, admin *madmin.AdminClient, ttl time.Duration) *replicationCache {
+	return &replicationCache{
+		client:  client,
+		admin:   admin,
+		ttl:     ttl,
+		configs: map[string]replicationCacheConfig{},
+		targets: map[string]replicationCacheTargets{},
+	}
+}
+
+// GetBucketReplication returns the replication configuration of the bucket. Callers get their own
+// copy of the rules and can modify it.
+func (c *replicationCache) GetBucketReplication(ctx context.Context, bucket string) (replication.Config, error) {
+	c.mu.Lock()
+	entry, ok := c.configs[bucket]
+	c.mu.Unlock()
+
+	if !ok || time.Since(entry.fetchedAt) > c.ttl {
+		config, err := c.client.GetBucketReplication(ctx, bucket)
+		if err != nil {
+			return config, err
+		}
+		entry = replicationCacheConfig{config: config, fetchedAt: time.Now()}
+
+		c.mu.Lock()
+		c.configs[bucket] = entry
+		c.mu.Unlock()
+	} else {
+		log.Printf("[DEBUG] Using cached replication configuration of bucket %s", bucket)
+	}
+
+	config := entry.config
+	config.Rules = append([]replication.Rule(nil), entry.config.Rules...)
+	return config, nil
+}
+
+// ListRemoteTargets returns all the remote targets of the bucket. Callers get their own copy.
+func (c *replicationCache) ListRemoteTargets(ctx context.Context, bucket string) ([]madmin.BucketTarget, error) {
+	c.mu.Lock()
+	entry, ok := c.targets[bucket]
+	c.mu.Unlock()
+
+	if !ok || time.Since(entry.fetchedAt) > c.ttl {
+		targets, err := c.admin.ListRemoteTargets(ctx, bucket, "")
+		if err != nil {
+			return targets, err
+		}
+		entry = replicationCacheTargets{targets: targets, fetchedAt: time.Now()}
+
+		c.mu.Lock()
+		c.targets[bucket] = entry
+		c.mu.Unlock()
+	} else {
+		log.Printf("[DEBUG] Using cached remote targets of bucket %s", bucket)
+	}
+
+	return append([]madmin.BucketTarget(nil), entry.targets...), nil
+}
+
+// Invalidate drops the cached reads of the bucket. It must be called after any replication or
+// remote target change.
+func (c *replicationCache) Invalidate(bucket string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.configs, bucket)
+	delete(c.targets, bucket)
+}
@@ -0,0 +1,234 @@
+package minio
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/minio/madmin-go"
+)
+
+const (
+	poolDecommissionActive   = "active"
+	poolDecommissionComplete = "complete"
+	poolDecommissionFailed   = "failed"
+	poolDecommissionCanceled = "canceled"
+)
+
+func resourceMinioAdminPoolDecommission() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: minioCreatePoolDecommission,
+		ReadContext:   minioReadPoolDecommission,
+		UpdateContext: minioUpdatePoolDecommission,
+		DeleteContext: minioDeletePoolDecommission,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(24 * time.Hour),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"pool": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+				Description:  "Pool to decommission, as given on the server command line (e.g. http://server{1...4}/disk{1...4})",
+			},
+			"wait_for_completion": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Wait for the decommission to complete before returning",
+			},
+			"cancel_on_destroy": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Cancel a decommission still in progress when the resource is destroyed",
+			},
+			"status": poolDecommissionStatusSchema(),
+		},
+	}
+}
+
+func poolDecommissionStatusSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"state": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"start_time": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"start_size": {
+					Type:     schema.TypeInt,
+					Computed: true,
+				},
+				"total_size": {
+					Type:     schema.TypeInt,
+					Computed: true,
+				},
+				"current_size": {
+					Type:     schema.TypeInt,
+					Computed: true,
+				},
+			},
+		},
+	}
+}
+
+func minioCreatePoolDecommission(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	admin := meta.(*S3MinioClient).S3Admin
+	pool := d.Get("pool").(string)
+
+	log.Printf("[DEBUG] Starting decommission of pool %s", pool)
+
+	if err := admin.DecommissionPool(ctx, pool); err != nil {
+		return NewResourceError("unable to start pool decommission", pool, err)
+	}
+
+	d.SetId(pool)
+
+	if d.Get("wait_for_completion").(bool) {
+		if err := waitForPoolDecommission(ctx, admin, pool, d.Timeout(schema.TimeoutCreate)); err != nil {
+			return NewResourceError("pool decommission did not complete", pool, err)
+		}
+	}
+
+	return minioReadPoolDecommission(ctx, d, meta)
+}
+
+func minioReadPoolDecommission(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	admin := meta.(*S3MinioClient).S3Admin
+
+	status, err := admin.StatusPool(ctx, d.Id())
+	if isMinioPoolNotFound(err) {
+		log.Printf("[WARN] Pool %s no longer exists, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return NewResourceError("unable to read pool status", d.Id(), err)
+	}
+
+	if status.Decommission == nil {
+		log.Printf("[WARN] Pool %s is not being decommissioned, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	_ = d.Set("pool", d.Id())
+	if err := d.Set("status", flattenPoolDecommissionInfo(status.Decommission)); err != nil {
+		return NewResourceError("unable to read pool status", d.Id(), err)
+	}
+
+	return nil
+}
+
+func minioUpdatePoolDecommission(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// wait_for_completion and cancel_on_destroy only drive the provider behaviour
+	return minioReadPoolDecommission(ctx, d, meta)
+}
+
+func minioDeletePoolDecommission(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	admin := meta.(*S3MinioClient).S3Admin
+
+	if !d.Get("cancel_on_destroy").(bool) {
+		return nil
+	}
+
+	status, err := admin.StatusPool(ctx, d.Id())
+	if isMinioPoolNotFound(err) {
+		log.Printf("[DEBUG] Pool %s no longer exists, nothing to cancel", d.Id())
+		return nil
+	}
+	if err != nil {
+		return NewResourceError("unable to read pool status", d.Id(), err)
+	}
+
+	if poolDecommissionState(status.Decommission) != poolDecommissionActive {
+		return nil
+	}
+
+	log.Printf("[DEBUG] Canceling decommission of pool %s", d.Id())
+	if err := admin.CancelDecommissionPool(ctx, d.Id()); err != nil {
+		return NewResourceError("unable to cancel pool decommission", d.Id(), err)
+	}
+
+	return nil
+}
+
+func waitForPoolDecommission(ctx context.Context, admin *madmin.AdminClient, pool string, timeout time.Duration) error {
+	stateConf := &retry.StateChangeConf{
+		Pending: []string{poolDecommissionActive},
+		Target:  []string{poolDecommissionComplete},
+		Refresh: func() (interface{}, string, error) {
+			status, err := admin.StatusPool(ctx, pool)
+			if err != nil {
+				return nil, "", err
+			}
+			state := poolDecommissionState(status.Decommission)
+			if state == poolDecommissionFailed || state == poolDecommissionCanceled {
+				return status, state, fmt.Errorf("decommission of pool %s is %s", pool, state)
+			}
+			return status, state, nil
+		},
+		Timeout:    timeout,
+		MinTimeout: 10 * time.Second,
+	}
+
+	_, err := stateConf.WaitForStateContext(ctx)
+	return err
+}
+
+// isMinioPoolNotFound reports whether the server does not know the pool, as once a decommissioned pool is removed
+// from the command line of the servers
+func isMinioPoolNotFound(err error) bool {
+	errResp := madmin.ToErrorResponse(err)
+	return errResp.Code == "InvalidArgument" && strings.Contains(errResp.Message, "not found")
+}
+
+func poolDecommissionState(info *madmin.PoolDecommissionInfo) string {
+	switch {
+	case info == nil:
+		return ""
+	case info.Complete:
+		return poolDecommissionComplete
+	case info.Failed:
+		return poolDecommissionFailed
+	case info.Canceled:
+		return poolDecommissionCanceled
+	default:
+		return poolDecommissionActive
+	}
+}
+
+func flattenPoolDecommissionInfo(info *madmin.PoolDecommissionInfo) []interface{} {
+	if info == nil {
+		return nil
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"state":        poolDecommissionState(info),
+			"start_time":   info.StartTime.Format(time.RFC3339),
+			"start_size":   int(info.StartSize),
+			"total_size":   int(info.TotalSize),
+			"current_size": int(info.CurrentSize),
+		},
+	}
+}
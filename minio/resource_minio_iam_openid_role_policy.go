@@ -0,0 +1,173 @@
+package minio
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/minio/madmin-go"
+)
+
+// Keys of the identity_openid configuration managed by minio_iam_openid_role_policy
+const (
+	openIDRolePolicyKey  = "role_policy"
+	openIDClaimNameKey   = "claim_name"
+	openIDClaimPrefixKey = "claim_prefix"
+	openIDRoleARNKey     = "roleARN"
+
+	// openIDDefaultClaimName is the claim read by the server when claim_name is not set
+	openIDDefaultClaimName = "policy"
+)
+
+func resourceMinioIAMOpenIDRolePolicy() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: minioPutOpenIDRolePolicy,
+		ReadContext:   minioReadOpenIDRolePolicy,
+		UpdateContext: minioPutOpenIDRolePolicy,
+		DeleteContext: minioDeleteOpenIDRolePolicy,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     madmin.Default,
+				Description: "Name of the identity_openid configuration, the default one being `_`",
+			},
+			"role_policy": {
+				Type:         schema.TypeSet,
+				Optional:     true,
+				Description:  "Policies applied to every user authenticating through the role ARN of this OpenID provider",
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				ExactlyOneOf: []string{"role_policy", "claim_name"},
+			},
+			"claim_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "JWT claim listing the policies of the user, e.g. the roles mapped by a Keycloak client scope",
+			},
+			"claim_prefix": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Prefix added to every value of claim_name to get the policy name",
+				RequiredWith: []string{"claim_name"},
+			},
+			"role_arn": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Role ARN to use in AssumeRoleWithWebIdentity when role_policy is set",
+			},
+		},
+	}
+}
+
+func minioPutOpenIDRolePolicy(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	admin := meta.(*S3MinioClient).S3Admin
+	name := d.Get("name").(string)
+
+	policies := []string{}
+	for _, policy := range d.Get("role_policy").(*schema.Set).List() {
+		policies = append(policies, policy.(string))
+	}
+
+	cfgData := openIDRolePolicyConfig(policies, d.Get("claim_name").(string), d.Get("claim_prefix").(string))
+
+	log.Printf("[DEBUG] Setting role policy of OpenID configuration %s: %s", name, cfgData)
+	restart, err := admin.AddOrUpdateIDPConfig(ctx, madmin.OpenidIDPCfg, name, cfgData, true)
+	if err != nil {
+		return NewResourceError("unable to set OpenID role policy", name, err)
+	}
+
+	d.SetId(name)
+
+	diags := minioReadOpenIDRolePolicy(ctx, d, meta)
+	if restart {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "MinIO restart required",
+			Detail:   fmt.Sprintf("The OpenID configuration %s was saved, the server must be restarted to apply it", name),
+		})
+	}
+	return diags
+}
+
+func minioReadOpenIDRolePolicy(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	admin := meta.(*S3MinioClient).S3Admin
+
+	cfg, err := admin.GetIDPConfig(ctx, madmin.OpenidIDPCfg, d.Id())
+	if err != nil {
+		if madmin.ToErrorResponse(err).Code == "XMinioAdminNoSuchConfigTarget" {
+			log.Printf("[WARN] OpenID configuration %s not found, removing its role policy from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return NewResourceError("unable to read OpenID role policy", d.Id(), err)
+	}
+
+	values := openIDConfigValues(cfg)
+
+	rolePolicy := splitPolicyNames(values[openIDRolePolicyKey])
+	sort.Strings(rolePolicy)
+	claimName := values[openIDClaimNameKey]
+	if len(rolePolicy) != 0 {
+		// The server reports its default claim, which is ignored when a role policy is set
+		claimName = ""
+	}
+
+	_ = d.Set("name", d.Id())
+	if err := d.Set("role_policy", rolePolicy); err != nil {
+		return NewResourceError("unable to read OpenID role policy", d.Id(), err)
+	}
+	_ = d.Set("claim_name", claimName)
+	_ = d.Set("claim_prefix", values[openIDClaimPrefixKey])
+	_ = d.Set("role_arn", values[openIDRoleARNKey])
+
+	return nil
+}
+
+func minioDeleteOpenIDRolePolicy(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	admin := meta.(*S3MinioClient).S3Admin
+
+	// Policies go back to be read from the default claim
+	cfgData := openIDRolePolicyConfig(nil, openIDDefaultClaimName, "")
+
+	log.Printf("[DEBUG] Removing role policy of OpenID configuration %s", d.Id())
+	if _, err := admin.AddOrUpdateIDPConfig(ctx, madmin.OpenidIDPCfg, d.Id(), cfgData, true); err != nil {
+		if madmin.ToErrorResponse(err).Code == "XMinioAdminNoSuchConfigTarget" {
+			return nil
+		}
+		return NewResourceError("unable to remove OpenID role policy", d.Id(), err)
+	}
+
+	return nil
+}
+
+// openIDRolePolicyConfig returns the configuration keys updating the way an OpenID provider maps its users to
+// policies: either the role policy, or the claim listing them. The server does not accept both.
+func openIDRolePolicyConfig(rolePolicy []string, claimName string, claimPrefix string) string {
+	if len(rolePolicy) != 0 {
+		sort.Strings(rolePolicy)
+		return fmt.Sprintf("%s=%q %s=%q %s=%q", openIDRolePolicyKey, strings.Join(rolePolicy, ","), openIDClaimNameKey, openIDDefaultClaimName, openIDClaimPrefixKey, "")
+	}
+	return fmt.Sprintf("%s=%q %s=%q %s=%q", openIDRolePolicyKey, "", openIDClaimNameKey, claimName, openIDClaimPrefixKey, claimPrefix)
+}
+
+// openIDConfigValues returns the values of the OpenID configuration keys. Environment variables set on the
+// server take precedence over the stored configuration, as they do when it is applied.
+func openIDConfigValues(cfg madmin.IDPConfig) map[string]string {
+	values := map[string]string{}
+	for _, info := range cfg.Info {
+		if _, ok := values[info.Key]; ok && !info.IsEnv {
+			continue
+		}
+		values[info.Key] = info.Value
+	}
+	return values
+}
@@ -0,0 +1,45 @@
+package minio
+
+import (
+	"testing"
+
+	"github.com/minio/madmin-go"
+)
+
+func TestOpenIDRolePolicyConfig(t *testing.T) {
+	cases := []struct {
+		rolePolicy  []string
+		claimName   string
+		claimPrefix string
+		expected    string
+	}{
+		{[]string{"readwrite", "diagnostics"}, "", "", `role_policy="diagnostics,readwrite" claim_name="policy" claim_prefix=""`},
+		{nil, "groups", "keycloak-", `role_policy="" claim_name="groups" claim_prefix="keycloak-"`},
+		{nil, "policy", "", `role_policy="" claim_name="policy" claim_prefix=""`},
+	}
+
+	for _, c := range cases {
+		if actual := openIDRolePolicyConfig(c.rolePolicy, c.claimName, c.claimPrefix); actual != c.expected {
+			t.Errorf("expected %s, got %s", c.expected, actual)
+		}
+	}
+}
+
+func TestOpenIDConfigValues(t *testing.T) {
+	values := openIDConfigValues(madmin.IDPConfig{
+		Type: madmin.OpenidIDPCfg,
+		Info: []madmin.IDPCfgInfo{
+			{Key: "claim_name", Value: "groups", IsCfg: true},
+			{Key: "claim_name", Value: "roles", IsCfg: true, IsEnv: true},
+			{Key: "role_policy", Value: "", IsCfg: true},
+			{Key: "roleARN", Value: "arn:minio:iam:::role/dummy"},
+		},
+	})
+
+	if values["claim_name"] != "roles" {
+		t.Errorf("expected the environment to take precedence, got %q", values["claim_name"])
+	}
+	if values["roleARN"] != "arn:minio:iam:::role/dummy" {
+		t.Errorf("expected the role ARN to be read, got %q", values["roleARN"])
+	}
+}
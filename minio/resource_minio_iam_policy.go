@@ -31,6 +31,7 @@ func resourceMinioIAMPolicy() *schema.Resource {
 				Required:         true,
 				ValidateFunc:     validateIAMPolicyJSON,
 				DiffSuppressFunc: suppressEquivalentAwsPolicyDiffs,
+				Description:      "Policy document, its policy variables such as `${aws:username}` or `${jwt:sub}` being checked at plan time",
 			},
 			"name": {
 				Type:          schema.TypeString,
@@ -46,6 +47,7 @@ func resourceMinioIAMPolicy() *schema.Resource {
 				ForceNew:      true,
 				ConflictsWith: []string{"name"},
 				ValidateFunc:  validateIAMNamePolicy,
+				Description:   "Creates a unique policy name beginning with the specified prefix",
 			},
 		},
 	}
@@ -141,6 +143,11 @@ func validateIAMNamePolicy(v interface{}, k string) (ws []string, errors []error
 		errors = append(errors, fmt.Errorf(
 			"%q must match [\\w+=,.@-]", k))
 	}
+
+	if strings.HasPrefix(value, minioIamUserExpirationPolicyPrefix) {
+		errors = append(errors, fmt.Errorf(
+			"%q cannot start with %q, reserved for the expiration policies of minio_iam_user", k, minioIamUserExpirationPolicyPrefix))
+	}
 	return
 }
 
@@ -156,6 +163,16 @@ func validateIAMPolicyJSON(v interface{}, k string) (ws []string, errors []error
 	}
 	if _, err := structure.NormalizeJsonString(v); err != nil {
 		errors = append(errors, fmt.Errorf("%q contains an invalid JSON: %s", k, err))
+		return
+	}
+
+	// Typos in policy variables would only be noticed when the policy is evaluated
+	variableWarnings, variableErrors := validatePolicyVariables(value)
+	for _, w := range variableWarnings {
+		ws = append(ws, fmt.Sprintf("%q: %s", k, w))
+	}
+	for _, err := range variableErrors {
+		errors = append(errors, fmt.Errorf("%q: %w", k, err))
 	}
 	return
 }
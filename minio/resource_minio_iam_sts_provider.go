@@ -0,0 +1,145 @@
+package minio
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/minio/madmin-go"
+)
+
+// Keys of the identity_tls configuration managed by minio_iam_sts_provider
+const (
+	identityTLSSkipVerifyKey = "skip_verify"
+	identityTLSEnableEnv     = "MINIO_IDENTITY_TLS_ENABLE"
+)
+
+func resourceMinioIAMSTSProvider() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: minioPutSTSProvider,
+		ReadContext:   minioReadSTSProvider,
+		UpdateContext: minioPutSTSProvider,
+		DeleteContext: minioDeleteSTSProvider,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"skip_verify": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Accept client certificates which are not signed by the certificate authorities trusted by the server. Only for testing, as any certificate is then accepted",
+			},
+			"sts_endpoint": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "URL the workloads request their credentials from with their client certificate",
+			},
+		},
+	}
+}
+
+func minioPutSTSProvider(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*S3MinioClient)
+
+	cfgData := identityTLSConfig(d.Get("skip_verify").(bool))
+
+	log.Printf("[DEBUG] Setting certificate STS configuration: %s", cfgData)
+	restart, err := client.S3Admin.SetConfigKV(ctx, cfgData)
+	if err != nil {
+		return NewResourceError("unable to set certificate STS configuration", madmin.IdentityTLSSubSys, err)
+	}
+
+	d.SetId(madmin.IdentityTLSSubSys)
+
+	diags := minioReadSTSProvider(ctx, d, meta)
+	if restart {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "MinIO restart required",
+			Detail:   "The certificate STS configuration was saved, the server must be restarted to apply it",
+		})
+	}
+	if client.S3Client.EndpointURL().Scheme != "https" {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Certificate STS requires TLS",
+			Detail:   fmt.Sprintf("The server is reached without TLS, it only issues credentials for client certificates when serving TLS and started with %s=on", identityTLSEnableEnv),
+		})
+	}
+	return diags
+}
+
+func minioReadSTSProvider(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*S3MinioClient)
+
+	output, err := client.S3Admin.GetConfigKVWithOptions(ctx, madmin.IdentityTLSSubSys, madmin.KVOptions{Env: true})
+	if err != nil {
+		return NewResourceError("unable to read certificate STS configuration", d.Id(), err)
+	}
+
+	config, err := madmin.ParseServerConfigOutput(string(output))
+	if err != nil {
+		return NewResourceError("unable to parse certificate STS configuration", d.Id(), err)
+	}
+
+	skipVerify, err := identityTLSSkipVerify(config)
+	if err != nil {
+		return NewResourceError("unable to read certificate STS configuration", d.Id(), err)
+	}
+
+	endpoint := client.S3Client.EndpointURL()
+	endpoint.Path = "/"
+	endpoint.RawQuery = "Action=AssumeRoleWithCertificate&Version=2011-06-15"
+
+	_ = d.Set("skip_verify", skipVerify)
+	_ = d.Set("sts_endpoint", endpoint.String())
+
+	return nil
+}
+
+func minioDeleteSTSProvider(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	admin := meta.(*S3MinioClient).S3Admin
+
+	log.Printf("[DEBUG] Resetting certificate STS configuration")
+	if _, err := admin.DelConfigKV(ctx, madmin.IdentityTLSSubSys); err != nil {
+		return NewResourceError("unable to reset certificate STS configuration", d.Id(), err)
+	}
+
+	return nil
+}
+
+// identityTLSConfig returns the identity_tls configuration to set on the server
+func identityTLSConfig(skipVerify bool) string {
+	value := madmin.EnableOff
+	if skipVerify {
+		value = madmin.EnableOn
+	}
+	return fmt.Sprintf("%s %s=%s", madmin.IdentityTLSSubSys, identityTLSSkipVerifyKey, value)
+}
+
+// identityTLSSkipVerify returns whether the server skips the verification of the client certificates, an
+// environment variable set on the server taking precedence over the stored configuration
+func identityTLSSkipVerify(config []madmin.SubsysConfig) (bool, error) {
+	for _, subsys := range config {
+		if subsys.SubSystem != madmin.IdentityTLSSubSys {
+			continue
+		}
+		value, ok := subsys.Lookup(identityTLSSkipVerifyKey)
+		if !ok {
+			return false, nil
+		}
+		switch value {
+		case madmin.EnableOn, "true":
+			return true, nil
+		case madmin.EnableOff, "false", "":
+			return false, nil
+		default:
+			return false, fmt.Errorf("invalid value %q of %s", value, identityTLSSkipVerifyKey)
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,67 @@
+package minio
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/minio/madmin-go"
+)
+
+func TestAccMinioIAMSTSProvider_basic(t *testing.T) {
+	resourceName := "minio_iam_sts_provider.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `resource "minio_iam_sts_provider" "test" {}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", madmin.IdentityTLSSubSys),
+					resource.TestCheckResourceAttr(resourceName, "skip_verify", "false"),
+					resource.TestCheckResourceAttrSet(resourceName, "sts_endpoint"),
+				),
+			},
+			{
+				Config: `resource "minio_iam_sts_provider" "test" {
+  skip_verify = true
+}`,
+				Check: resource.TestCheckResourceAttr(resourceName, "skip_verify", "true"),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestIdentityTLSSkipVerify(t *testing.T) {
+	cases := []struct {
+		output   string
+		expected bool
+	}{
+		{"identity_tls skip_verify=off", false},
+		{"identity_tls skip_verify=on", true},
+		{"# MINIO_IDENTITY_TLS_SKIP_VERIFY=on\nidentity_tls skip_verify=off", true},
+		{"region name=us-east-1", false},
+	}
+
+	for _, c := range cases {
+		config, err := madmin.ParseServerConfigOutput(c.output)
+		if err != nil {
+			t.Fatal(err)
+		}
+		actual, err := identityTLSSkipVerify(config)
+		if err != nil {
+			t.Errorf("%s: unexpected error %v", c.output, err)
+		} else if actual != c.expected {
+			t.Errorf("%s: expected %t, got %t", c.output, c.expected, actual)
+		}
+	}
+
+	if actual := identityTLSConfig(true); actual != "identity_tls skip_verify=on" {
+		t.Errorf("unexpected configuration %s", actual)
+	}
+}
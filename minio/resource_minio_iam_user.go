@@ -2,15 +2,20 @@ package minio
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log"
 	"regexp"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/minio/madmin-go"
+	"golang.org/x/exp/slices"
 )
 
 func resourceMinioIAMUser() *schema.Resource {
@@ -26,15 +31,25 @@ func resourceMinioIAMUser() *schema.Resource {
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:         schema.TypeString,
-				Required:     true,
+				Optional:     true,
+				Computed:     true,
 				ValidateFunc: validateMinioIamUserName,
 				ForceNew:     true,
+				ExactlyOneOf: []string{"name", "name_prefix"},
+			},
+			"name_prefix": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateMinioIamUserName,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"name", "name_prefix"},
+				Description:  "Creates a unique user name beginning with the specified prefix",
 			},
 			"force_destroy": {
 				Type:        schema.TypeBool,
 				Optional:    true,
 				Default:     false,
-				Description: "Delete user even if it has non-Terraform-managed IAM access keys",
+				Description: "Delete user even if it has non-Terraform-managed IAM access keys, deleting its service accounts and disabling it first so its STS sessions are denied",
 			},
 			"disable_user": {
 				Type:        schema.TypeBool,
@@ -48,6 +63,12 @@ func resourceMinioIAMUser() *schema.Resource {
 				Default:     false,
 				Description: "Rotate Minio User Secret Key",
 			},
+			"expiration": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsRFC3339Time,
+				Description:  "Date (RFC3339) after which every request of the user is denied, through an attached policy",
+			},
 			"status": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -71,6 +92,11 @@ func minioCreateUser(ctx context.Context, d *schema.ResourceData, meta interface
 	accessKey := iamUserConfig.MinioIAMName
 	secretKey := iamUserConfig.MinioSecret
 
+	if accessKey == "" {
+		accessKey = resource.PrefixedUniqueId(iamUserConfig.MinioIAMNamePrefix)
+		iamUserConfig.MinioIAMName = accessKey
+	}
+
 	if secretKey == "" {
 		if secretKey, err = generateSecretAccessKey(); err != nil {
 			return NewResourceError("error creating user", accessKey, err)
@@ -83,6 +109,7 @@ func minioCreateUser(ctx context.Context, d *schema.ResourceData, meta interface
 	}
 
 	d.SetId(aws.StringValue(&accessKey))
+	_ = d.Set("name", accessKey)
 	_ = d.Set("secret", secretKey)
 
 	if iamUserConfig.MinioDisableUser {
@@ -92,6 +119,12 @@ func minioCreateUser(ctx context.Context, d *schema.ResourceData, meta interface
 		}
 	}
 
+	if iamUserConfig.MinioExpiration != "" {
+		if err := setMinioIamUserExpiration(ctx, iamUserConfig, ""); err != nil {
+			return NewResourceError("error setting expiration of IAM User", d.Id(), err)
+		}
+	}
+
 	return minioReadUser(ctx, d, meta)
 }
 
@@ -133,6 +166,13 @@ func minioUpdateUser(ctx context.Context, d *schema.ResourceData, meta interface
 		_ = d.Set("secret", wantedSecret)
 	}
 
+	if d.HasChange("expiration") {
+		previous, _ := d.GetChange("expiration")
+		if err := setMinioIamUserExpiration(ctx, iamUserConfig, previous.(string)); err != nil {
+			return NewResourceError("error updating expiration of IAM User", d.Id(), err)
+		}
+	}
+
 	return minioReadUser(ctx, d, meta)
 }
 
@@ -155,6 +195,14 @@ func minioReadUser(ctx context.Context, d *schema.ResourceData, meta interface{}
 		return NewResourceError("reading IAM user failed", d.Id(), err)
 	}
 
+	expiration := ""
+	if slices.Contains(splitPolicyNames(output.PolicyName), minioIamUserExpirationPolicyName(d.Id())) {
+		if expiration, err = readMinioIamUserExpiration(ctx, iamUserConfig.MinioAdmin, d.Id()); err != nil {
+			return NewResourceError("error reading expiration of IAM User", d.Id(), err)
+		}
+	}
+	_ = d.Set("expiration", expiration)
+
 	return nil
 }
 
@@ -171,11 +219,27 @@ func minioDeleteUser(ctx context.Context, d *schema.ResourceData, meta interface
 		}
 	}
 
+	if iamUserConfig.MinioForceDestroy {
+		if err := revokeMinioIamUserCredentials(ctx, iamUserConfig); err != nil {
+			return NewResourceError("error revoking IAM User credentials", d.Id(), err)
+		}
+	}
+
 	err := deleteMinioIamUser(ctx, iamUserConfig)
 	if err != nil {
 		return NewResourceError("error deleting IAM User", d.Id(), err)
 	}
 
+	// Only the expiration policy created by this resource is removed
+	if d.Get("expiration").(string) != "" {
+		expirationPolicy := minioIamUserExpirationPolicyName(iamUserConfig.MinioIAMName)
+		if _, err := iamUserConfig.MinioAdmin.InfoCannedPolicy(ctx, expirationPolicy); err == nil {
+			if err := iamUserConfig.MinioAdmin.RemoveCannedPolicy(ctx, expirationPolicy); err != nil {
+				return NewResourceError("error deleting expiration policy of IAM User", d.Id(), err)
+			}
+		}
+	}
+
 	// Actively set resource as deleted as the update path might force a deletion via MinioForceDestroy
 	d.SetId("")
 
@@ -192,6 +256,138 @@ func validateMinioIamUserName(v interface{}, k string) (ws []string, errors []er
 	return
 }
 
+// setMinioIamUserExpiration maintains the policy denying every request of the user after its expiration. The
+// previous expiration tells whether the policy was created by the resource: an existing policy with the same name
+// is never adopted.
+func setMinioIamUserExpiration(ctx context.Context, iamUserConfig *S3MinioIAMUserConfig, previous string) error {
+	policyName := minioIamUserExpirationPolicyName(iamUserConfig.MinioIAMName)
+
+	if iamUserConfig.MinioExpiration == "" {
+		if previous == "" {
+			return nil
+		}
+		if err := toggleMinioIamUserPolicy(ctx, iamUserConfig, policyName, false); err != nil {
+			return err
+		}
+		if _, err := iamUserConfig.MinioAdmin.InfoCannedPolicy(ctx, policyName); err != nil {
+			return nil
+		}
+		return iamUserConfig.MinioAdmin.RemoveCannedPolicy(ctx, policyName)
+	}
+
+	if previous == "" {
+		if _, err := iamUserConfig.MinioAdmin.InfoCannedPolicy(ctx, policyName); err == nil {
+			return fmt.Errorf("policy %s already exists and is not managed by this user", policyName)
+		} else if !isMinioNoSuchPolicy(err) {
+			return err
+		}
+	}
+
+	policy, err := json.Marshal(minioIamUserExpirationPolicy(iamUserConfig.MinioExpiration))
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] Setting expiration of IAM User %s to %s", iamUserConfig.MinioIAMName, iamUserConfig.MinioExpiration)
+	if err := iamUserConfig.MinioAdmin.AddCannedPolicy(ctx, policyName, policy); err != nil {
+		return err
+	}
+	return toggleMinioIamUserPolicy(ctx, iamUserConfig, policyName, true)
+}
+
+// readMinioIamUserExpiration returns the expiration date of the user from its expiration policy
+func readMinioIamUserExpiration(ctx context.Context, admin *madmin.AdminClient, userName string) (string, error) {
+	policyInfo, err := admin.InfoCannedPolicyV2(ctx, minioIamUserExpirationPolicyName(userName))
+	if err != nil {
+		return "", err
+	}
+
+	var policy struct {
+		Statement []struct {
+			Condition map[string]map[string]string
+		}
+	}
+	if err := json.Unmarshal(policyInfo.Policy, &policy); err != nil {
+		return "", err
+	}
+	for _, statement := range policy.Statement {
+		if expiration, ok := statement.Condition["DateGreaterThan"]["aws:CurrentTime"]; ok {
+			return expiration, nil
+		}
+	}
+
+	return "", nil
+}
+
+// minioIamUserExpirationPolicyPrefix is reserved for the expiration policies: minio_iam_policy refuses the names
+// starting with it
+const minioIamUserExpirationPolicyPrefix = "terraform-expiration-"
+
+func minioIamUserExpirationPolicyName(userName string) string {
+	return minioIamUserExpirationPolicyPrefix + userName
+}
+
+// minioIamUserExpirationPolicy denies the S3 actions on every bucket and the admin and KMS actions, which are not
+// scoped by S3 resources, in separate statements
+func minioIamUserExpirationPolicy(expiration string) IAMPolicyDoc {
+	conditions := map[string]map[string]string{
+		"DateGreaterThan": {"aws:CurrentTime": expiration},
+	}
+	return IAMPolicyDoc{
+		Version: "2012-10-17",
+		Statements: []*IAMPolicyStatement{
+			{
+				Sid:        "DenyS3AfterExpiration",
+				Effect:     "Deny",
+				Actions:    []string{"s3:*"},
+				Resources:  []string{bucketArn("*")},
+				Conditions: conditions,
+			},
+			{
+				Sid:        "DenyAdminAfterExpiration",
+				Effect:     "Deny",
+				Actions:    []string{"admin:*", "kms:*"},
+				Conditions: conditions,
+			},
+		},
+	}
+}
+
+// toggleMinioIamUserPolicy attaches or detaches a policy while keeping the other policies of the user
+func toggleMinioIamUserPolicy(ctx context.Context, iamUserConfig *S3MinioIAMUserConfig, policyName string, attached bool) error {
+	userInfo, err := iamUserConfig.MinioAdmin.GetUserInfo(ctx, iamUserConfig.MinioIAMName)
+	if err != nil {
+		return err
+	}
+
+	current := splitPolicyNames(userInfo.PolicyName)
+	policies := []string{}
+	for _, policy := range current {
+		if policy != policyName {
+			policies = append(policies, policy)
+		}
+	}
+	if attached {
+		policies = append(policies, policyName)
+	}
+	if len(policies) == len(current) && slices.Contains(current, policyName) == attached {
+		return nil
+	}
+
+	log.Printf("[DEBUG] Setting policies of IAM User %s to %v", iamUserConfig.MinioIAMName, policies)
+	return iamUserConfig.MinioAdmin.SetPolicy(ctx, strings.Join(policies, ","), iamUserConfig.MinioIAMName, false)
+}
+
+func splitPolicyNames(policyName string) []string {
+	policies := []string{}
+	for _, policy := range strings.Split(policyName, ",") {
+		if policy = strings.TrimSpace(policy); policy != "" {
+			policies = append(policies, policy)
+		}
+	}
+	return policies
+}
+
 func deleteMinioIamUser(ctx context.Context, iamUserConfig *S3MinioIAMUserConfig) error {
 	log.Println("[DEBUG] Deleting IAM User request:", iamUserConfig.MinioIAMName)
 	err := iamUserConfig.MinioAdmin.RemoveUser(ctx, iamUserConfig.MinioIAMName)
@@ -201,6 +397,28 @@ func deleteMinioIamUser(ctx context.Context, iamUserConfig *S3MinioIAMUserConfig
 	return nil
 }
 
+// revokeMinioIamUserCredentials disables the user, which denies the requests of the STS sessions it opened as the
+// server cannot list them, then deletes its service accounts, which would otherwise keep working on some servers
+func revokeMinioIamUserCredentials(ctx context.Context, iamUserConfig *S3MinioIAMUserConfig) error {
+	log.Printf("[DEBUG] Disabling IAM User %s to revoke its STS sessions", iamUserConfig.MinioIAMName)
+	if err := iamUserConfig.MinioAdmin.SetUserStatus(ctx, iamUserConfig.MinioIAMName, madmin.AccountDisabled); err != nil {
+		return fmt.Errorf("unable to disable user: %w", err)
+	}
+
+	serviceAccounts, err := iamUserConfig.MinioAdmin.ListServiceAccounts(ctx, iamUserConfig.MinioIAMName)
+	if err != nil {
+		return fmt.Errorf("unable to list service accounts: %w", err)
+	}
+	for _, accessKey := range serviceAccounts.Accounts {
+		log.Printf("[DEBUG] Deleting service account %s of IAM User %s", accessKey, iamUserConfig.MinioIAMName)
+		if err := iamUserConfig.MinioAdmin.DeleteServiceAccount(ctx, accessKey); err != nil {
+			return fmt.Errorf("unable to delete service account %s: %w", accessKey, err)
+		}
+	}
+
+	return nil
+}
+
 func deleteMinioIamUserGroupMemberships(ctx context.Context, iamUserConfig *S3MinioIAMUserConfig) error {
 
 	userInfo, _ := iamUserConfig.MinioAdmin.GetUserInfo(ctx, iamUserConfig.MinioIAMName)
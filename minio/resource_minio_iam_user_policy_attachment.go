@@ -6,10 +6,13 @@ import (
 	"fmt"
 	"log"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/minio/madmin-go"
 )
 
 func resourceMinioIAMUserPolicyAttachment() *schema.Resource {
@@ -20,6 +23,9 @@ func resourceMinioIAMUserPolicyAttachment() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: minioImportUserPolicyAttachment,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(1 * time.Minute),
+		},
 		Schema: map[string]*schema.Schema{
 			"policy_name": {
 				Type:         schema.TypeString,
@@ -42,7 +48,29 @@ func minioCreateUserPolicyAttachment(ctx context.Context, d *schema.ResourceData
 	var userName = d.Get("user_name").(string)
 	var policyName = d.Get("policy_name").(string)
 	minioAdmin := meta.(*S3MinioClient).S3Admin
-	err := minioAdmin.SetPolicy(ctx, policyName, userName, false)
+
+	// A user created in the same run may not be visible to every node yet, so the attachment is
+	// retried until the user reports the policy.
+	err := retry.RetryContext(ctx, d.Timeout(schema.TimeoutCreate), func() *retry.RetryError {
+		if err := minioAdmin.SetPolicy(ctx, policyName, userName, false); err != nil {
+			if isMinioNoSuchUser(err) {
+				return retry.RetryableError(err)
+			}
+			return retry.NonRetryableError(err)
+		}
+
+		userInfo, err := minioAdmin.GetUserInfo(ctx, userName)
+		if err != nil {
+			if isMinioNoSuchUser(err) {
+				return retry.RetryableError(err)
+			}
+			return retry.NonRetryableError(err)
+		}
+		if !Contains(splitPolicyNames(userInfo.PolicyName), policyName) {
+			return retry.RetryableError(fmt.Errorf("policy %s is not attached to user %s yet", policyName, userName))
+		}
+		return nil
+	})
 	if err != nil {
 		return NewResourceError("unable to Set User policy", userName+" "+policyName, err)
 	}
@@ -55,22 +83,25 @@ func minioCreateUserPolicyAttachment(ctx context.Context, d *schema.ResourceData
 func minioReadUserPolicyAttachment(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	minioAdmin := meta.(*S3MinioClient).S3Admin
 	var userName = d.Get("user_name").(string)
+	var policyName = d.Get("policy_name").(string)
 
 	userInfo, errUser := minioAdmin.GetUserInfo(ctx, userName)
 	if errUser != nil {
+		if isMinioNoSuchUser(errUser) {
+			log.Printf("[WARN] No such user by name (%s) found, removing from state", userName)
+			d.SetId("")
+			return nil
+		}
 		return NewResourceError("failed to load user Infos", userName, errUser)
 	}
 
-	if userInfo.PolicyName == "" {
+	// The user may have other policies attached, only this one is compared
+	if !Contains(splitPolicyNames(userInfo.PolicyName), policyName) {
 		log.Printf("[WARN] No such policy by name (%s) found, removing from state", d.Id())
 		d.SetId("")
 		return nil
 	}
 
-	if err := d.Set("policy_name", string(userInfo.PolicyName)); err != nil {
-		return diag.FromErr(err)
-	}
-
 	return nil
 }
 
@@ -79,7 +110,7 @@ func minioDeleteUserPolicyAttachment(ctx context.Context, d *schema.ResourceData
 	var userName = d.Get("user_name").(string)
 
 	errIam := minioAdmin.SetPolicy(ctx, "", userName, false)
-	if errIam != nil {
+	if errIam != nil && !isMinioNoSuchUser(errIam) {
 		return NewResourceError("unable to delete user policy", userName, errIam)
 	}
 
@@ -106,3 +137,8 @@ func minioImportUserPolicyAttachment(ctx context.Context, d *schema.ResourceData
 	d.SetId(resource.PrefixedUniqueId(fmt.Sprintf("%s-", userName)))
 	return []*schema.ResourceData{d}, nil
 }
+
+// isMinioNoSuchUser tells whether an admin API call failed because the user does not exist
+func isMinioNoSuchUser(err error) bool {
+	return madmin.ToErrorResponse(err).Code == "XMinioAdminNoSuchUser"
+}
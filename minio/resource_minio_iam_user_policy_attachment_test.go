@@ -0,0 +1,64 @@
+package minio
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccMinioUserPolicyAttachment_forEach(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-attach-%d", acctest.RandInt())
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				// The user names are unknown until the users are created in the same run
+				Config: testAccMinioUserPolicyAttachmentConfigForEach(name),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMinioUserPolicyAttached("minio_iam_user_policy_attachment.attachment[\"alice\"]"),
+					testAccCheckMinioUserPolicyAttached("minio_iam_user_policy_attachment.attachment[\"bob\"]"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckMinioUserPolicyAttached(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+
+		minioAdmin := testAccProvider.Meta().(*S3MinioClient).S3Admin
+		userInfo, err := minioAdmin.GetUserInfo(context.Background(), rs.Primary.Attributes["user_name"])
+		if err != nil {
+			return err
+		}
+		if !Contains(splitPolicyNames(userInfo.PolicyName), rs.Primary.Attributes["policy_name"]) {
+			return fmt.Errorf("policy %s is not attached, user policies are %q", rs.Primary.Attributes["policy_name"], userInfo.PolicyName)
+		}
+		return nil
+	}
+}
+
+func testAccMinioUserPolicyAttachmentConfigForEach(name string) string {
+	return fmt.Sprintf(`
+resource "minio_iam_user" "user" {
+  for_each = toset(["alice", "bob"])
+  name     = "%[1]s-${each.key}"
+}
+
+resource "minio_iam_user_policy_attachment" "attachment" {
+  for_each    = minio_iam_user.user
+  user_name   = each.value.id
+  policy_name = "readonly"
+}
+`, name)
+}
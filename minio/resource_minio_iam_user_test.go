@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"regexp"
 	"strings"
 	"testing"
 
@@ -77,6 +78,29 @@ func TestAccAWSUser_basic(t *testing.T) {
 	})
 }
 
+func TestAccAWSUser_NamePrefix(t *testing.T) {
+	var user madmin.UserInfo
+
+	namePrefix := "test-user-"
+	resourceName := "minio_iam_user.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckMinioUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMinioUserConfigNamePrefix(namePrefix),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMinioUserExists(resourceName, &user),
+					resource.TestMatchResourceAttr(resourceName, "name", regexp.MustCompile(fmt.Sprintf("^%s", namePrefix))),
+					resource.TestCheckResourceAttrPair(resourceName, "id", resourceName, "name"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccAWSUser_UpdateName(t *testing.T) {
 	var user madmin.UserInfo
 
@@ -128,6 +152,88 @@ func TestAccAWSUser_DisableUser(t *testing.T) {
 	})
 }
 
+func TestAccAWSUser_Expiration(t *testing.T) {
+	var user madmin.UserInfo
+
+	name := fmt.Sprintf("test-user-%d", acctest.RandInt())
+	resourceName := "minio_iam_user.test7"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckMinioUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMinioUserConfigExpiration(name, "2099-01-01T00:00:00Z"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMinioUserExists(resourceName, &user),
+					testAccCheckMinioUserPolicies(resourceName, minioIamUserExpirationPolicyName(name)),
+					resource.TestCheckResourceAttr(resourceName, "expiration", "2099-01-01T00:00:00Z"),
+				),
+			},
+			{
+				Config: testAccMinioUserConfigExpiration(name, "2098-06-01T00:00:00Z"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMinioUserPolicies(resourceName, minioIamUserExpirationPolicyName(name)),
+					resource.TestCheckResourceAttr(resourceName, "expiration", "2098-06-01T00:00:00Z"),
+				),
+			},
+			{
+				Config: testAccMinioUserConfigExpiration(name, ""),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMinioUserPolicies(resourceName, ""),
+					resource.TestCheckResourceAttr(resourceName, "expiration", ""),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSUser_ExpirationExistingPolicy(t *testing.T) {
+	name := fmt.Sprintf("test-user-%d", acctest.RandInt())
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckMinioUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					client := testAccProvider.Meta().(*S3MinioClient)
+					policy := `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["s3:*"],"Resource":["arn:aws:s3:::*"]}]}`
+					if err := client.S3Admin.AddCannedPolicy(context.Background(), minioIamUserExpirationPolicyName(name), []byte(policy)); err != nil {
+						t.Fatalf("unable to create policy: %v", err)
+					}
+					t.Cleanup(func() {
+						_ = client.S3Admin.RemoveCannedPolicy(context.Background(), minioIamUserExpirationPolicyName(name))
+					})
+				},
+				Config:      testAccMinioUserConfigExpiration(name, "2099-01-01T00:00:00Z"),
+				ExpectError: regexp.MustCompile("already exists and is not managed by this user"),
+			},
+		},
+	})
+}
+
+func TestMinioIamUserExpirationPolicy(t *testing.T) {
+	policy := minioIamUserExpirationPolicy("2099-01-01T00:00:00Z")
+
+	for _, statement := range policy.Statements {
+		if statement.Effect != "Deny" {
+			t.Errorf("statement %s should deny, got %s", statement.Sid, statement.Effect)
+		}
+		for _, action := range statement.Actions.([]string) {
+			if !strings.HasPrefix(action, "s3:") && statement.Resources != nil {
+				t.Errorf("statement %s scopes %s to S3 resources %v", statement.Sid, action, statement.Resources)
+			}
+		}
+	}
+
+	if _, errs := validateIAMNamePolicy(minioIamUserExpirationPolicyName("test-user"), "name"); len(errs) == 0 {
+		t.Errorf("the expiration policy name prefix should be reserved")
+	}
+}
+
 func TestAccAWSUser_RotateAccessKey(t *testing.T) {
 	var user madmin.UserInfo
 	var oldAccessKey string
@@ -214,6 +320,31 @@ func TestAccAWSUser_UpdateAccessKey(t *testing.T) {
 	})
 }
 
+func TestAccAWSUser_ForceDestroyServiceAccounts(t *testing.T) {
+	var serviceAccount string
+
+	name := fmt.Sprintf("test-user-%d", acctest.RandInt())
+	resourceName := "minio_iam_user.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testAccCheckMinioUserDestroy,
+			testAccCheckMinioServiceAccountRevoked(&serviceAccount),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMinioUserConfigForceDestroy(name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "force_destroy", "true"),
+					testAccCheckMinioUserAddServiceAccount(resourceName, &serviceAccount),
+				),
+			},
+		},
+	})
+}
+
 func testAccMinioUserConfigWithSecretOne(rName string) string {
 	return fmt.Sprintf(`
 	resource "minio_iam_user" "test5" {
@@ -238,6 +369,21 @@ func testAccMinioUserConfig(rName string) string {
 		}`, rName)
 }
 
+func testAccMinioUserConfigForceDestroy(rName string) string {
+	return fmt.Sprintf(`
+	resource "minio_iam_user" "test" {
+		  name          = %q
+		  force_destroy = true
+		}`, rName)
+}
+
+func testAccMinioUserConfigNamePrefix(namePrefix string) string {
+	return fmt.Sprintf(`
+	resource "minio_iam_user" "test" {
+		  name_prefix = %q
+		}`, namePrefix)
+}
+
 func testAccMinioUserConfigDisabled(rName string) string {
 	return fmt.Sprintf(`
 	resource "minio_iam_user" "test1" {
@@ -271,6 +417,45 @@ resource "minio_iam_user" "test4" {
 `, rName)
 }
 
+func testAccMinioUserConfigExpiration(rName string, expiration string) string {
+	if expiration == "" {
+		return fmt.Sprintf(`
+resource "minio_iam_user" "test7" {
+  name = %q
+}
+`, rName)
+	}
+
+	return fmt.Sprintf(`
+resource "minio_iam_user" "test7" {
+  name       = %q
+  expiration = %q
+}
+`, rName, expiration)
+}
+
+func testAccCheckMinioUserPolicies(n string, policyName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+
+		minioIam := testAccProvider.Meta().(*S3MinioClient).S3Admin
+
+		resp, err := minioIam.GetUserInfo(context.Background(), rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		if resp.PolicyName != policyName {
+			return fmt.Errorf("user %s has policies %q instead of %q", rs.Primary.ID, resp.PolicyName, policyName)
+		}
+
+		return nil
+	}
+}
+
 func testAccCheckMinioUserExists(n string, res *madmin.UserInfo) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -352,6 +537,36 @@ func testAccCheckMinioUserDestroy(s *terraform.State) error {
 	return nil
 }
 
+// testAccCheckMinioUserAddServiceAccount creates a service account of the user outside of Terraform
+func testAccCheckMinioUserAddServiceAccount(n string, accessKey *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs := s.RootModule().Resources[n]
+		minioIam := testAccProvider.Meta().(*S3MinioClient).S3Admin
+
+		creds, err := minioIam.AddServiceAccount(context.Background(), madmin.AddServiceAccountReq{
+			TargetUser: rs.Primary.ID,
+		})
+		if err != nil {
+			return fmt.Errorf("unable to add service account: %w", err)
+		}
+		*accessKey = creds.AccessKey
+
+		return nil
+	}
+}
+
+func testAccCheckMinioServiceAccountRevoked(accessKey *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		minioIam := testAccProvider.Meta().(*S3MinioClient).S3Admin
+
+		if _, err := minioIam.InfoServiceAccount(context.Background(), *accessKey); err == nil {
+			return fmt.Errorf("service account %s still exists", *accessKey)
+		}
+
+		return nil
+	}
+}
+
 func testAccCheckMinioUserExfiltrateAccessKey(n string, accessKey *string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs := s.RootModule().Resources[n]
@@ -9,7 +9,6 @@ import (
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/minio/minio-go/v7/pkg/lifecycle"
 )
 
@@ -25,10 +24,10 @@ func resourceMinioILMPolicy() *schema.Resource {
 		Description: "`minio_ilm_policy` handles lifecycle settings for a given `minio_s3_bucket`.",
 		Schema: map[string]*schema.Schema{
 			"bucket": {
-				Type:         schema.TypeString,
-				Required:     true,
-				ForceNew:     true,
-				ValidateFunc: validation.StringLenBetween(0, 63),
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateMinioBucketName,
 			},
 			"rule": {
 				Type:     schema.TypeList,
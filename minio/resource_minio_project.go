@@ -0,0 +1,324 @@
+package minio
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/minio/madmin-go"
+	"github.com/minio/minio-go/v7"
+	"golang.org/x/exp/slices"
+)
+
+func resourceMinioProject() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: minioCreateProject,
+		ReadContext:   minioReadProject,
+		UpdateContext: minioUpdateProject,
+		DeleteContext: minioDeleteProject,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		CustomizeDiff: minioDiffProjectMissingParts,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the project, used for the bucket, the policy and the user",
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					if err := validateS3BucketName(v.(string)); err != nil {
+						errors = append(errors, err)
+					}
+					return
+				},
+			},
+			"force_destroy": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Delete all objects from the bucket when destroying the project",
+			},
+			"bucket": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"bucket_arn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"policy_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"user_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"access_key": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"secret_key": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+		},
+	}
+}
+
+func minioCreateProject(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	projectConfig := ProjectConfig(d, meta)
+	name := projectConfig.MinioProjectName
+
+	log.Printf("[DEBUG] Creating project bucket: [%s]", name)
+	if e, err := projectConfig.MinioClient.BucketExists(ctx, name); err != nil {
+		return NewResourceError("unable to check bucket", name, err)
+	} else if e {
+		return NewResourceError("bucket already exists", name, fmt.Errorf("bucket %s already exists", name))
+	}
+
+	if err := projectConfig.MinioClient.MakeBucket(ctx, name, minio.MakeBucketOptions{Region: projectConfig.MinioRegion}); err != nil {
+		return NewResourceError("unable to create project bucket", name, err)
+	}
+
+	// The bucket exists from now on: a failure in the next steps taints the
+	// resource so the partially created project gets cleaned up.
+	d.SetId(name)
+
+	if diags := minioEnsureProjectParts(ctx, d, projectConfig); diags.HasError() {
+		return diags
+	}
+
+	return minioReadProject(ctx, d, meta)
+}
+
+// minioEnsureProjectParts creates the policy, the user and the service account of the project which are missing
+// from the state, on creation or after they were removed outside of Terraform
+func minioEnsureProjectParts(ctx context.Context, d *schema.ResourceData, projectConfig *S3MinioProjectConfig) diag.Diagnostics {
+	name := d.Id()
+
+	if d.Get("policy_name").(string) == "" {
+		policy, err := minioProjectPolicy(name)
+		if err != nil {
+			return NewResourceError("unable to generate project policy", name, err)
+		}
+
+		log.Printf("[DEBUG] Creating project policy: [%s]", name)
+		if err := projectConfig.MinioAdmin.AddCannedPolicy(ctx, name, []byte(policy)); err != nil {
+			return NewResourceError("unable to create project policy", name, err)
+		}
+	}
+
+	if d.Get("user_name").(string) == "" {
+		secretKey, err := generateSecretAccessKey()
+		if err != nil {
+			return NewResourceError("unable to create project user", name, err)
+		}
+
+		log.Printf("[DEBUG] Creating project user: [%s]", name)
+		if err := projectConfig.MinioAdmin.AddUser(ctx, name, secretKey); err != nil {
+			return NewResourceError("unable to create project user", name, err)
+		}
+	}
+
+	if d.Get("policy_name").(string) == "" || d.Get("user_name").(string) == "" {
+		if err := projectConfig.MinioAdmin.SetPolicy(ctx, name, name, false); err != nil {
+			return NewResourceError("unable to attach project policy", name, err)
+		}
+	}
+
+	if d.Get("access_key").(string) == "" {
+		log.Printf("[DEBUG] Creating project service account for: [%s]", name)
+		serviceAccount, err := projectConfig.MinioAdmin.AddServiceAccount(ctx, madmin.AddServiceAccountReq{
+			TargetUser: name,
+		})
+		if err != nil {
+			return NewResourceError("unable to create project service account", name, err)
+		}
+
+		_ = d.Set("access_key", serviceAccount.AccessKey)
+		_ = d.Set("secret_key", serviceAccount.SecretKey)
+	}
+
+	return nil
+}
+
+func minioReadProject(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	projectConfig := ProjectConfig(d, meta)
+
+	found, err := projectConfig.MinioClient.BucketExists(ctx, d.Id())
+	if err != nil {
+		return NewResourceError("unable to check project bucket", d.Id(), err)
+	}
+	if !found {
+		log.Printf("[WARN] Project bucket (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	// The parts removed outside of Terraform are cleared from the state, which plans an update creating them again
+	policyName, userName, accessKey := d.Id(), d.Id(), projectConfig.MinioAccessKey
+
+	if _, err := projectConfig.MinioAdmin.InfoCannedPolicy(ctx, d.Id()); isMinioNoSuchPolicy(err) {
+		log.Printf("[WARN] Project policy (%s) not found, it will be created again", d.Id())
+		policyName = ""
+	} else if err != nil {
+		return NewResourceError("unable to read project policy", d.Id(), err)
+	}
+
+	userInfo, err := projectConfig.MinioAdmin.GetUserInfo(ctx, d.Id())
+	switch {
+	case isMinioNoSuchUser(err):
+		// The service accounts of a user are removed along with it
+		log.Printf("[WARN] Project user (%s) not found, it will be created again", d.Id())
+		userName, accessKey = "", ""
+	case err != nil:
+		return NewResourceError("unable to read project user", d.Id(), err)
+	case !slices.Contains(splitPolicyNames(userInfo.PolicyName), d.Id()):
+		log.Printf("[WARN] Project user (%s) has policy %q instead of the project policy, it will be attached again", d.Id(), userInfo.PolicyName)
+		policyName = ""
+	}
+
+	if accessKey != "" {
+		if _, err := projectConfig.MinioAdmin.InfoServiceAccount(ctx, accessKey); isMinioNoSuchServiceAccount(err) {
+			log.Printf("[WARN] Project service account (%s) not found, it will be created again", accessKey)
+			accessKey = ""
+		} else if err != nil {
+			return NewResourceError("unable to read project service account", d.Id(), err)
+		}
+	}
+
+	_ = d.Set("name", d.Id())
+	_ = d.Set("bucket", d.Id())
+	_ = d.Set("bucket_arn", bucketArn(d.Id()))
+	_ = d.Set("policy_name", policyName)
+	_ = d.Set("user_name", userName)
+	if accessKey == "" {
+		_ = d.Set("access_key", "")
+		_ = d.Set("secret_key", "")
+	}
+
+	return nil
+}
+
+func minioUpdateProject(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// Only force_destroy can be changed in place, and it is only used on delete. The parts of the project removed
+	// outside of Terraform are created again.
+	if diags := minioEnsureProjectParts(ctx, d, ProjectConfig(d, meta)); diags.HasError() {
+		return diags
+	}
+	return minioReadProject(ctx, d, meta)
+}
+
+// minioDiffProjectMissingParts plans an update of a project whose policy, user or service account was removed
+// outside of Terraform, as read cleared them from the state
+func minioDiffProjectMissingParts(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+	for _, key := range minioProjectMissingParts(d.Get("policy_name").(string), d.Get("user_name").(string), d.Get("access_key").(string)) {
+		if err := d.SetNewComputed(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// minioProjectMissingParts returns the computed attributes to plan again for the parts missing from the state
+func minioProjectMissingParts(policyName string, userName string, accessKey string) []string {
+	keys := []string{}
+	if policyName == "" {
+		keys = append(keys, "policy_name")
+	}
+	if userName == "" {
+		keys = append(keys, "user_name")
+	}
+	if accessKey == "" {
+		keys = append(keys, "access_key", "secret_key")
+	}
+	return keys
+}
+
+// isMinioNoSuchPolicy tells whether an admin API call failed because the policy does not exist
+func isMinioNoSuchPolicy(err error) bool {
+	return madmin.ToErrorResponse(err).Code == "XMinioAdminNoSuchPolicy"
+}
+
+// isMinioNoSuchServiceAccount tells whether an admin API call failed because the service account does not exist
+func isMinioNoSuchServiceAccount(err error) bool {
+	return madmin.ToErrorResponse(err).Code == "XMinioAdminServiceAccountNotFound"
+}
+
+func minioDeleteProject(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	projectConfig := ProjectConfig(d, meta)
+	name := d.Id()
+
+	if projectConfig.MinioAccessKey != "" {
+		log.Printf("[DEBUG] Deleting project service account: [%s]", projectConfig.MinioAccessKey)
+		if err := projectConfig.MinioAdmin.DeleteServiceAccount(ctx, projectConfig.MinioAccessKey); err != nil {
+			return NewResourceError("unable to delete project service account", name, err)
+		}
+	}
+
+	if userInfo, err := projectConfig.MinioAdmin.GetUserInfo(ctx, name); err == nil {
+		log.Printf("[DEBUG] Deleting project user: [%s] (%s)", name, userInfo.Status)
+		if err := projectConfig.MinioAdmin.RemoveUser(ctx, name); err != nil {
+			return NewResourceError("unable to delete project user", name, err)
+		}
+	}
+
+	if _, err := projectConfig.MinioAdmin.InfoCannedPolicy(ctx, name); err == nil {
+		log.Printf("[DEBUG] Deleting project policy: [%s]", name)
+		if err := projectConfig.MinioAdmin.RemoveCannedPolicy(ctx, name); err != nil {
+			return NewResourceError("unable to delete project policy", name, err)
+		}
+	}
+
+	if projectConfig.MinioForceDestroy {
+		if err := minioEmptyBucket(ctx, &S3MinioBucket{MinioClient: projectConfig.MinioClient}, name); err != nil {
+			return NewResourceError("unable to empty project bucket", name, err)
+		}
+	}
+
+	log.Printf("[DEBUG] Deleting project bucket: [%s]", name)
+	if err := projectConfig.MinioClient.RemoveBucket(ctx, name); err != nil {
+		return NewResourceError("unable to delete project bucket", name, err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+// minioProjectPolicy returns a policy granting read/write access to the objects of a single bucket.
+func minioProjectPolicy(bucket string) (string, error) {
+	policy := IAMPolicyDoc{
+		Version: "2012-10-17",
+		Statements: []*IAMPolicyStatement{
+			{
+				Sid:       "ListProjectBucket",
+				Effect:    "Allow",
+				Actions:   []string{"s3:GetBucketLocation", "s3:ListBucket", "s3:ListBucketMultipartUploads"},
+				Resources: []string{bucketArn(bucket)},
+			},
+			{
+				Sid:       "ReadWriteProjectObjects",
+				Effect:    "Allow",
+				Actions:   []string{"s3:AbortMultipartUpload", "s3:DeleteObject", "s3:GetObject", "s3:ListMultipartUploadParts", "s3:PutObject"},
+				Resources: []string{objectArn(bucket, "*")},
+			},
+		},
+	}
+
+	policyJSON, err := json.Marshal(policy)
+	if err != nil {
+		return "", err
+	}
+	return string(policyJSON), nil
+}
@@ -0,0 +1,156 @@
+package minio
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccMinioProject_basic(t *testing.T) {
+	name := fmt.Sprintf("tf-test-project-%d", acctest.RandInt())
+	resourceName := "minio_project.project"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckMinioProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMinioProjectConfig(name),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMinioProjectExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "bucket", name),
+					resource.TestCheckResourceAttr(resourceName, "bucket_arn", testAccBucketArn(name)),
+					resource.TestCheckResourceAttr(resourceName, "policy_name", name),
+					resource.TestCheckResourceAttr(resourceName, "user_name", name),
+					resource.TestCheckResourceAttrSet(resourceName, "access_key"),
+					resource.TestCheckResourceAttrSet(resourceName, "secret_key"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"force_destroy", "access_key", "secret_key"},
+			},
+		},
+	})
+}
+
+func TestAccMinioProject_recreateRemovedParts(t *testing.T) {
+	name := fmt.Sprintf("tf-test-project-%d", acctest.RandInt())
+	resourceName := "minio_project.project"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckMinioProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMinioProjectConfig(name),
+				Check:  testAccCheckMinioProjectExists(resourceName),
+			},
+			{
+				PreConfig: func() {
+					client := testAccProvider.Meta().(*S3MinioClient)
+					if err := client.S3Admin.RemoveUser(context.Background(), name); err != nil {
+						t.Fatalf("unable to remove project user: %v", err)
+					}
+					if err := client.S3Admin.RemoveCannedPolicy(context.Background(), name); err != nil {
+						t.Fatalf("unable to remove project policy: %v", err)
+					}
+				},
+				Config: testAccMinioProjectConfig(name),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMinioProjectExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "policy_name", name),
+					resource.TestCheckResourceAttr(resourceName, "user_name", name),
+					resource.TestCheckResourceAttrSet(resourceName, "access_key"),
+				),
+			},
+		},
+	})
+}
+
+func TestMinioProjectMissingParts(t *testing.T) {
+	cases := []struct {
+		policyName, userName, accessKey string
+		expected                        []string
+	}{
+		{"project", "project", "AKIA", []string{}},
+		{"", "project", "AKIA", []string{"policy_name"}},
+		{"project", "", "", []string{"user_name", "access_key", "secret_key"}},
+		{"project", "project", "", []string{"access_key", "secret_key"}},
+	}
+
+	for _, c := range cases {
+		if got := minioProjectMissingParts(c.policyName, c.userName, c.accessKey); !reflect.DeepEqual(got, c.expected) {
+			t.Errorf("minioProjectMissingParts(%q, %q, %q) = %v, expected %v", c.policyName, c.userName, c.accessKey, got, c.expected)
+		}
+	}
+}
+
+func testAccMinioProjectConfig(name string) string {
+	return fmt.Sprintf(`
+resource "minio_project" "project" {
+  name          = %q
+  force_destroy = true
+}
+`, name)
+}
+
+func testAccCheckMinioProjectExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("no ID is set")
+		}
+
+		client := testAccProvider.Meta().(*S3MinioClient)
+		if found, err := client.S3Client.BucketExists(context.Background(), rs.Primary.ID); err != nil || !found {
+			return fmt.Errorf("project bucket %s not found: %v", rs.Primary.ID, err)
+		}
+
+		userInfo, err := client.S3Admin.GetUserInfo(context.Background(), rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("project user %s not found: %v", rs.Primary.ID, err)
+		}
+		if userInfo.PolicyName != rs.Primary.ID {
+			return fmt.Errorf("project user %s has policy %q", rs.Primary.ID, userInfo.PolicyName)
+		}
+
+		_, err = client.S3Admin.InfoServiceAccount(context.Background(), rs.Primary.Attributes["access_key"])
+		return err
+	}
+}
+
+func testAccCheckMinioProjectDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*S3MinioClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "minio_project" {
+			continue
+		}
+
+		if found, _ := client.S3Client.BucketExists(context.Background(), rs.Primary.ID); found {
+			return fmt.Errorf("project bucket %s still exists", rs.Primary.ID)
+		}
+		if _, err := client.S3Admin.GetUserInfo(context.Background(), rs.Primary.ID); err == nil {
+			return fmt.Errorf("project user %s still exists", rs.Primary.ID)
+		}
+		if _, err := client.S3Admin.InfoCannedPolicy(context.Background(), rs.Primary.ID); err == nil {
+			return fmt.Errorf("project policy %s still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
@@ -9,7 +9,11 @@ import (
 	"net/url"
 	"regexp"
 	"strings"
+	"sync"
+	"time"
 
+	"github.com/dustin/go-humanize"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/minio/minio-go/v7"
 
@@ -19,6 +23,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/minio/minio-go/v7/pkg/s3utils"
+	"github.com/minio/minio-go/v7/pkg/tags"
 )
 
 func resourceMinioBucket() *schema.Resource {
@@ -30,36 +35,61 @@ func resourceMinioBucket() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceMinioS3BucketImportState,
 		},
+		CustomizeDiff: minioValidateBucketQuotaCapacity,
 
 		SchemaVersion: 0,
 
 		Schema: map[string]*schema.Schema{
 			"bucket": {
-				Type:          schema.TypeString,
-				Optional:      true,
-				Computed:      true,
-				ForceNew:      true,
-				ConflictsWith: []string{"bucket_prefix"},
-				ValidateFunc:  validation.StringLenBetween(0, 63),
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ForceNew:         true,
+				ConflictsWith:    []string{"bucket_prefix"},
+				ValidateDiagFunc: validateMinioBucketName,
 			},
 			"bucket_prefix": {
 				Type:          schema.TypeString,
 				Optional:      true,
 				ForceNew:      true,
 				ConflictsWith: []string{"bucket"},
-				ValidateFunc:  validation.StringLenBetween(0, 63-resource.UniqueIDSuffixLength),
+				Description:   "Creates a unique bucket name beginning with the specified prefix",
+				ValidateFunc: validation.All(
+					validation.StringLenBetween(0, 63-resource.UniqueIDSuffixLength),
+					validation.StringMatch(regexp.MustCompile(`^[0-9a-z][0-9a-z-.]*$`), "must start with a lowercase letter or a digit and only contain lowercase alphanumeric characters, hyphens and periods"),
+				),
 			},
 			"force_destroy": {
 				Type:     schema.TypeBool,
 				Optional: true,
 				Default:  false,
 			},
+			"force_destroy_workers": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      4,
+				ValidateFunc: validation.IntBetween(1, 64),
+				Description:  "Number of concurrent workers removing objects when force_destroy is set",
+			},
+			"force_destroy_bypass_governance": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Remove every object version when force_destroy is set, including the versions under GOVERNANCE retention. Requires the s3:BypassGovernanceRetention permission",
+			},
 			"acl": {
 				Type:     schema.TypeString,
 				Optional: true,
 				Default:  "private",
 				ForceNew: false,
 			},
+			"object_locking": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				ForceNew:    true,
+				Description: "Create the bucket with object lock enabled, which enables its versioning too. Object lock cannot be enabled on an existing bucket nor disabled, changing it replaces the bucket",
+			},
 			"arn": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -68,10 +98,42 @@ func resourceMinioBucket() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"endpoint_url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Path-style URL of the bucket",
+			},
+			"virtual_host_url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Virtual-host style URL of the bucket, empty when the endpoint or the bucket name does not allow it",
+			},
+			"creation_date": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Date the bucket was created, in RFC3339 format",
+			},
+			"owner": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Access key of the credentials the bucket is managed with, MinIO listing every bucket under the same owner",
+			},
 			"quota": {
 				Type:     schema.TypeInt,
 				Optional: true,
 			},
+			"quota_capacity_margin": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntBetween(0, 99),
+				Description:  "Check during plan that the quota fits in the space left in the cluster, keeping this percentage of its usable capacity free, e.g. 10. The quota is not checked when omitted",
+			},
+			"enforce_object_encryption": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Make minio_s3_object uploads into the bucket request the server side encryption of the bucket encryption configuration, and fail those which would be stored unencrypted. The uploads are only checked when their credentials can read the bucket tags (s3:GetBucketTagging)",
+			},
 		},
 	}
 }
@@ -108,8 +170,10 @@ func minioCreateBucket(ctx context.Context, d *schema.ResourceData, meta interfa
 		return NewResourceError("bucket already exists!", bucket, err)
 	}
 
+	// Object lock is requested with the creation, so the bucket is never left without it
 	err := bucketConfig.MinioClient.MakeBucket(ctx, bucket, minio.MakeBucketOptions{
-		Region: region,
+		Region:        region,
+		ObjectLocking: bucketConfig.MinioObjectLocking,
 	})
 	if err != nil {
 		log.Printf("%s", NewResourceErrorStr("unable to create bucket", bucket, err))
@@ -153,6 +217,27 @@ func minioReadBucket(ctx context.Context, d *schema.ResourceData, meta interface
 
 	_ = d.Set("arn", bucketArn(d.Id()))
 	_ = d.Set("bucket_domain_name", bucketDomainName(d.Id(), bucketURL))
+	_ = d.Set("endpoint_url", bucketEndpointURL(d.Id(), bucketURL))
+	_ = d.Set("virtual_host_url", bucketVirtualHostURL(d.Id(), bucketURL))
+
+	creationDate, err := bucketCreationDate(ctx, bucketConfig.MinioClient, d.Id())
+	if err != nil {
+		return NewResourceError("unable to list buckets", d.Id(), err)
+	}
+	_ = d.Set("creation_date", creationDate)
+	_ = d.Set("owner", bucketConfig.MinioAccess)
+
+	objectLocking, err := bucketObjectLockEnabled(ctx, bucketConfig.MinioClient, d.Id())
+	if err != nil {
+		return NewResourceError("unable to read bucket object lock", d.Id(), err)
+	}
+	_ = d.Set("object_locking", objectLocking)
+
+	enforceEncryption, err := bucketEnforcesObjectEncryption(ctx, bucketConfig.MinioClient, d.Id())
+	if err != nil {
+		return NewResourceError("unable to read bucket tags", d.Id(), err)
+	}
+	_ = d.Set("enforce_object_encryption", enforceEncryption)
 
 	return nil
 }
@@ -188,6 +273,13 @@ func minioUpdateBucket(ctx context.Context, d *schema.ResourceData, meta interfa
 		_ = d.Set("quota", bucketQuota.Quota)
 	}
 
+	if d.HasChange("enforce_object_encryption") {
+		enforce := d.Get("enforce_object_encryption").(bool)
+		if err := setBucketEnforceObjectEncryption(ctx, bucketConfig.MinioClient, d.Id(), enforce); err != nil {
+			return NewResourceError("[Encryption] Unable to update bucket", d.Id(), err)
+		}
+	}
+
 	return minioReadBucket(ctx, d, meta)
 }
 
@@ -199,32 +291,8 @@ func minioDeleteBucket(ctx context.Context, d *schema.ResourceData, meta interfa
 	if err = bucketConfig.MinioClient.RemoveBucket(ctx, d.Id()); err != nil {
 		if strings.Contains(err.Error(), "empty") {
 			if bucketConfig.MinioForceDestroy {
-				objectsCh := make(chan minio.ObjectInfo)
-
-				// Send object names that are needed to be removed to objectsCh
-				go func() {
-					defer close(objectsCh)
-
-					ctx, cancel := context.WithCancel(ctx)
-
-					// Indicate to our routine to exit cleanly upon return.
-					defer cancel()
-
-					// List all objects from a bucket-name with a matching prefix.
-					for object := range bucketConfig.MinioClient.ListObjects(ctx, d.Id(), minio.ListObjectsOptions{
-						Recursive: true,
-					}) {
-						if object.Err != nil {
-							log.Fatalln(object.Err)
-						}
-						objectsCh <- object
-					}
-				}()
-
-				errorCh := bucketConfig.MinioClient.RemoveObjects(ctx, d.Id(), objectsCh, minio.RemoveObjectsOptions{})
-
-				if len(errorCh) > 0 {
-					return NewResourceError("unable to remove bucket", d.Id(), errors.New("could not delete objects"))
+				if err := minioEmptyBucket(ctx, bucketConfig, d.Id()); err != nil {
+					return NewResourceError("unable to remove bucket", d.Id(), err)
 				}
 
 				return minioDeleteBucket(ctx, d, meta)
@@ -245,6 +313,73 @@ func minioDeleteBucket(ctx context.Context, d *schema.ResourceData, meta interfa
 
 }
 
+// minioEmptyBucket removes every object in the bucket. Listed objects are fanned
+// out to several RemoveObjects calls, each of them batching up to 1000 keys per
+// DeleteObjects request.
+func minioEmptyBucket(ctx context.Context, bucketConfig *S3MinioBucket, bucket string) error {
+	workers := bucketConfig.MinioForceDestroyWorkers
+	if workers < 1 {
+		workers = 1
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var listErr error
+	objectsCh := make(chan minio.ObjectInfo, workers*1000)
+
+	// Send object names that are needed to be removed to objectsCh
+	go func() {
+		defer close(objectsCh)
+
+		// Retention applies to object versions, which must be removed one by one to bypass it
+		for object := range bucketConfig.MinioClient.ListObjects(ctx, bucket, minio.ListObjectsOptions{
+			Recursive:    true,
+			WithVersions: bucketConfig.MinioForceDestroyBypassGovernance,
+		}) {
+			if object.Err != nil {
+				listErr = object.Err
+				return
+			}
+			select {
+			case objectsCh <- object:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var removeErrs []minio.RemoveObjectError
+
+	removeOpts := minio.RemoveObjectsOptions{GovernanceBypass: bucketConfig.MinioForceDestroyBypassGovernance}
+
+	log.Printf("[DEBUG] Removing objects from bucket [%s] with %d workers", bucket, workers)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for removeErr := range bucketConfig.MinioClient.RemoveObjects(ctx, bucket, objectsCh, removeOpts) {
+				mu.Lock()
+				removeErrs = append(removeErrs, removeErr)
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if listErr != nil {
+		return fmt.Errorf("could not list objects: %w", listErr)
+	}
+
+	if len(removeErrs) > 0 {
+		return fmt.Errorf("could not delete %d objects, first error on %q: %w", len(removeErrs), removeErrs[0].ObjectName, removeErrs[0].Err)
+	}
+
+	return nil
+}
+
 func minioSetBucketACL(ctx context.Context, bucketConfig *S3MinioBucket) diag.Diagnostics {
 
 	defaultPolicies := map[string]string{
@@ -285,6 +420,53 @@ func minioSetBucketQuota(ctx context.Context, bucketConfig *S3MinioBucket, bucke
 	return nil
 }
 
+// bucketEnforceEncryptionTag is the bucket tag recording enforce_object_encryption, so the uploads of
+// minio_s3_object find it on the bucket they land in
+const bucketEnforceEncryptionTag = "terraform-provider-minio:enforce-object-encryption"
+
+// bucketEnforcesObjectEncryption returns whether the uploads into a bucket must be encrypted. Credentials which
+// cannot read the bucket tags, such as the object only policy of minio_project, upload without the check.
+func bucketEnforcesObjectEncryption(ctx context.Context, client *minio.Client, bucket string) (bool, error) {
+	bucketTags, err := client.GetBucketTagging(ctx, bucket)
+	if err != nil {
+		switch minio.ToErrorResponse(err).Code {
+		case "NoSuchTagSet":
+			return false, nil
+		case "AccessDenied":
+			log.Printf("[WARN] Unable to read the tags of bucket %s, enforce_object_encryption is not checked: %v", bucket, err)
+			return false, nil
+		}
+		return false, err
+	}
+	return bucketTags.ToMap()[bucketEnforceEncryptionTag] == "true", nil
+}
+
+// setBucketEnforceObjectEncryption adds or removes the enforce_object_encryption tag, keeping the other tags
+// of the bucket
+func setBucketEnforceObjectEncryption(ctx context.Context, client *minio.Client, bucket string, enforce bool) error {
+	tagMap := map[string]string{}
+	bucketTags, err := client.GetBucketTagging(ctx, bucket)
+	if err == nil {
+		tagMap = bucketTags.ToMap()
+	} else if minio.ToErrorResponse(err).Code != "NoSuchTagSet" {
+		return err
+	}
+
+	if enforce {
+		tagMap[bucketEnforceEncryptionTag] = "true"
+	} else {
+		delete(tagMap, bucketEnforceEncryptionTag)
+	}
+
+	if len(tagMap) == 0 {
+		return client.RemoveBucketTagging(ctx, bucket)
+	}
+	if bucketTags, err = tags.MapToBucketTags(tagMap); err != nil {
+		return err
+	}
+	return client.SetBucketTagging(ctx, bucket, bucketTags)
+}
+
 func exportPolicyString(policyStruct BucketPolicy, bucketName string) string {
 	policyJSON, err := json.Marshal(policyStruct)
 	if err != nil {
@@ -294,14 +476,61 @@ func exportPolicyString(policyStruct BucketPolicy, bucketName string) string {
 	return string(policyJSON)
 }
 
+// bucketCreationDate returns the creation date of a bucket, which only the bucket listing holds
+func bucketCreationDate(ctx context.Context, client *minio.Client, bucket string) (string, error) {
+	buckets, err := client.ListBuckets(ctx)
+	if err != nil {
+		return "", err
+	}
+	for _, info := range buckets {
+		if info.Name == bucket {
+			return info.CreationDate.UTC().Format(time.RFC3339), nil
+		}
+	}
+	return "", nil
+}
+
 func bucketArn(bucket string) string {
 	return fmt.Sprintf("%s%s", awsResourcePrefix, bucket)
 }
 
+func objectArn(bucket string, key string) string {
+	return fmt.Sprintf("%s/%s", bucketArn(bucket), key)
+}
+
 func bucketDomainName(bucket string, bucketConfig *url.URL) string {
 	return fmt.Sprintf("%s/minio/%s", bucketConfig, bucket)
 }
 
+func bucketEndpointURL(bucket string, endpoint *url.URL) string {
+	return fmt.Sprintf("%s://%s/%s", endpoint.Scheme, endpoint.Host, bucket)
+}
+
+// bucketVirtualHostURL returns the virtual-host style URL of the bucket. IP
+// and single label endpoints (e.g. localhost) cannot be prefixed by the bucket
+// name, and buckets containing dots would not match a wildcard TLS certificate.
+func bucketVirtualHostURL(bucket string, endpoint *url.URL) string {
+	hostname := endpoint.Hostname()
+	if s3utils.IsValidIP(hostname) || !strings.Contains(hostname, ".") || !s3utils.IsValidDomain(hostname) {
+		return ""
+	}
+	if endpoint.Scheme == "https" && strings.Contains(bucket, ".") {
+		return ""
+	}
+	return fmt.Sprintf("%s://%s.%s", endpoint.Scheme, bucket, endpoint.Host)
+}
+
+// validateMinioBucketName checks a bucket name with the rules the server applies, so an invalid
+// name fails at plan time instead of during the apply.
+func validateMinioBucketName(v interface{}, p cty.Path) diag.Diagnostics {
+	value := v.(string)
+	if err := s3utils.CheckValidBucketName(value); err != nil {
+		return diag.Errorf("invalid bucket name %q: %v", value, err)
+	}
+
+	return nil
+}
+
 func validateS3BucketName(value string) error {
 	if (len(value) < 3) || (len(value) > 63) {
 		return fmt.Errorf("%q must contain from 3 to 63 characters", value)
@@ -324,3 +553,55 @@ func validateS3BucketName(value string) error {
 
 	return nil
 }
+
+// minioValidateBucketQuotaCapacity fails the plan when the quota of the bucket does not fit in the space left in the
+// cluster, when quota_capacity_margin is set
+func minioValidateBucketQuotaCapacity(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	rawConfig := d.GetRawConfig()
+	if rawConfig.IsNull() || !rawConfig.IsKnown() || rawConfig.GetAttr("quota_capacity_margin").IsNull() {
+		return nil
+	}
+	if !d.HasChanges("quota", "quota_capacity_margin") || !d.NewValueKnown("quota") || d.Get("quota").(int) == 0 {
+		return nil
+	}
+	m, ok := meta.(*S3MinioClient)
+	if !ok {
+		return nil
+	}
+
+	free, total, err := clusterFreeSpace(ctx, m.S3Admin)
+	if err != nil {
+		return fmt.Errorf("unable to check the quota against the capacity of the cluster: %w", err)
+	}
+
+	// The data already in the bucket counts in its quota, it takes no more space
+	var used uint64
+	if d.Id() != "" {
+		usage, err := m.S3Admin.DataUsageInfo(ctx)
+		if err != nil {
+			log.Printf("[WARN] Unable to read the usage of bucket %s, checking its whole quota: %v", d.Id(), err)
+		} else {
+			used = usage.BucketsUsage[d.Id()].Size
+		}
+	}
+
+	return checkBucketQuotaCapacity(uint64(d.Get("quota").(int)), used, free, total, uint64(d.Get("quota_capacity_margin").(int)))
+}
+
+// checkBucketQuotaCapacity returns an error when the space a quota can still take, beyond the used space, exceeds the
+// free space minus margin percent of the total capacity
+func checkBucketQuotaCapacity(quota uint64, used uint64, free uint64, total uint64, margin uint64) error {
+	var needed, available uint64
+	if quota > used {
+		needed = quota - used
+	}
+	if reserved := total * margin / 100; free > reserved {
+		available = free - reserved
+	}
+
+	if needed > available {
+		return fmt.Errorf("quota of %s needs %s beyond the %s used, more than the %s left in the cluster once %d%% of its %s capacity is kept free",
+			humanize.IBytes(quota), humanize.IBytes(needed), humanize.IBytes(used), humanize.IBytes(available), margin, humanize.IBytes(total))
+	}
+	return nil
+}
@@ -0,0 +1,223 @@
+package minio
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/minio/minio-go/v7/pkg/policy"
+)
+
+// anonymousAccessPolicies maps the access levels of mc anonymous to the canned policies they compile to
+var anonymousAccessPolicies = map[string]policy.BucketPolicy{
+	"none":     policy.BucketPolicyNone,
+	"download": policy.BucketPolicyReadOnly,
+	"upload":   policy.BucketPolicyWriteOnly,
+	"public":   policy.BucketPolicyReadWrite,
+}
+
+func resourceMinioBucketAnonymousAccess() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: minioPutBucketAnonymousAccess,
+		ReadContext:   minioReadBucketAnonymousAccess,
+		UpdateContext: minioPutBucketAnonymousAccess,
+		DeleteContext: minioDeleteBucketAnonymousAccess,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		CustomizeDiff: minioValidateBucketAnonymousAccessPublicWrite,
+		Schema: map[string]*schema.Schema{
+			"bucket": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateMinioBucketName,
+			},
+			"rule": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				MinItems:    1,
+				Description: "Anonymous access to the objects under a prefix, the longest matching prefix applying to an object",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"prefix": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "",
+							Description: "Prefix of the objects the access applies to, the whole bucket when empty",
+						},
+						"access": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice([]string{"none", "download", "upload", "public"}, false),
+							Description:  "Access granted to everyone, as with mc anonymous set: none, download (list and read), upload (list and write) or public (list, read and write)",
+						},
+					},
+				},
+			},
+			"allow_public_write": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Allow the upload and public access when the provider strict_bucket_policies option is set",
+			},
+			"policy": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Bucket policy the rules compile to",
+			},
+		},
+	}
+}
+
+func minioPutBucketAnonymousAccess(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*S3MinioClient).S3Client
+	bucket := d.Get("bucket").(string)
+
+	bucketPolicy, err := compileAnonymousAccessRules(bucket, anonymousAccessRules(d.Get("rule").(*schema.Set)))
+	if err != nil {
+		return NewResourceError("unable to compile anonymous access rules", bucket, err)
+	}
+
+	log.Printf("[DEBUG] S3 bucket: %s, put anonymous access policy: %s", bucket, bucketPolicy)
+	if err := client.SetBucketPolicy(ctx, bucket, bucketPolicy); err != nil {
+		return NewResourceError("error putting bucket anonymous access", bucket, err)
+	}
+
+	d.SetId(bucket)
+
+	return minioReadBucketAnonymousAccess(ctx, d, meta)
+}
+
+func minioReadBucketAnonymousAccess(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*S3MinioClient).S3Client
+
+	log.Printf("[DEBUG] S3 bucket anonymous access, read for bucket: %s", d.Id())
+
+	bucketPolicy, err := client.GetBucketPolicy(ctx, d.Id())
+	if err != nil {
+		return NewResourceError("failed to load bucket policy", d.Id(), err)
+	}
+
+	rules, err := decompileAnonymousAccessPolicy(d.Id(), bucketPolicy)
+	if err != nil {
+		return NewResourceError("failed to decompile bucket policy", d.Id(), err)
+	}
+
+	// A prefix without access has no statement, it is kept when no broader statement grants one
+	for _, rule := range anonymousAccessRules(d.Get("rule").(*schema.Set)) {
+		if _, ok := rules[rule["prefix"]]; !ok && rule["access"] == "none" {
+			rules[rule["prefix"]] = "none"
+		}
+	}
+
+	ruleList := []map[string]interface{}{}
+	for prefix, access := range rules {
+		ruleList = append(ruleList, map[string]interface{}{"prefix": prefix, "access": access})
+	}
+
+	_ = d.Set("bucket", d.Id())
+	if err := d.Set("rule", ruleList); err != nil {
+		return NewResourceError("failed to read anonymous access rules", d.Id(), err)
+	}
+	_ = d.Set("policy", bucketPolicy)
+
+	return nil
+}
+
+func minioDeleteBucketAnonymousAccess(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*S3MinioClient).S3Client
+
+	log.Printf("[DEBUG] S3 bucket: %s, delete anonymous access policy", d.Id())
+	if err := client.SetBucketPolicy(ctx, d.Id(), ""); err != nil {
+		return NewResourceError("error deleting bucket anonymous access", d.Id(), err)
+	}
+
+	return nil
+}
+
+func minioValidateBucketAnonymousAccessPublicWrite(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	m, ok := meta.(*S3MinioClient)
+	if !ok || !m.StrictBucketPolicies || d.Get("allow_public_write").(bool) {
+		return nil
+	}
+
+	for _, rule := range anonymousAccessRules(d.Get("rule").(*schema.Set)) {
+		if rule["access"] == "upload" || rule["access"] == "public" {
+			return fmt.Errorf("the %s access of prefix %q of bucket %s lets everyone write objects, set allow_public_write to confirm it", rule["access"], rule["prefix"], d.Get("bucket").(string))
+		}
+	}
+	return nil
+}
+
+func anonymousAccessRules(set *schema.Set) []map[string]string {
+	rules := []map[string]string{}
+	for _, item := range set.List() {
+		rule := item.(map[string]interface{})
+		rules = append(rules, map[string]string{
+			"prefix": rule["prefix"].(string),
+			"access": rule["access"].(string),
+		})
+	}
+	return rules
+}
+
+// compileAnonymousAccessRules returns the bucket policy granting the anonymous access of the rules, as mc anonymous
+// set would, or an empty policy when no access is granted
+func compileAnonymousAccessRules(bucket string, rules []map[string]string) (string, error) {
+	// The statements of a prefix are set after those of its parents, which they narrow
+	sort.Slice(rules, func(i, j int) bool {
+		return len(rules[i]["prefix"]) < len(rules[j]["prefix"]) || len(rules[i]["prefix"]) == len(rules[j]["prefix"]) && rules[i]["prefix"] < rules[j]["prefix"]
+	})
+
+	statements := []policy.Statement{}
+	for _, rule := range rules {
+		bucketPolicy, ok := anonymousAccessPolicies[rule["access"]]
+		if !ok {
+			return "", fmt.Errorf("unsupported access %q for prefix %q", rule["access"], rule["prefix"])
+		}
+		statements = policy.SetPolicy(statements, bucketPolicy, bucket, rule["prefix"])
+	}
+	if len(statements) == 0 {
+		return "", nil
+	}
+
+	content, err := json.Marshal(policy.BucketAccessPolicy{Version: "2012-10-17", Statements: statements})
+	if err != nil {
+		return "", err
+	}
+	return string(content), nil
+}
+
+// decompileAnonymousAccessPolicy returns the access of each prefix a bucket policy grants, as mc anonymous list
+// would, the statements outside of the canned policies being ignored
+func decompileAnonymousAccessPolicy(bucket string, bucketPolicy string) (map[string]string, error) {
+	rules := map[string]string{}
+	if strings.TrimSpace(bucketPolicy) == "" {
+		return rules, nil
+	}
+
+	var accessPolicy policy.BucketAccessPolicy
+	if err := json.Unmarshal([]byte(bucketPolicy), &accessPolicy); err != nil {
+		return nil, fmt.Errorf("unable to parse the bucket policy: %w", err)
+	}
+
+	for resource, bucketPolicy := range policy.GetPolicies(accessPolicy.Statements, bucket, "") {
+		if bucketPolicy == policy.BucketPolicyNone {
+			continue
+		}
+		prefix := strings.TrimSuffix(strings.TrimPrefix(resource, bucket+"/"), "*")
+		for access, p := range anonymousAccessPolicies {
+			if p == bucketPolicy {
+				rules[prefix] = access
+			}
+		}
+	}
+	return rules, nil
+}
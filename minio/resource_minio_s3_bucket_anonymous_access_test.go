@@ -0,0 +1,102 @@
+package minio
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccMinioS3BucketAnonymousAccess_basic(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "minio_s3_bucket_anonymous_access.bucket"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckMinioS3BucketDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMinioS3BucketAnonymousAccessConfig(name, "download"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "rule.#", "3"),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "rule.*", map[string]string{"prefix": "", "access": "download"}),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "rule.*", map[string]string{"prefix": "private/", "access": "none"}),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "rule.*", map[string]string{"prefix": "uploads/", "access": "upload"}),
+					resource.TestCheckResourceAttrSet(resourceName, "policy"),
+				),
+			},
+			{
+				Config: testAccMinioS3BucketAnonymousAccessConfig(name, "public"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "rule.*", map[string]string{"prefix": "", "access": "public"}),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"allow_public_write", "rule"},
+			},
+		},
+	})
+}
+
+func testAccMinioS3BucketAnonymousAccessConfig(bucketName string, access string) string {
+	return fmt.Sprintf(`
+resource "minio_s3_bucket" "bucket" {
+  bucket = "%s"
+}
+
+resource "minio_s3_bucket_anonymous_access" "bucket" {
+  bucket             = minio_s3_bucket.bucket.bucket
+  allow_public_write = true
+
+  rule {
+    access = "%s"
+  }
+
+  rule {
+    prefix = "private/"
+    access = "none"
+  }
+
+  rule {
+    prefix = "uploads/"
+    access = "upload"
+  }
+}
+`, bucketName, access)
+}
+
+func TestAnonymousAccessPolicyRoundTrip(t *testing.T) {
+	rules := []map[string]string{
+		{"prefix": "uploads/", "access": "upload"},
+		{"prefix": "", "access": "download"},
+		{"prefix": "shared/", "access": "public"},
+	}
+
+	bucketPolicy, err := compileAnonymousAccessRules("bucket", rules)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decompiled, err := decompileAnonymousAccessPolicy("bucket", bucketPolicy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]string{"": "download", "uploads/": "upload", "shared/": "public"}
+	if !reflect.DeepEqual(decompiled, expected) {
+		t.Errorf("expected %v, got %v", expected, decompiled)
+	}
+
+	if bucketPolicy, err := compileAnonymousAccessRules("bucket", []map[string]string{{"prefix": "", "access": "none"}}); err != nil || bucketPolicy != "" {
+		t.Errorf("expected no policy without access, got %q (%v)", bucketPolicy, err)
+	}
+
+	if decompiled, err := decompileAnonymousAccessPolicy("bucket", ""); err != nil || len(decompiled) != 0 {
+		t.Errorf("expected no rule without policy, got %v (%v)", decompiled, err)
+	}
+}
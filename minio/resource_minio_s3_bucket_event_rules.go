@@ -0,0 +1,409 @@
+package minio
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/minio/madmin-go"
+	"github.com/minio/minio-go/v7/pkg/notification"
+)
+
+func resourceMinioBucketEventRules() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Sends the events of a bucket to a webhook, configuring the webhook notification target on the server and subscribing the bucket to it. The server is restarted when it needs to be to register the target.",
+		CreateContext: minioPutBucketEventRules,
+		ReadContext:   minioReadBucketEventRules,
+		UpdateContext: minioPutBucketEventRules,
+		DeleteContext: minioDeleteBucketEventRules,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"bucket": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateMinioBucketName,
+			},
+			"webhook": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MaxItems:    1,
+				Description: "Webhook notification target receiving the events",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ForceNew:     true,
+							ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[A-Za-z0-9_-]+$`), "must only contain alphanumeric characters, hyphens and underscores"),
+							Description:  "Identifier of the target in the server configuration, which must not be used by another target",
+						},
+						"endpoint": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+							Description:  "URL the events are posted to",
+						},
+						"auth_token": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Sensitive:   true,
+							Description: "Token sent by the server in the Authorization header of the webhook calls",
+						},
+						"queue_dir": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Directory of the servers where the events are kept while the endpoint is unreachable",
+						},
+					},
+				},
+			},
+			"restart_server": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Restart the server when it requires it to register or remove the target. When false and a restart is required, the apply fails before subscribing the bucket",
+			},
+			"rule": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Description: "Events of the bucket sent to the webhook",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+						},
+						"events": {
+							Type:     schema.TypeSet,
+							Required: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+							Set:      schema.HashString,
+						},
+						"filter_prefix": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"filter_suffix": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+			"arn": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ARN of the webhook target, as registered by the server",
+			},
+		},
+	}
+}
+
+func minioPutBucketEventRules(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	m := meta.(*S3MinioClient)
+	bucket := d.Get("bucket").(string)
+	name := d.Get("webhook.0.name").(string)
+
+	timeout := d.Timeout(schema.TimeoutUpdate)
+	if d.IsNewResource() {
+		timeout = d.Timeout(schema.TimeoutCreate)
+	}
+
+	if d.IsNewResource() || d.HasChange("webhook") {
+		cfgData := bucketEventRulesWebhookConfig(
+			name,
+			d.Get("webhook.0.endpoint").(string),
+			d.Get("webhook.0.auth_token").(string),
+			d.Get("webhook.0.queue_dir").(string),
+		)
+
+		log.Printf("[DEBUG] Setting webhook notification target %s to %s", name, d.Get("webhook.0.endpoint").(string))
+		restart, err := m.S3Admin.SetConfigKV(ctx, cfgData)
+		if err != nil {
+			return NewResourceError("unable to set webhook notification target", name, err)
+		}
+
+		d.SetId(bucketEventRulesID(bucket, name))
+
+		if restart {
+			if !d.Get("restart_server").(bool) {
+				return NewResourceError("unable to subscribe bucket to webhook notification target", name,
+					fmt.Errorf("the target was saved, the server must be restarted to register it"))
+			}
+			log.Printf("[DEBUG] Restarting the server to register webhook notification target %s", name)
+			if err := restartMinioServer(ctx, m.S3Admin, timeout); err != nil {
+				return NewResourceError("unable to register webhook notification target", name, err)
+			}
+		}
+	}
+
+	arn, err := waitForBucketEventRulesWebhookARN(ctx, m.S3Admin, name, timeout)
+	if err != nil {
+		return NewResourceError("unable to find webhook notification target", name, err)
+	}
+
+	config, err := m.S3Client.GetBucketNotification(ctx, bucket)
+	if err != nil {
+		return NewResourceError("unable to read bucket notification configuration", bucket, err)
+	}
+
+	// The subscriptions of the bucket to other targets are kept
+	config.QueueConfigs = removeBucketEventRulesQueueConfigs(config.QueueConfigs, arn.String(), d.Get("arn").(string))
+	for _, c := range getNotificationTargetConfigs(d, "rule", "", "tf-s3-event-") {
+		c.Arn = arn
+		config.AddQueue(c)
+	}
+
+	log.Printf("[DEBUG] S3 bucket: %s, subscribing to webhook notification target %s", bucket, arn)
+	if err := m.S3Client.SetBucketNotification(ctx, bucket, config); err != nil {
+		return NewResourceError("unable to subscribe bucket to webhook notification target", bucket, err)
+	}
+
+	d.SetId(bucketEventRulesID(bucket, name))
+	_ = d.Set("arn", arn.String())
+
+	return minioReadBucketEventRules(ctx, d, meta)
+}
+
+func minioReadBucketEventRules(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	m := meta.(*S3MinioClient)
+
+	bucket, name, err := parseBucketEventRulesID(d.Id())
+	if err != nil {
+		return NewResourceError("invalid bucket event rules ID", d.Id(), err)
+	}
+
+	output, err := m.S3Admin.GetConfigKV(ctx, madmin.NotifyWebhookSubSys+":"+name)
+	if err != nil {
+		return NewResourceError("unable to read webhook notification target", name, err)
+	}
+
+	serverConfig, err := madmin.ParseServerConfigOutput(string(output))
+	if err != nil {
+		return NewResourceError("unable to parse webhook notification target", name, err)
+	}
+
+	target, found := bucketEventRulesWebhookTarget(serverConfig, name)
+	if !found {
+		log.Printf("[WARN] Webhook notification target %s not found, removing bucket event rules %s from state", name, d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	// The auth token is not read back, the server does not return secrets
+	target["auth_token"] = d.Get("webhook.0.auth_token").(string)
+
+	// A target waiting for a restart is not registered yet, its rules are read with the last known ARN
+	arn := d.Get("arn").(string)
+	if info, err := m.S3Admin.ServerInfo(ctx); err == nil {
+		if registered, found := bucketEventRulesWebhookARN(info, name); found {
+			arn = registered.String()
+		}
+	}
+
+	config, err := m.S3Client.GetBucketNotification(ctx, bucket)
+	if err != nil {
+		return NewResourceError("unable to read bucket notification configuration", bucket, err)
+	}
+
+	rules := []map[string]interface{}{}
+	for _, queue := range config.QueueConfigs {
+		if arn == "" || queue.Queue != arn {
+			continue
+		}
+		rule := flattenNotificationConfiguration(queue.Config, "queue_arn", queue.Queue)
+		delete(rule, "queue_arn")
+		rules = append(rules, rule)
+	}
+
+	_ = d.Set("bucket", bucket)
+	_ = d.Set("arn", arn)
+	if err := d.Set("webhook", []map[string]interface{}{target}); err != nil {
+		return NewResourceError("unable to load webhook notification target", name, err)
+	}
+	if err := d.Set("rule", rules); err != nil {
+		return NewResourceError("unable to load bucket event rules", bucket, err)
+	}
+
+	return nil
+}
+
+func minioDeleteBucketEventRules(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	m := meta.(*S3MinioClient)
+
+	bucket, name, err := parseBucketEventRulesID(d.Id())
+	if err != nil {
+		return NewResourceError("invalid bucket event rules ID", d.Id(), err)
+	}
+
+	// The bucket is unsubscribed first, the server refusing to remove a target in use
+	if arn := d.Get("arn").(string); arn != "" {
+		config, err := m.S3Client.GetBucketNotification(ctx, bucket)
+		if err != nil {
+			return NewResourceError("unable to read bucket notification configuration", bucket, err)
+		}
+		config.QueueConfigs = removeBucketEventRulesQueueConfigs(config.QueueConfigs, arn)
+
+		log.Printf("[DEBUG] S3 bucket: %s, unsubscribing from webhook notification target %s", bucket, arn)
+		if err := m.S3Client.SetBucketNotification(ctx, bucket, config); err != nil {
+			return NewResourceError("unable to unsubscribe bucket from webhook notification target", bucket, err)
+		}
+	}
+
+	log.Printf("[DEBUG] Removing webhook notification target %s", name)
+	restart, err := m.S3Admin.DelConfigKV(ctx, madmin.NotifyWebhookSubSys+":"+name)
+	if err != nil {
+		return NewResourceError("unable to remove webhook notification target", name, err)
+	}
+
+	if !restart {
+		return nil
+	}
+	if !d.Get("restart_server").(bool) {
+		return diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  "MinIO restart required",
+			Detail:   fmt.Sprintf("The webhook notification target %s was removed, the server must be restarted to apply it", name),
+		}}
+	}
+
+	log.Printf("[DEBUG] Restarting the server to remove webhook notification target %s", name)
+	if err := restartMinioServer(ctx, m.S3Admin, d.Timeout(schema.TimeoutDelete)); err != nil {
+		return NewResourceError("unable to remove webhook notification target", name, err)
+	}
+
+	return nil
+}
+
+func bucketEventRulesID(bucket string, name string) string {
+	return bucket + "/" + name
+}
+
+func parseBucketEventRulesID(id string) (string, string, error) {
+	bucket, name, found := strings.Cut(id, "/")
+	if !found || bucket == "" || name == "" {
+		return "", "", fmt.Errorf("expected <bucket>/<webhook name>, got %q", id)
+	}
+	return bucket, name, nil
+}
+
+// bucketEventRulesWebhookConfig returns the configuration of a webhook notification target to set on the server
+func bucketEventRulesWebhookConfig(name string, endpoint string, authToken string, queueDir string) string {
+	args := []string{
+		fmt.Sprintf("%s:%s", madmin.NotifyWebhookSubSys, name),
+		fmt.Sprintf("%s=%s", madmin.EnableKey, madmin.EnableOn),
+		fmt.Sprintf("endpoint=%q", endpoint),
+	}
+	if authToken != "" {
+		args = append(args, fmt.Sprintf("auth_token=%q", authToken))
+	}
+	if queueDir != "" {
+		args = append(args, fmt.Sprintf("queue_dir=%q", queueDir))
+	}
+	return strings.Join(args, " ")
+}
+
+// bucketEventRulesWebhookTarget returns the webhook block of an enabled webhook notification target
+func bucketEventRulesWebhookTarget(config []madmin.SubsysConfig, name string) (map[string]interface{}, bool) {
+	for _, subsys := range config {
+		if subsys.SubSystem != madmin.NotifyWebhookSubSys || subsys.Target != name {
+			continue
+		}
+		if enable, ok := subsys.Lookup(madmin.EnableKey); ok && enable != madmin.EnableOn {
+			return nil, false
+		}
+		endpoint, ok := subsys.Lookup("endpoint")
+		if !ok || endpoint == "" {
+			return nil, false
+		}
+		queueDir, _ := subsys.Lookup("queue_dir")
+		return map[string]interface{}{
+			"name":      name,
+			"endpoint":  endpoint,
+			"queue_dir": queueDir,
+		}, true
+	}
+	return nil, false
+}
+
+// bucketEventRulesWebhookARN returns the ARN the servers registered the webhook notification target with, which holds
+// the region of the server
+func bucketEventRulesWebhookARN(info madmin.InfoMessage, name string) (notification.Arn, bool) {
+	for _, s := range info.SQSARN {
+		arn, err := notification.NewArnFromString(s)
+		if err != nil {
+			continue
+		}
+		if arn.AccountID == name && arn.Resource == "webhook" {
+			return arn, true
+		}
+	}
+	return notification.Arn{}, false
+}
+
+// waitForBucketEventRulesWebhookARN waits for the servers to register the webhook notification target, which they do
+// after the configuration is applied, and returns its ARN
+func waitForBucketEventRulesWebhookARN(ctx context.Context, admin *madmin.AdminClient, name string, timeout time.Duration) (notification.Arn, error) {
+	var arn notification.Arn
+	err := retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		info, err := admin.ServerInfo(ctx)
+		if err != nil {
+			return retry.RetryableError(err)
+		}
+		registered, found := bucketEventRulesWebhookARN(info, name)
+		if !found {
+			return retry.RetryableError(fmt.Errorf("the servers did not register the target %s", name))
+		}
+		arn = registered
+		return nil
+	})
+	return arn, err
+}
+
+// removeBucketEventRulesQueueConfigs returns the queue configurations not sending the events to one of the arns
+func removeBucketEventRulesQueueConfigs(configs []notification.QueueConfig, arns ...string) []notification.QueueConfig {
+	kept := make([]notification.QueueConfig, 0, len(configs))
+	for _, config := range configs {
+		removed := false
+		for _, arn := range arns {
+			if arn != "" && config.Queue == arn {
+				removed = true
+			}
+		}
+		if !removed {
+			kept = append(kept, config)
+		}
+	}
+	return kept
+}
+
+// restartMinioServer restarts the servers to apply a configuration change and waits for them to answer again
+func restartMinioServer(ctx context.Context, admin *madmin.AdminClient, timeout time.Duration) error {
+	if err := admin.ServiceRestart(ctx); err != nil {
+		return fmt.Errorf("unable to restart the server: %w", err)
+	}
+	return retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		if _, err := admin.ServerInfo(ctx); err != nil {
+			return retry.RetryableError(err)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,178 @@
+package minio
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/minio/madmin-go"
+	"github.com/minio/minio-go/v7/pkg/notification"
+)
+
+func TestAccS3BucketEventRules_basic(t *testing.T) {
+	bucketName := acctest.RandomWithPrefix("tf-acc-test")
+	webhookName := acctest.RandomWithPrefix("tf-acc-webhook")
+
+	config := func(suffix string) string {
+		return fmt.Sprintf(`
+resource "minio_s3_bucket" "bucket" {
+  bucket = %q
+}
+
+resource "minio_s3_bucket_event_rules" "events" {
+  bucket = minio_s3_bucket.bucket.bucket
+
+  webhook {
+    name      = %q
+    endpoint  = "https://webhook.example.com"
+    queue_dir = "/tmp/%s"
+  }
+
+  rule {
+    events        = ["s3:ObjectCreated:*"]
+    filter_suffix = %q
+  }
+
+  rule {
+    events = ["s3:ObjectRemoved:Delete"]
+  }
+}
+`, bucketName, webhookName, webhookName, suffix)
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckMinioS3BucketEventRulesDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config(".png"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("minio_s3_bucket_event_rules.events", "arn", fmt.Sprintf("arn:minio:sqs::%s:webhook", webhookName)),
+					resource.TestCheckResourceAttr("minio_s3_bucket_event_rules.events", "rule.#", "2"),
+					resource.TestCheckResourceAttr("minio_s3_bucket_event_rules.events", "rule.0.filter_suffix", ".png"),
+					resource.TestCheckResourceAttrSet("minio_s3_bucket_event_rules.events", "rule.0.id"),
+				),
+			},
+			{
+				Config: config(".jpg"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("minio_s3_bucket_event_rules.events", "rule.#", "2"),
+					resource.TestCheckResourceAttr("minio_s3_bucket_event_rules.events", "rule.0.filter_suffix", ".jpg"),
+				),
+			},
+			{
+				ResourceName:      "minio_s3_bucket_event_rules.events",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"restart_server",
+				},
+			},
+		},
+	})
+}
+
+func testAccCheckMinioS3BucketEventRulesDestroy(s *terraform.State) error {
+	minioC := testAccProvider.Meta().(*S3MinioClient).S3Admin
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "minio_s3_bucket_event_rules" {
+			continue
+		}
+
+		_, name, err := parseBucketEventRulesID(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		output, err := minioC.GetConfigKV(context.Background(), madmin.NotifyWebhookSubSys+":"+name)
+		if err != nil {
+			continue
+		}
+		config, err := madmin.ParseServerConfigOutput(string(output))
+		if err != nil {
+			return err
+		}
+		if _, found := bucketEventRulesWebhookTarget(config, name); found {
+			return fmt.Errorf("webhook notification target %s still exists", name)
+		}
+	}
+
+	return nil
+}
+
+func TestBucketEventRulesWebhookConfig(t *testing.T) {
+	expected := `notify_webhook:events enable=on endpoint="http://receiver:8080/events"`
+	if config := bucketEventRulesWebhookConfig("events", "http://receiver:8080/events", "", ""); config != expected {
+		t.Errorf("expected %s, got %s", expected, config)
+	}
+
+	expected += ` auth_token="secret token" queue_dir="/data/events"`
+	if config := bucketEventRulesWebhookConfig("events", "http://receiver:8080/events", "secret token", "/data/events"); config != expected {
+		t.Errorf("expected %s, got %s", expected, config)
+	}
+}
+
+func TestBucketEventRulesWebhookTarget(t *testing.T) {
+	config, err := madmin.ParseServerConfigOutput(`notify_webhook:events enable=on endpoint=http://receiver:8080/events auth_token= queue_dir=/data/events
+notify_webhook:disabled enable=off endpoint=http://disabled:8080/`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	target, found := bucketEventRulesWebhookTarget(config, "events")
+	if !found || target["endpoint"] != "http://receiver:8080/events" || target["queue_dir"] != "/data/events" {
+		t.Errorf("expected the target events, got %v (%t)", target, found)
+	}
+	if _, found := bucketEventRulesWebhookTarget(config, "disabled"); found {
+		t.Error("expected a disabled target not to be found")
+	}
+	if _, found := bucketEventRulesWebhookTarget(config, "missing"); found {
+		t.Error("expected a missing target not to be found")
+	}
+}
+
+func TestBucketEventRulesWebhookARN(t *testing.T) {
+	info := madmin.InfoMessage{SQSARN: []string{
+		"arn:minio:sqs:us-east-1:events:amqp",
+		"arn:minio:sqs:us-east-1:events-archive:webhook",
+		"arn:minio:sqs:us-east-1:events:webhook",
+	}}
+
+	arn, found := bucketEventRulesWebhookARN(info, "events")
+	if !found || arn.String() != "arn:minio:sqs:us-east-1:events:webhook" {
+		t.Errorf("expected the webhook target arn, got %s (%t)", arn, found)
+	}
+	if _, found := bucketEventRulesWebhookARN(info, "missing"); found {
+		t.Error("expected an unregistered target not to be found")
+	}
+}
+
+func TestParseBucketEventRulesID(t *testing.T) {
+	bucket, name, err := parseBucketEventRulesID(bucketEventRulesID("source-bucket", "events"))
+	if err != nil || bucket != "source-bucket" || name != "events" {
+		t.Errorf("got (%q, %q, %v), want (%q, %q)", bucket, name, err, "source-bucket", "events")
+	}
+
+	for _, id := range []string{"", "source-bucket", "source-bucket/", "/events"} {
+		if _, _, err := parseBucketEventRulesID(id); err == nil {
+			t.Errorf("%q: expected an error", id)
+		}
+	}
+}
+
+func TestRemoveBucketEventRulesQueueConfigs(t *testing.T) {
+	configs := []notification.QueueConfig{
+		{Queue: "arn:minio:sqs::events:webhook"},
+		{Queue: "arn:minio:sqs::audit:webhook"},
+		{Queue: "arn:minio:sqs:us-east-1:events:webhook"},
+	}
+
+	kept := removeBucketEventRulesQueueConfigs(configs, "arn:minio:sqs:us-east-1:events:webhook", "arn:minio:sqs::events:webhook", "")
+	if len(kept) != 1 || kept[0].Queue != "arn:minio:sqs::audit:webhook" {
+		t.Errorf("expected the other targets to be kept, got %v", kept)
+	}
+}
@@ -0,0 +1,175 @@
+package minio
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/minio/madmin-go"
+	"golang.org/x/exp/slices"
+)
+
+func resourceMinioS3BucketMetadataImport() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: minioPutBucketMetadataImport,
+		ReadContext:   minioReadBucketMetadataImport,
+		UpdateContext: minioPutBucketMetadataImport,
+		DeleteContext: minioDeleteBucketMetadataImport,
+
+		Schema: map[string]*schema.Schema{
+			"bucket": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateMinioBucketName,
+				Description:      "Bucket whose metadata is imported, the archive must hold it",
+			},
+			"content_base64": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateBucketMetadataArchive,
+				Description:  "Base64 encoded zip archive of the bucket configurations, as exported by the minio_s3_bucket_metadata_export data source or `mc admin cluster bucket export`. A change imports it again",
+			},
+			"imported": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Configurations the server set from the archive, such as policy, lifecycle or notification",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func minioPutBucketMetadataImport(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	admin := meta.(*S3MinioClient).S3Admin
+	bucket := d.Get("bucket").(string)
+
+	// Validated by the schema
+	content, _ := base64.StdEncoding.DecodeString(d.Get("content_base64").(string))
+
+	buckets, err := bucketMetadataArchiveBuckets(content)
+	if err != nil {
+		return NewResourceError("unable to read bucket metadata archive", bucket, err)
+	}
+	if !slices.Contains(buckets, bucket) {
+		return NewResourceError("unable to import bucket metadata", bucket, fmt.Errorf("the archive only holds the metadata of %s", strings.Join(buckets, ", ")))
+	}
+
+	log.Printf("[DEBUG] Importing metadata of bucket %s", bucket)
+	result, err := admin.ImportBucketMetadata(ctx, bucket, io.NopCloser(bytes.NewReader(content)))
+	if err != nil {
+		return NewResourceError("unable to import bucket metadata", bucket, err)
+	}
+
+	imported, failures := bucketMetadataImportStatus(result.Buckets[bucket])
+	if len(failures) != 0 {
+		return NewResourceError("unable to import bucket metadata", bucket, fmt.Errorf("%s", strings.Join(failures, "; ")))
+	}
+
+	d.SetId(bucket)
+	_ = d.Set("imported", imported)
+
+	return minioReadBucketMetadataImport(ctx, d, meta)
+}
+
+func minioReadBucketMetadataImport(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*S3MinioClient).S3Client
+
+	// The imported configurations are managed by the other resources once in place, only the bucket is checked
+	found, err := client.BucketExists(ctx, d.Id())
+	if err != nil {
+		return NewResourceError("unable to check bucket", d.Id(), err)
+	}
+	if !found {
+		log.Printf("[WARN] Bucket %s not found, removing its metadata import from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	_ = d.Set("bucket", d.Id())
+
+	return nil
+}
+
+func minioDeleteBucketMetadataImport(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// The imported configurations are left on the bucket, there is nothing to go back to
+	log.Printf("[DEBUG] Removing metadata import of bucket %s from state, the bucket configurations are unchanged", d.Id())
+	d.SetId("")
+	return nil
+}
+
+// bucketMetadataArchiveBuckets returns the sorted buckets an exported archive holds the metadata of, its files
+// being named after their bucket, e.g. mybucket/policy.json
+func bucketMetadataArchiveBuckets(content []byte) ([]string, error) {
+	archive, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return nil, err
+	}
+
+	found := map[string]bool{}
+	for _, file := range archive.File {
+		bucket, _, ok := strings.Cut(file.Name, "/")
+		if !ok || bucket == "" {
+			return nil, fmt.Errorf("unexpected file %q, the files must be named after their bucket", file.Name)
+		}
+		found[bucket] = true
+	}
+
+	buckets := make([]string, 0, len(found))
+	for bucket := range found {
+		buckets = append(buckets, bucket)
+	}
+	sort.Strings(buckets)
+	return buckets, nil
+}
+
+// bucketMetadataImportStatus returns the configurations the server set when importing the metadata of a bucket,
+// and those it failed to set
+func bucketMetadataImportStatus(status madmin.BucketStatus) (imported []string, failures []string) {
+	imported = []string{}
+	if status.Err != "" {
+		failures = append(failures, status.Err)
+	}
+
+	configs := []struct {
+		name   string
+		status madmin.MetaStatus
+	}{
+		{"lifecycle", status.Lifecycle},
+		{"notification", status.Notification},
+		{"object_lock", status.ObjectLock},
+		{"policy", status.Policy},
+		{"quota", status.Quota},
+		{"sse", status.SSEConfig},
+		{"tagging", status.Tagging},
+		{"versioning", status.Versioning},
+	}
+	for _, config := range configs {
+		if config.status.Err != "" {
+			failures = append(failures, fmt.Sprintf("%s: %s", config.name, config.status.Err))
+		} else if config.status.IsSet {
+			imported = append(imported, config.name)
+		}
+	}
+	return imported, failures
+}
+
+func validateBucketMetadataArchive(v interface{}, k string) (ws []string, errors []error) {
+	content, err := base64.StdEncoding.DecodeString(v.(string))
+	if err != nil {
+		errors = append(errors, fmt.Errorf("%q: invalid base64 content: %w", k, err))
+		return
+	}
+	if _, err := bucketMetadataArchiveBuckets(content); err != nil {
+		errors = append(errors, fmt.Errorf("%q: invalid bucket metadata archive: %w", k, err))
+	}
+	return
+}
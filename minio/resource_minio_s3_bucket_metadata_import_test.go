@@ -0,0 +1,107 @@
+package minio
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/minio/madmin-go"
+)
+
+func TestAccMinioS3BucketMetadataImport_basic(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "minio_s3_bucket_metadata_import.bucket"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckMinioS3BucketDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMinioS3BucketMetadataImportConfig(name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.minio_s3_bucket_metadata_export.bucket", "content_base64"),
+					resource.TestCheckResourceAttrSet("data.minio_s3_bucket_metadata_export.bucket", "content_sha256"),
+					resource.TestCheckResourceAttr(resourceName, "bucket", name),
+					resource.TestCheckTypeSetElemAttr(resourceName, "imported.*", "policy"),
+				),
+			},
+		},
+	})
+}
+
+func testAccMinioS3BucketMetadataImportConfig(name string) string {
+	return fmt.Sprintf(`
+resource "minio_s3_bucket" "bucket" {
+  bucket = %[1]q
+}
+
+resource "minio_s3_bucket_anonymous_access" "bucket" {
+  bucket = minio_s3_bucket.bucket.bucket
+
+  rule {
+    access = "download"
+  }
+}
+
+data "minio_s3_bucket_metadata_export" "bucket" {
+  bucket = minio_s3_bucket_anonymous_access.bucket.bucket
+}
+
+resource "minio_s3_bucket_metadata_import" "bucket" {
+  bucket         = minio_s3_bucket.bucket.bucket
+  content_base64 = data.minio_s3_bucket_metadata_export.bucket.content_base64
+}
+`, name)
+}
+
+func TestBucketMetadataArchiveBuckets(t *testing.T) {
+	archive := func(names ...string) []byte {
+		var buf bytes.Buffer
+		w := zip.NewWriter(&buf)
+		for _, name := range names {
+			if _, err := w.Create(name); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+		return buf.Bytes()
+	}
+
+	buckets, err := bucketMetadataArchiveBuckets(archive("b/policy.json", "a/lifecycle.xml", "b/quota.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := []string{"a", "b"}; !reflect.DeepEqual(buckets, expected) {
+		t.Errorf("expected %v, got %v", expected, buckets)
+	}
+
+	if _, err := bucketMetadataArchiveBuckets(archive("policy.json")); err == nil {
+		t.Error("expected an error for a file outside of a bucket")
+	}
+
+	if _, err := bucketMetadataArchiveBuckets([]byte("not a zip")); err == nil {
+		t.Error("expected an error for an invalid archive")
+	}
+}
+
+func TestBucketMetadataImportStatus(t *testing.T) {
+	imported, failures := bucketMetadataImportStatus(madmin.BucketStatus{
+		Policy:       madmin.MetaStatus{IsSet: true},
+		Lifecycle:    madmin.MetaStatus{IsSet: true},
+		Notification: madmin.MetaStatus{Err: "invalid ARN"},
+	})
+
+	if expected := []string{"lifecycle", "policy"}; !reflect.DeepEqual(imported, expected) {
+		t.Errorf("expected %v imported, got %v", expected, imported)
+	}
+	if expected := []string{"notification: invalid ARN"}; !reflect.DeepEqual(failures, expected) {
+		t.Errorf("expected %v failures, got %v", expected, failures)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/minio/minio-go/v7"
 	"github.com/minio/minio-go/v7/pkg/notification"
 )
 
@@ -23,41 +24,54 @@ func resourceMinioBucketNotification() *schema.Resource {
 		},
 		Schema: map[string]*schema.Schema{
 			"bucket": {
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateMinioBucketName,
 			},
 			"queue": {
 				Type:     schema.TypeList,
 				Optional: true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"id": {
-							Type:     schema.TypeString,
-							Optional: true,
-							Computed: true,
-						},
-						"filter_prefix": {
-							Type:     schema.TypeString,
-							Optional: true,
-						},
-						"filter_suffix": {
-							Type:     schema.TypeString,
-							Optional: true,
-						},
-						"queue_arn": {
-							Type:             schema.TypeString,
-							Required:         true,
-							ValidateDiagFunc: validateMinioArn,
-						},
-						"events": {
-							Type:     schema.TypeSet,
-							Required: true,
-							Elem:     &schema.Schema{Type: schema.TypeString},
-							Set:      schema.HashString,
-						},
-					},
-				},
+				Elem:     bucketNotificationTargetResource("queue_arn", ""),
+			},
+			"lambda_function": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Function targets, for the servers exposing compute extensions as Lambda-compatible functions",
+				Elem:        bucketNotificationTargetResource("lambda_function_arn", "ARN of the function target, e.g. arn:minio:lambda::primary:webhook"),
+			},
+		},
+	}
+}
+
+// bucketNotificationTargetResource returns the schema of a notification target block, identified by its arnKey
+func bucketNotificationTargetResource(arnKey string, arnDescription string) *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"filter_prefix": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"filter_suffix": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			arnKey: {
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      arnDescription,
+				ValidateDiagFunc: validateMinioArn,
+			},
+			"events": {
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
 			},
 		},
 	}
@@ -75,6 +89,9 @@ func minioPutBucketNotification(ctx context.Context, d *schema.ResourceData, met
 	)
 
 	if err != nil {
+		if minio.ToErrorResponse(err).Code == "UnsupportedNotification" && len(bucketNotificationConfig.Configuration.LambdaConfigs) != 0 {
+			err = fmt.Errorf("the server does not advertise function targets, remove the lambda_function blocks: %w", err)
+		}
 		return NewResourceError("error putting bucket notification configuration: %v", d.Id(), err)
 	}
 
@@ -98,6 +115,9 @@ func minioReadBucketNotification(ctx context.Context, d *schema.ResourceData, me
 	if err := d.Set("queue", flattenQueueNotificationConfiguration(notificationConfig.QueueConfigs)); err != nil {
 		return NewResourceError("failed to load bucket queue notifications", d.Id(), err)
 	}
+	if err := d.Set("lambda_function", flattenLambdaNotificationConfiguration(notificationConfig.LambdaConfigs)); err != nil {
+		return NewResourceError("failed to load bucket function notifications", d.Id(), err)
+	}
 
 	return nil
 }
@@ -140,59 +160,76 @@ func flattenNotificationConfigurationFilter(filter *notification.Filter) map[str
 func flattenQueueNotificationConfiguration(configs []notification.QueueConfig) []map[string]interface{} {
 	queueNotifications := make([]map[string]interface{}, 0, len(configs))
 	for _, notification := range configs {
-		var conf map[string]interface{}
-		if filter := notification.Filter; filter != nil {
-			conf = flattenNotificationConfigurationFilter(filter)
-		} else {
-			conf = map[string]interface{}{}
-		}
-
-		conf["id"] = notification.ID
-		conf["events"] = notification.Events
 		// The Config.Arn value is not set to the queue ARN even though it's
 		// expected in the submission, so we're getting the correct value
 		// from the Queue attribute on the response object
-		conf["queue_arn"] = notification.Queue
-		queueNotifications = append(queueNotifications, conf)
+		queueNotifications = append(queueNotifications, flattenNotificationConfiguration(notification.Config, "queue_arn", notification.Queue))
 	}
 
 	return queueNotifications
 }
 
+func flattenLambdaNotificationConfiguration(configs []notification.LambdaConfig) []map[string]interface{} {
+	lambdaNotifications := make([]map[string]interface{}, 0, len(configs))
+	for _, notification := range configs {
+		lambdaNotifications = append(lambdaNotifications, flattenNotificationConfiguration(notification.Config, "lambda_function_arn", notification.Lambda))
+	}
+
+	return lambdaNotifications
+}
+
+func flattenNotificationConfiguration(config notification.Config, arnKey string, arn string) map[string]interface{} {
+	var conf map[string]interface{}
+	if filter := config.Filter; filter != nil {
+		conf = flattenNotificationConfigurationFilter(filter)
+	} else {
+		conf = map[string]interface{}{}
+	}
+
+	conf["id"] = config.ID
+	conf["events"] = config.Events
+	conf[arnKey] = arn
+	return conf
+}
+
 func getNotificationConfiguration(d *schema.ResourceData) notification.Configuration {
 	var config notification.Configuration
-	queueConfigs := getNotificationQueueConfigs(d)
 
-	for _, c := range queueConfigs {
+	for _, c := range getNotificationTargetConfigs(d, "queue", "queue_arn", "tf-s3-queue-") {
 		config.AddQueue(c)
 	}
+	for _, c := range getNotificationTargetConfigs(d, "lambda_function", "lambda_function_arn", "tf-s3-lambda-") {
+		config.AddLambda(c)
+	}
 
 	return config
 }
 
-func getNotificationQueueConfigs(d *schema.ResourceData) []notification.Config {
-	queueFunctionNotifications := d.Get("queue").([]interface{})
-	configs := make([]notification.Config, 0, len(queueFunctionNotifications))
+// getNotificationTargetConfigs returns the configurations of the notification target blocks, generating the
+// missing IDs with idPrefix
+func getNotificationTargetConfigs(d *schema.ResourceData, block string, arnKey string, idPrefix string) []notification.Config {
+	targetNotifications := d.Get(block).([]interface{})
+	configs := make([]notification.Config, 0, len(targetNotifications))
 
-	for i, c := range queueFunctionNotifications {
+	for i, c := range targetNotifications {
 		config := notification.Config{Filter: &notification.Filter{}}
 		c := c.(map[string]interface{})
 
-		if queueArnStr, ok := c["queue_arn"].(string); ok {
-			queueArn, err := notification.NewArnFromString(queueArnStr)
+		if arnStr, ok := c[arnKey].(string); ok {
+			arn, err := notification.NewArnFromString(arnStr)
 			if err != nil {
 				continue
 			}
-			config.Arn = queueArn
+			config.Arn = arn
 		}
 
 		if val, ok := c["id"].(string); ok && val != "" {
 			config.ID = val
 		} else {
-			config.ID = resource.PrefixedUniqueId("tf-s3-queue-")
+			config.ID = resource.PrefixedUniqueId(idPrefix)
 		}
 
-		events := d.Get(fmt.Sprintf("queue.%d.events", i)).(*schema.Set).List()
+		events := d.Get(fmt.Sprintf("%s.%d.events", block, i)).(*schema.Set).List()
 		for _, e := range events {
 			config.AddEvents(notification.EventType(e.(string)))
 		}
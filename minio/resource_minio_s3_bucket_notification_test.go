@@ -3,10 +3,12 @@ package minio
 import (
 	"context"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/minio/minio-go/v7/pkg/notification"
 )
@@ -128,3 +130,31 @@ func testAccCheckBucketHasNotification(n string, config notification.Configurati
 func notificationConfigsEqual(a notification.Config, b notification.Config) bool {
 	return a.ID == b.ID && notification.EqualEventTypeList(a.Events, b.Events) && notification.EqualFilterRuleList(a.Filter.S3Key.FilterRules, b.Filter.S3Key.FilterRules)
 }
+
+func TestGetNotificationConfigurationLambda(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceMinioBucketNotification().Schema, map[string]interface{}{
+		"bucket": "bucket",
+		"lambda_function": []interface{}{
+			map[string]interface{}{
+				"lambda_function_arn": "arn:minio:lambda::primary:webhook",
+				"events":              []interface{}{"s3:ObjectCreated:*"},
+				"filter_suffix":       ".png",
+			},
+		},
+	})
+
+	config := getNotificationConfiguration(d)
+	if len(config.QueueConfigs) != 0 || len(config.LambdaConfigs) != 1 {
+		t.Fatalf("expected a single function target, got %+v", config)
+	}
+
+	lambda := config.LambdaConfigs[0]
+	if lambda.Lambda != "arn:minio:lambda::primary:webhook" || !strings.HasPrefix(lambda.ID, "tf-s3-lambda-") {
+		t.Errorf("unexpected function target %+v", lambda)
+	}
+
+	flattened := flattenLambdaNotificationConfiguration(config.LambdaConfigs)
+	if len(flattened) != 1 || flattened[0]["lambda_function_arn"] != lambda.Lambda || flattened[0]["filter_suffix"] != ".png" {
+		t.Errorf("unexpected flattened function targets %v", flattened)
+	}
+}
@@ -0,0 +1,480 @@
+package minio
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/minio/madmin-go"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/replication"
+	"github.com/rs/xid"
+)
+
+func resourceMinioBucketPair() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: minioCreateBucketPair,
+		ReadContext:   minioReadBucketPair,
+		UpdateContext: minioUpdateBucketPair,
+		DeleteContext: minioDeleteBucketPair,
+		Importer: &schema.ResourceImporter{
+			StateContext: minioImportBucketPair,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"bucket": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the bucket created on both clusters",
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					if err := validateS3BucketName(v.(string)); err != nil {
+						errors = append(errors, err)
+					}
+					return
+				},
+			},
+			"peer_cluster": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+				Description:  "Name of the provider cluster holding the second bucket",
+			},
+			"force_destroy": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Delete the buckets with all their objects and versions on destroy",
+			},
+			"replication": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Replicate the buckets to each other",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"primary": bucketPairConnectionSchema("Connection used by the peer cluster to replicate into the primary bucket"),
+						"peer":    bucketPairConnectionSchema("Connection used by the primary cluster to replicate into the peer bucket"),
+						"delete_replication": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  true,
+						},
+						"delete_marker_replication": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  true,
+						},
+						"existing_object_replication": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  true,
+						},
+						"metadata_sync": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  true,
+						},
+						"primary_rule_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"primary_arn": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"peer_rule_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"peer_arn": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func bucketPairConnectionSchema(description string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Required:    true,
+		MaxItems:    1,
+		Description: description,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"host": {
+					Type:             schema.TypeString,
+					Optional:         true,
+					Description:      "Host and port the other cluster uses to reach this one (default: the provider endpoint of the cluster)",
+					ValidateDiagFunc: validateReplicationTargetHost,
+				},
+				"secure": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Description: "Whether the other cluster connects with TLS (default: as the scheme of the provider endpoint of the cluster)",
+				},
+				"access_key": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+				"secret_key": {
+					Type:         schema.TypeString,
+					Required:     true,
+					Sensitive:    true,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+			},
+		},
+	}
+}
+
+func minioCreateBucketPair(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	bucket := d.Get("bucket").(string)
+
+	primary, peer, err := minioBucketPairClients(d, meta)
+	if err != nil {
+		return NewResourceError("unable to create bucket pair", bucket, err)
+	}
+
+	for _, client := range []*S3MinioClient{primary, peer} {
+		endpoint := client.S3Client.EndpointURL().Host
+		log.Printf("[DEBUG] Creating bucket %s on %s", bucket, endpoint)
+
+		if exists, err := client.S3Client.BucketExists(ctx, bucket); err != nil {
+			return NewResourceError("unable to check bucket", bucket, err)
+		} else if exists {
+			return NewResourceError("bucket already exists!", bucket, fmt.Errorf("on %s", endpoint))
+		}
+
+		if err := client.S3Client.MakeBucket(ctx, bucket, minio.MakeBucketOptions{Region: client.S3Region}); err != nil {
+			return NewResourceError("unable to create bucket", bucket, err)
+		}
+
+		// The first bucket exists from now on: a failure in the next steps taints the
+		// resource so the partially created pair gets cleaned up.
+		d.SetId(bucket)
+
+		if err := ensureBucketVersioning(ctx, client.S3Client, bucket); err != nil {
+			return NewResourceError("unable to enable versioning on bucket", bucket, fmt.Errorf("on %s: %w", endpoint, err))
+		}
+	}
+
+	if err := minioSetBucketPairReplication(ctx, d, primary, peer); err != nil {
+		return NewResourceError("unable to configure bucket pair replication", bucket, err)
+	}
+
+	return minioReadBucketPair(ctx, d, meta)
+}
+
+func minioReadBucketPair(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	bucket := d.Id()
+
+	primary, peer, err := minioBucketPairClients(d, meta)
+	if err != nil {
+		return NewResourceError("unable to read bucket pair", bucket, err)
+	}
+
+	for _, client := range []*S3MinioClient{primary, peer} {
+		exists, err := client.S3Client.BucketExists(ctx, bucket)
+		if err != nil {
+			return NewResourceError("unable to check bucket", bucket, err)
+		}
+		if !exists {
+			log.Printf("[WARN] Bucket %s not found on %s, removing bucket pair from state", bucket, client.S3Client.EndpointURL().Host)
+			d.SetId("")
+			return nil
+		}
+	}
+
+	_ = d.Set("bucket", bucket)
+
+	if replicationConfig, ok := d.Get("replication").([]interface{}); ok && len(replicationConfig) == 1 && replicationConfig[0] != nil {
+		replicationMap := replicationConfig[0].(map[string]interface{})
+		for _, side := range []struct {
+			client *S3MinioClient
+			ruleID string
+		}{
+			{primary, replicationMap["primary_rule_id"].(string)},
+			{peer, replicationMap["peer_rule_id"].(string)},
+		} {
+			rcfg, err := side.client.S3Client.GetBucketReplication(ctx, bucket)
+			if err != nil {
+				return NewResourceError("unable to read bucket pair replication", bucket, err)
+			}
+
+			found := false
+			for _, rule := range rcfg.Rules {
+				found = found || rule.ID == side.ruleID
+			}
+			if !found {
+				// Dropping the block from the state makes the next plan configure the replication again
+				log.Printf("[WARN] Replication rule %q not found on %s, bucket pair replication must be configured again", side.ruleID, side.client.S3Client.EndpointURL().Host)
+				_ = d.Set("replication", []interface{}{})
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+func minioUpdateBucketPair(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	bucket := d.Id()
+
+	if d.HasChange("replication") {
+		primary, peer, err := minioBucketPairClients(d, meta)
+		if err != nil {
+			return NewResourceError("unable to update bucket pair", bucket, err)
+		}
+
+		if err := minioRemoveBucketPairReplication(ctx, d, primary, peer); err != nil {
+			return NewResourceError("unable to remove bucket pair replication", bucket, err)
+		}
+		if err := minioSetBucketPairReplication(ctx, d, primary, peer); err != nil {
+			return NewResourceError("unable to configure bucket pair replication", bucket, err)
+		}
+	}
+
+	return minioReadBucketPair(ctx, d, meta)
+}
+
+func minioDeleteBucketPair(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	bucket := d.Id()
+
+	primary, peer, err := minioBucketPairClients(d, meta)
+	if err != nil {
+		return NewResourceError("unable to delete bucket pair", bucket, err)
+	}
+
+	if err := minioRemoveBucketPairReplication(ctx, d, primary, peer); err != nil {
+		return NewResourceError("unable to remove bucket pair replication", bucket, err)
+	}
+
+	for _, client := range []*S3MinioClient{primary, peer} {
+		if exists, err := client.S3Client.BucketExists(ctx, bucket); err != nil {
+			return NewResourceError("unable to check bucket", bucket, err)
+		} else if !exists {
+			continue
+		}
+
+		log.Printf("[DEBUG] Deleting bucket %s on %s", bucket, client.S3Client.EndpointURL().Host)
+		if err := client.S3Client.RemoveBucketWithOptions(ctx, bucket, minio.RemoveBucketOptions{ForceDelete: d.Get("force_destroy").(bool)}); err != nil {
+			return NewResourceError("unable to delete bucket", bucket, err)
+		}
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+// minioBucketPairClients returns the clients of the primary and peer clusters
+func minioBucketPairClients(d *schema.ResourceData, meta interface{}) (*S3MinioClient, *S3MinioClient, error) {
+	peer, err := clusterMeta(d.Get("peer_cluster").(string), meta)
+	if err != nil {
+		return nil, nil, err
+	}
+	return meta.(*S3MinioClient), peer.(*S3MinioClient), nil
+}
+
+// minioSetBucketPairReplication replicates each bucket of the pair to the other one
+func minioSetBucketPairReplication(ctx context.Context, d *schema.ResourceData, primary, peer *S3MinioClient) error {
+	replicationConfig, ok := d.Get("replication").([]interface{})
+	if !ok || len(replicationConfig) != 1 || replicationConfig[0] == nil {
+		return nil
+	}
+
+	replicationMap := replicationConfig[0].(map[string]interface{})
+	bucket := d.Get("bucket").(string)
+
+	rule := S3MinioBucketReplicationRule{
+		Enabled:                   true,
+		Priority:                  1,
+		DeleteReplication:         replicationMap["delete_replication"].(bool),
+		DeleteMarkerReplication:   replicationMap["delete_marker_replication"].(bool),
+		ExistingObjectReplication: replicationMap["existing_object_replication"].(bool),
+		ReplicaModifications:      replicationMap["metadata_sync"].(bool),
+	}
+
+	primaryRuleID, primaryArn, err := minioReplicateBucketPairSide(ctx, primary, peer, bucket, replicationMap["peer"].([]interface{}), bucketPairConnectionSecure(d, "peer"), rule)
+	if err != nil {
+		return fmt.Errorf("from %s: %w", primary.S3Client.EndpointURL().Host, err)
+	}
+	replicationMap["primary_rule_id"] = primaryRuleID
+	replicationMap["primary_arn"] = primaryArn
+
+	peerRuleID, peerArn, err := minioReplicateBucketPairSide(ctx, peer, primary, bucket, replicationMap["primary"].([]interface{}), bucketPairConnectionSecure(d, "primary"), rule)
+	if err != nil {
+		return fmt.Errorf("from %s: %w", peer.S3Client.EndpointURL().Host, err)
+	}
+	replicationMap["peer_rule_id"] = peerRuleID
+	replicationMap["peer_arn"] = peerArn
+
+	return d.Set("replication", []interface{}{replicationMap})
+}
+
+// minioReplicateBucketPairSide replicates the bucket of source into the bucket of target, reached with connection.
+// secure is nil when the connection does not set it.
+func minioReplicateBucketPairSide(ctx context.Context, source, target *S3MinioClient, bucket string, connection []interface{}, secure *bool, rule S3MinioBucketReplicationRule) (string, string, error) {
+	connectionMap, _ := connection[0].(map[string]interface{})
+	customHost, _ := connectionMap["host"].(string)
+	host, useTLS := bucketPairTargetEndpoint(target.S3Client.EndpointURL(), customHost, secure)
+
+	arn, err := source.S3Admin.SetRemoteTarget(ctx, bucket, &madmin.BucketTarget{
+		TargetBucket: bucket,
+		Secure:       useTLS,
+		Credentials: &madmin.Credentials{
+			AccessKey: connectionMap["access_key"].(string),
+			SecretKey: connectionMap["secret_key"].(string),
+		},
+		Endpoint: host,
+		Path:     S3PathSyleAuto.String(),
+		API:      "s3v4",
+		Type:     madmin.ReplicationService,
+		Region:   target.S3Region,
+	})
+	remoteTargetCache(source).Invalidate(bucket)
+	if err != nil {
+		return "", "", fmt.Errorf("unable to configure remote target %q: %w", host, err)
+	}
+
+	rule.Id = xid.New().String()
+	opts := bucketReplicationRuleOptions(rule, arn)
+	opts.Op = replication.AddOption
+
+	rcfg := replication.Config{}
+	if err := rcfg.AddRule(opts); err != nil {
+		return "", "", err
+	}
+	err = source.S3Client.SetBucketReplication(ctx, bucket, rcfg)
+	remoteTargetCache(source).Invalidate(bucket)
+	if err != nil {
+		return "", "", err
+	}
+
+	return rule.Id, arn, nil
+}
+
+// minioRemoveBucketPairReplication removes the replication rules and remote targets known to the state
+func minioRemoveBucketPairReplication(ctx context.Context, d *schema.ResourceData, primary, peer *S3MinioClient) error {
+	oldReplication, _ := d.GetChange("replication")
+	replicationConfig, ok := oldReplication.([]interface{})
+	if !ok || len(replicationConfig) != 1 || replicationConfig[0] == nil {
+		return nil
+	}
+
+	replicationMap := replicationConfig[0].(map[string]interface{})
+	bucket := d.Id()
+
+	for _, side := range []struct {
+		client *S3MinioClient
+		arn    string
+	}{
+		{primary, replicationMap["primary_arn"].(string)},
+		{peer, replicationMap["peer_arn"].(string)},
+	} {
+		if exists, err := side.client.S3Client.BucketExists(ctx, bucket); err != nil || !exists {
+			continue
+		}
+
+		err := side.client.S3Client.RemoveBucketReplication(ctx, bucket)
+		remoteTargetCache(side.client).Invalidate(bucket)
+		if err != nil {
+			return err
+		}
+		if side.arn == "" {
+			continue
+		}
+		err = side.client.S3Admin.RemoveRemoteTarget(ctx, bucket, side.arn)
+		remoteTargetCache(side.client).Invalidate(bucket)
+		if err != nil {
+			return fmt.Errorf("unable to remove remote target %q: %w", side.arn, err)
+		}
+	}
+
+	return nil
+}
+
+// bucketPairTargetEndpoint returns the host and TLS setting a cluster uses to reach the other one, defaulting to
+// the provider endpoint of the other cluster, of which secure also follows the scheme when it is not set
+func bucketPairTargetEndpoint(endpoint *url.URL, host string, secure *bool) (string, bool) {
+	useTLS := endpoint.Scheme == "https"
+	if secure != nil {
+		useTLS = *secure
+	}
+	if host == "" {
+		return endpoint.Host, useTLS
+	}
+	return normalizeReplicationTargetHost(host), useTLS
+}
+
+// bucketPairConnectionSecure returns the secure setting of a connection block, nil when it is not set. It is read
+// from the raw configuration, false being the zero value of an unset boolean.
+func bucketPairConnectionSecure(d *schema.ResourceData, side string) *bool {
+	raw := d.GetRawConfig()
+	if raw.IsNull() || !raw.IsKnown() {
+		return nil
+	}
+	for _, attr := range []string{"replication", side} {
+		if raw = raw.GetAttr(attr); raw.IsNull() || !raw.IsKnown() || raw.LengthInt() == 0 {
+			return nil
+		}
+		raw = raw.Index(cty.NumberIntVal(0))
+	}
+	if raw = raw.GetAttr("secure"); raw.IsNull() || !raw.IsKnown() {
+		return nil
+	}
+	secure := raw.True()
+	return &secure
+}
+
+// minioImportBucketPair imports a pair with the bucket:peer_cluster ID. The replication set by the resource, one
+// rule on each bucket, is adopted so applying the configuration replaces it, the credentials being unknown.
+func minioImportBucketPair(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	bucket, peerCluster, found := strings.Cut(d.Id(), ":")
+	if !found || bucket == "" || peerCluster == "" {
+		return nil, fmt.Errorf("unexpected import ID %q, expected bucket:peer_cluster", d.Id())
+	}
+
+	d.SetId(bucket)
+	_ = d.Set("bucket", bucket)
+	_ = d.Set("peer_cluster", peerCluster)
+	_ = d.Set("force_destroy", false)
+
+	primary, peer, err := minioBucketPairClients(d, meta)
+	if err != nil {
+		return nil, err
+	}
+
+	replicationMap := map[string]interface{}{}
+	for _, side := range []struct {
+		name   string
+		client *S3MinioClient
+	}{
+		{"primary", primary},
+		{"peer", peer},
+	} {
+		rcfg, err := side.client.S3Client.GetBucketReplication(ctx, bucket)
+		if err != nil || len(rcfg.Rules) != 1 {
+			log.Printf("[DEBUG] Bucket %s on %s has no pair replication to import", bucket, side.client.S3Client.EndpointURL().Host)
+			return []*schema.ResourceData{d}, nil
+		}
+		replicationMap[side.name+"_rule_id"] = rcfg.Rules[0].ID
+		replicationMap[side.name+"_arn"] = rcfg.Rules[0].Destination.Bucket
+	}
+	_ = d.Set("replication", []interface{}{replicationMap})
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,156 @@
+package minio
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccMinioS3BucketPair_replication(t *testing.T) {
+	bucketName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "minio_s3_bucket_pair.pair"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckMinioS3BucketPairDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMinioS3BucketPairConfig(bucketName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMinioS3BucketPairExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "bucket", bucketName),
+					resource.TestCheckResourceAttrSet(resourceName, "replication.0.primary_rule_id"),
+					resource.TestCheckResourceAttrSet(resourceName, "replication.0.primary_arn"),
+					resource.TestCheckResourceAttrSet(resourceName, "replication.0.peer_rule_id"),
+					resource.TestCheckResourceAttrSet(resourceName, "replication.0.peer_arn"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateId:     bucketName + ":second",
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"force_destroy",
+					"replication",
+				},
+			},
+		},
+	})
+}
+
+func testAccMinioS3BucketPairConfig(bucketName string) string {
+	return fmt.Sprintf(`
+provider "minio" {
+  alias = "pair"
+
+  clusters {
+    name           = "second"
+    minio_server   = %[2]q
+    minio_user     = %[3]q
+    minio_password = %[4]q
+  }
+}
+
+resource "minio_s3_bucket_pair" "pair" {
+  provider      = minio.pair
+  bucket        = %[1]q
+  peer_cluster  = "second"
+  force_destroy = true
+
+  replication {
+    primary {
+      host       = %[5]q
+      access_key = %[6]q
+      secret_key = %[7]q
+    }
+    peer {
+      access_key = %[3]q
+      secret_key = %[4]q
+    }
+  }
+}
+`, bucketName, os.Getenv("SECOND_MINIO_ENDPOINT"), os.Getenv("SECOND_MINIO_USER"), os.Getenv("SECOND_MINIO_PASSWORD"),
+		os.Getenv("MINIO_ENDPOINT"), os.Getenv("MINIO_USER"), os.Getenv("MINIO_PASSWORD"))
+}
+
+func testAccCheckMinioS3BucketPairExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+
+		for i, client := range []*S3MinioClient{testAccProvider.Meta().(*S3MinioClient), testAccSecondProvider.Meta().(*S3MinioClient)} {
+			versioning, err := client.S3Client.GetBucketVersioning(context.Background(), rs.Primary.ID)
+			if err != nil {
+				return fmt.Errorf("error reading the versioning of bucket %d: %s", i, err)
+			}
+			if !versioning.Enabled() {
+				return fmt.Errorf("expected versioning to be enabled on bucket %d, got %q", i, versioning.Status)
+			}
+
+			rcfg, err := client.S3Client.GetBucketReplication(context.Background(), rs.Primary.ID)
+			if err != nil {
+				return fmt.Errorf("error reading the replication of bucket %d: %s", i, err)
+			}
+			if len(rcfg.Rules) != 1 {
+				return fmt.Errorf("expected 1 replication rule on bucket %d, got %d", i, len(rcfg.Rules))
+			}
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckMinioS3BucketPairDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "minio_s3_bucket_pair" {
+			continue
+		}
+
+		for _, client := range []*S3MinioClient{testAccProvider.Meta().(*S3MinioClient), testAccSecondProvider.Meta().(*S3MinioClient)} {
+			exists, err := client.S3Client.BucketExists(context.Background(), rs.Primary.ID)
+			if err != nil {
+				return err
+			}
+			if exists {
+				return fmt.Errorf("bucket %s still exists on %s", rs.Primary.ID, client.S3Client.EndpointURL().Host)
+			}
+		}
+	}
+
+	return nil
+}
+
+func TestBucketPairTargetEndpoint(t *testing.T) {
+	enabled, disabled := true, false
+	httpsEndpoint := &url.URL{Scheme: "https", Host: "minio-b.example.com:9000"}
+
+	for _, tc := range []struct {
+		endpoint *url.URL
+		host     string
+		secure   *bool
+		expected string
+		tls      bool
+	}{
+		{httpsEndpoint, "", nil, "minio-b.example.com:9000", true},
+		{&url.URL{Scheme: "http", Host: "minio-b:9000"}, "", nil, "minio-b:9000", false},
+		// An unset secure follows the scheme of the endpoint, even with a custom host
+		{httpsEndpoint, "minio-b.internal:9000", nil, "minio-b.internal:9000", true},
+		{httpsEndpoint, "minio-b.internal:9000", &disabled, "minio-b.internal:9000", false},
+		{&url.URL{Scheme: "http", Host: "minio-b:9000"}, "minio-b.internal:443", &enabled, "minio-b.internal:443", true},
+	} {
+		host, tls := bucketPairTargetEndpoint(tc.endpoint, tc.host, tc.secure)
+		if host != tc.expected || tls != tc.tls {
+			t.Errorf("%s with host %q: expected (%q, %t), got (%q, %t)", tc.endpoint, tc.host, tc.expected, tc.tls, host, tls)
+		}
+	}
+}
@@ -2,15 +2,58 @@ package minio
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"log"
+	"path"
 	"strings"
 
 	awspolicy "github.com/hashicorp/awspolicyequivalence"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
+	"github.com/minio/minio-go/v7/pkg/set"
 )
 
+// bucketPolicyWriteActions are the S3 actions modifying a bucket or its objects
+var bucketPolicyWriteActions = []string{
+	"s3:AbortMultipartUpload",
+	"s3:BypassGovernanceRetention",
+	"s3:CreateBucket",
+	"s3:DeleteBucket",
+	"s3:DeleteBucketPolicy",
+	"s3:DeleteObject",
+	"s3:DeleteObjectTagging",
+	"s3:DeleteObjectVersion",
+	"s3:DeleteObjectVersionTagging",
+	"s3:PutBucketNotification",
+	"s3:PutBucketObjectLockConfiguration",
+	"s3:PutBucketPolicy",
+	"s3:PutBucketTagging",
+	"s3:PutBucketVersioning",
+	"s3:PutEncryptionConfiguration",
+	"s3:PutLifecycleConfiguration",
+	"s3:PutObject",
+	"s3:PutObjectLegalHold",
+	"s3:PutObjectRetention",
+	"s3:PutObjectTagging",
+	"s3:PutObjectVersionTagging",
+	"s3:PutReplicationConfiguration",
+	"s3:ReplicateDelete",
+	"s3:ReplicateObject",
+	"s3:RestoreObject",
+}
+
+// bucketPolicyStatement holds the statement fields telling who is allowed to do what
+type bucketPolicyStatement struct {
+	Sid          string
+	Effect       string
+	Principal    json.RawMessage
+	NotPrincipal json.RawMessage
+	Action       set.StringSet
+	NotAction    set.StringSet
+}
+
 func resourceMinioBucketPolicy() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: minioPutBucketPolicy,
@@ -20,11 +63,13 @@ func resourceMinioBucketPolicy() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: minioValidateBucketPolicyPublicWrite,
 		Schema: map[string]*schema.Schema{
 			"bucket": {
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateMinioBucketName,
 			},
 			"policy": {
 				Type:             schema.TypeString,
@@ -32,6 +77,12 @@ func resourceMinioBucketPolicy() *schema.Resource {
 				ValidateFunc:     validateIAMPolicyJSON,
 				DiffSuppressFunc: suppressEquivalentAwsPolicyDiffs,
 			},
+			"allow_public_write": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Allow the policy to grant write actions to everyone when the provider strict_bucket_policies option is set",
+			},
 		},
 	}
 }
@@ -131,3 +182,87 @@ func secondJSONUnlessEquivalent(old, new string) (string, error) {
 
 	return new, nil
 }
+
+// minioValidateBucketPolicyPublicWrite fails the plan of a policy granting write actions to everyone, when the
+// provider is in strict mode and the policy does not opt in with allow_public_write
+func minioValidateBucketPolicyPublicWrite(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	m, ok := meta.(*S3MinioClient)
+	if !ok || !m.StrictBucketPolicies || d.Get("allow_public_write").(bool) || !d.NewValueKnown("policy") {
+		return nil
+	}
+
+	statements, err := bucketPolicyPublicWriteStatements(d.Get("policy").(string))
+	if err != nil {
+		return err
+	}
+	if len(statements) != 0 {
+		return fmt.Errorf("the policy of bucket %s grants write actions to everyone in statement %s, set allow_public_write to confirm it", d.Get("bucket").(string), strings.Join(statements, ", "))
+	}
+	return nil
+}
+
+// bucketPolicyPublicWriteStatements returns the Sid, or the index when there is none, of the statements allowing
+// anonymous principals to modify the bucket or its objects. Conditions are not evaluated, a statement restricted
+// to some source IPs is still reported.
+func bucketPolicyPublicWriteStatements(policyJSON string) ([]string, error) {
+	var bucketPolicy struct {
+		Statement []bucketPolicyStatement
+	}
+	if err := json.Unmarshal([]byte(policyJSON), &bucketPolicy); err != nil {
+		return nil, fmt.Errorf("unable to parse the bucket policy: %w", err)
+	}
+
+	statements := []string{}
+	for i, statement := range bucketPolicy.Statement {
+		if !strings.EqualFold(statement.Effect, "Allow") {
+			continue
+		}
+
+		// Everyone but a few principals is allowed
+		public := len(statement.NotPrincipal) != 0 || bucketPolicyPrincipalIsPublic(statement.Principal)
+		if !public {
+			continue
+		}
+
+		// Every action but a few is allowed, some of them are writes
+		write := statement.NotAction != nil
+		for action := range statement.Action {
+			write = write || bucketPolicyActionWrites(action)
+		}
+		if !write {
+			continue
+		}
+
+		name := statement.Sid
+		if name == "" {
+			name = fmt.Sprintf("%d", i)
+		}
+		statements = append(statements, name)
+	}
+	return statements, nil
+}
+
+// bucketPolicyActionWrites tells whether an action, possibly holding wildcards, matches a write action
+func bucketPolicyActionWrites(action string) bool {
+	pattern := strings.ToLower(action)
+	for _, writeAction := range bucketPolicyWriteActions {
+		if matched, err := path.Match(pattern, strings.ToLower(writeAction)); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
+
+// bucketPolicyPrincipalIsPublic tells whether a principal, either "*" or {"AWS": ...}, includes everyone
+func bucketPolicyPrincipalIsPublic(principal json.RawMessage) bool {
+	var name string
+	if err := json.Unmarshal(principal, &name); err == nil {
+		return name == "*"
+	}
+
+	var principals map[string]set.StringSet
+	if err := json.Unmarshal(principal, &principals); err == nil {
+		return principals["AWS"].Contains("*")
+	}
+	return false
+}
@@ -3,6 +3,8 @@ package minio
 import (
 	"context"
 	"fmt"
+	"reflect"
+	"regexp"
 	"testing"
 
 	awspolicy "github.com/hashicorp/awspolicyequivalence"
@@ -148,6 +150,77 @@ func TestAccS3BucketPolicy_order(t *testing.T) {
 	})
 }
 
+func TestAccS3BucketPolicy_strictPublicWrite(t *testing.T) {
+	name := fmt.Sprintf("tf-test-bucket-%d", acctest.RandInt())
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccBucketPolicyConfigStrictPublicWrite(name, false),
+				ExpectError: regexp.MustCompile("grants write actions to everyone in statement PublicUpload"),
+			},
+			{
+				Config: testAccBucketPolicyConfigStrictPublicWrite(name, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("minio_s3_bucket_policy.bucket", "allow_public_write", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestBucketPolicyPublicWriteStatements(t *testing.T) {
+	cases := []struct {
+		name   string
+		policy string
+		want   []string
+	}{
+		{
+			name:   "public read",
+			policy: `{"Statement":[{"Effect":"Allow","Principal":"*","Action":"s3:GetObject","Resource":"arn:aws:s3:::b/*"}]}`,
+			want:   []string{},
+		},
+		{
+			name:   "public write",
+			policy: `{"Statement":[{"Sid":"Upload","Effect":"Allow","Principal":{"AWS":["*"]},"Action":["s3:GetObject","s3:PutObject"],"Resource":"arn:aws:s3:::b/*"}]}`,
+			want:   []string{"Upload"},
+		},
+		{
+			name:   "wildcard action",
+			policy: `{"Statement":[{"Effect":"Allow","Principal":{"AWS":"*"},"Action":"S3:Delete*","Resource":"arn:aws:s3:::b/*"}]}`,
+			want:   []string{"0"},
+		},
+		{
+			name:   "denied write",
+			policy: `{"Statement":[{"Effect":"Deny","Principal":"*","Action":"s3:*","Resource":"arn:aws:s3:::b/*"}]}`,
+			want:   []string{},
+		},
+		{
+			name:   "user write",
+			policy: `{"Statement":[{"Effect":"Allow","Principal":{"AWS":["arn:aws:iam::minio:user/writer"]},"Action":"s3:*","Resource":"arn:aws:s3:::b/*"}]}`,
+			want:   []string{},
+		},
+		{
+			name:   "not action",
+			policy: `{"Statement":[{"Effect":"Allow","Principal":"*","NotAction":"s3:GetObject","Resource":"arn:aws:s3:::b/*"}]}`,
+			want:   []string{"0"},
+		},
+	}
+
+	for _, c := range cases {
+		got, err := bucketPolicyPublicWriteStatements(c.policy)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", c.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%s: got %v, want %v", c.name, got, c.want)
+		}
+	}
+}
+
 func testAccBucketPolicyConfig(bucketName string) string {
 	return fmt.Sprintf(`
 resource "minio_s3_bucket" "bucket" {
@@ -260,3 +333,35 @@ func testAccCheckBucketHasPolicy(n string, expectedPolicyText string) resource.T
 		return nil
 	}
 }
+
+func testAccBucketPolicyConfigStrictPublicWrite(bucketName string, allowPublicWrite bool) string {
+	return fmt.Sprintf(`
+provider "minio" {
+  alias                  = "strict"
+  strict_bucket_policies = true
+}
+
+resource "minio_s3_bucket" "bucket" {
+  provider = minio.strict
+  bucket   = %[1]q
+}
+
+resource "minio_s3_bucket_policy" "bucket" {
+  provider           = minio.strict
+  bucket             = minio_s3_bucket.bucket.bucket
+  allow_public_write = %[2]t
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Sid       = "PublicUpload"
+        Effect    = "Allow"
+        Principal = { AWS = ["*"] }
+        Action    = ["s3:PutObject"]
+        Resource  = ["arn:aws:s3:::%[1]s/*"]
+      }
+    ]
+  })
+}
+`, bucketName, allowPublicWrite)
+}
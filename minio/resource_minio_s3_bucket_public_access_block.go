@@ -0,0 +1,143 @@
+package minio
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/policy"
+)
+
+func resourceMinioBucketPublicAccessBlock() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: minioPutBucketPublicAccessBlock,
+		ReadContext:   minioReadBucketPublicAccessBlock,
+		UpdateContext: minioPutBucketPublicAccessBlock,
+		DeleteContext: minioDeleteBucketPublicAccessBlock,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"bucket": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateMinioBucketName,
+			},
+			"block_public_policy": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Strip statements granting access to anonymous users from the bucket policy. A bucket made public out-of-band shows up as drift",
+			},
+			"public_statements": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Sids of the public statements found in the bucket policy during the last refresh",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func minioPutBucketPublicAccessBlock(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*S3MinioClient).S3Client
+	bucket := d.Get("bucket").(string)
+
+	if d.Get("block_public_policy").(bool) {
+		bucketPolicy, err := getBucketPolicyDocument(ctx, client, bucket)
+		if err != nil {
+			return NewResourceError("failed to load bucket policy", bucket, err)
+		}
+
+		statements := []policy.Statement{}
+		for _, statement := range bucketPolicy.Statements {
+			if isPublicPolicyStatement(statement) {
+				log.Printf("[DEBUG] S3 bucket: %s, removing public statement %q", bucket, statement.Sid)
+				continue
+			}
+			statements = append(statements, statement)
+		}
+
+		if len(statements) != len(bucketPolicy.Statements) {
+			newPolicy := ""
+			if len(statements) != 0 {
+				bucketPolicy.Statements = statements
+				policyJSON, err := json.Marshal(bucketPolicy)
+				if err != nil {
+					return NewResourceError("unable to generate bucket policy", bucket, err)
+				}
+				newPolicy = string(policyJSON)
+			}
+
+			if err := client.SetBucketPolicy(ctx, bucket, newPolicy); err != nil {
+				return NewResourceError("error putting bucket policy", bucket, err)
+			}
+		}
+	}
+
+	d.SetId(bucket)
+
+	return minioReadBucketPublicAccessBlock(ctx, d, meta)
+}
+
+func minioReadBucketPublicAccessBlock(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*S3MinioClient).S3Client
+
+	bucketPolicy, err := getBucketPolicyDocument(ctx, client, d.Id())
+	if err != nil {
+		return NewResourceError("failed to load bucket policy", d.Id(), err)
+	}
+
+	publicStatements := []string{}
+	for _, statement := range bucketPolicy.Statements {
+		if isPublicPolicyStatement(statement) {
+			publicStatements = append(publicStatements, statement.Sid)
+		}
+	}
+
+	if len(publicStatements) != 0 && d.Get("block_public_policy").(bool) {
+		// Reporting the block as disabled makes the next plan restore it
+		log.Printf("[WARN] S3 bucket: %s, found %d public statements in the bucket policy", d.Id(), len(publicStatements))
+		_ = d.Set("block_public_policy", false)
+	}
+
+	_ = d.Set("bucket", d.Id())
+	_ = d.Set("public_statements", publicStatements)
+
+	return nil
+}
+
+func minioDeleteBucketPublicAccessBlock(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// The block is only enforced by Terraform, there is nothing to remove on the server
+	d.SetId("")
+	return nil
+}
+
+func getBucketPolicyDocument(ctx context.Context, client *minio.Client, bucket string) (*BucketPolicy, error) {
+	policyText, err := client.GetBucketPolicy(ctx, bucket)
+	if err != nil {
+		return nil, err
+	}
+
+	bucketPolicy := &BucketPolicy{}
+	if policyText == "" {
+		return bucketPolicy, nil
+	}
+
+	if err := json.Unmarshal([]byte(policyText), bucketPolicy); err != nil {
+		return nil, err
+	}
+	return bucketPolicy, nil
+}
+
+// isPublicPolicyStatement returns true when the statement grants access to anonymous users
+func isPublicPolicyStatement(statement policy.Statement) bool {
+	if statement.Effect != "Allow" {
+		return false
+	}
+	return statement.Principal.AWS.Contains("*") || statement.Principal.CanonicalUser.Contains("*")
+}
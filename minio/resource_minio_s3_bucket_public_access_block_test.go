@@ -0,0 +1,118 @@
+package minio
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/minio/minio-go/v7/pkg/policy"
+	"github.com/minio/minio-go/v7/pkg/set"
+)
+
+func TestAccS3BucketPublicAccessBlock_basic(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "minio_s3_bucket_public_access_block.bucket"
+
+	publicPolicy := fmt.Sprintf(`{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Sid": "Public",
+      "Effect": "Allow",
+      "Principal": {"AWS": ["*"]},
+      "Resource": ["arn:aws:s3:::%[1]s"],
+      "Action": ["s3:ListBucket"]
+    }
+  ]
+}`, name)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckMinioS3BucketDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBucketPublicAccessBlockConfig(name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "block_public_policy", "true"),
+					resource.TestCheckResourceAttr(resourceName, "public_statements.#", "0"),
+				),
+			},
+			{
+				// Make the bucket public out-of-band, the block must strip it again
+				PreConfig: func() {
+					minioC := testAccProvider.Meta().(*S3MinioClient).S3Client
+					if err := minioC.SetBucketPolicy(context.Background(), name, publicPolicy); err != nil {
+						t.Fatalf("error on SetBucketPolicy: %v", err)
+					}
+				},
+				Config: testAccBucketPublicAccessBlockConfig(name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "block_public_policy", "true"),
+					resource.TestCheckResourceAttr(resourceName, "public_statements.#", "0"),
+					testAccCheckBucketHasNoPolicy(resourceName),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestIsPublicPolicyStatement(t *testing.T) {
+	cases := []struct {
+		statement policy.Statement
+		expected  bool
+	}{
+		{policy.Statement{Effect: "Allow", Principal: policy.User{AWS: set.CreateStringSet("*")}}, true},
+		{policy.Statement{Effect: "Allow", Principal: policy.User{CanonicalUser: set.CreateStringSet("*")}}, true},
+		{policy.Statement{Effect: "Deny", Principal: policy.User{AWS: set.CreateStringSet("*")}}, false},
+		{policy.Statement{Effect: "Allow", Principal: policy.User{AWS: set.CreateStringSet("arn:aws:iam::123456789012:root")}}, false},
+		{policy.Statement{Effect: "Allow"}, false},
+	}
+
+	for i, c := range cases {
+		if actual := isPublicPolicyStatement(c.statement); actual != c.expected {
+			t.Errorf("case #%d: expected %t, got %t", i, c.expected, actual)
+		}
+	}
+}
+
+func testAccBucketPublicAccessBlockConfig(bucketName string) string {
+	return fmt.Sprintf(`
+resource "minio_s3_bucket" "bucket" {
+  bucket = %q
+}
+
+resource "minio_s3_bucket_public_access_block" "bucket" {
+  bucket = minio_s3_bucket.bucket.id
+}
+`, bucketName)
+}
+
+func testAccCheckBucketHasNoPolicy(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+
+		minioC := testAccProvider.Meta().(*S3MinioClient).S3Client
+		actualPolicyText, err := minioC.GetBucketPolicy(context.Background(), rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("error on GetBucketPolicy: %v", err)
+		}
+
+		if actualPolicyText != "" {
+			return fmt.Errorf("bucket %s still has a policy: %s", rs.Primary.ID, actualPolicyText)
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,317 @@
+package minio
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/dustin/go-humanize"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/minio/madmin-go"
+)
+
+func resourceMinioS3BucketRemoteTarget() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: minioCreateBucketRemoteTarget,
+		ReadContext:   minioReadBucketRemoteTarget,
+		UpdateContext: minioUpdateBucketRemoteTarget,
+		DeleteContext: minioDeleteBucketRemoteTarget,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"bucket": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateMinioBucketName,
+				Description:      "Source bucket replicating to the target",
+			},
+			"target_bucket": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateMinioBucketName,
+				Description:      "Bucket receiving the replicated objects",
+			},
+			"host": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				Description:      "Host of the target, optionally followed by its port, e.g. minio.example.com:9000 or [2001:db8::1]:9000 for an IPv6 address",
+				ValidateDiagFunc: validateReplicationTargetHost,
+				DiffSuppressFunc: func(k, oldValue, newValue string, d *schema.ResourceData) bool {
+					return normalizeReplicationTargetHost(oldValue) == normalizeReplicationTargetHost(newValue)
+				},
+			},
+			"secure": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+				ForceNew: true,
+			},
+			"path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Folder of the target bucket on the remote server, stored normalized without leading or trailing slashes (\"\", \"/\" and \".\" meaning the root)",
+				DiffSuppressFunc: func(k, oldValue, newValue string, d *schema.ResourceData) bool {
+					return normalizeReplicationTargetPath(oldValue) == normalizeReplicationTargetPath(newValue)
+				},
+				ValidateFunc: validation.StringDoesNotMatch(regexp.MustCompile(`(^|/)\.\.(/|$)`), "must not contain parent directory references"),
+			},
+			"path_style": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "auto",
+				ValidateFunc: validation.StringInSlice([]string{"on", "off", "auto"}, true),
+			},
+			"target_region": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Region of the target bucket",
+			},
+			"access_key": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"secret_key": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Sensitive:    true,
+				ValidateFunc: validation.StringIsNotEmpty,
+				Description:  "Secret key of the target, which cannot be read back: it is set again after an import",
+			},
+			"synchronous": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Replicate the objects before acknowledging their upload",
+			},
+			"health_check_period": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "30s",
+				Description: "Interval of the health checks of the target, stored normalized (90s becoming 1m30s)",
+				StateFunc: func(v interface{}) string {
+					return normalizeReplicationHealthCheckPeriod(v.(string))
+				},
+				DiffSuppressFunc: func(k, oldValue, newValue string, d *schema.ResourceData) bool {
+					return normalizeReplicationHealthCheckPeriod(oldValue) == normalizeReplicationHealthCheckPeriod(newValue)
+				},
+				ValidateFunc: validateReplicationHealthCheckPeriod,
+			},
+			"bandwidth_limit": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "0",
+				Description: "Maximum bandwidth of the replication in bytes per second, e.g. 100M, at least 100MB when set (default: unlimited)",
+				DiffSuppressFunc: func(k, oldValue, newValue string, d *schema.ResourceData) bool {
+					newVal, err := parseBandwidthLimit(newValue)
+					return err == nil && humanize.Bytes(newVal) == oldValue
+				},
+				ValidateFunc: validateRemoteTargetBandwidthLimit,
+			},
+			"arn": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ARN of the target, to set as the remote_target_arn of minio_s3_bucket_replication rules",
+			},
+		},
+	}
+}
+
+func minioCreateBucketRemoteTarget(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	m := meta.(*S3MinioClient)
+	bucket := d.Get("bucket").(string)
+
+	target, err := bucketRemoteTarget(d)
+	if err != nil {
+		return NewResourceError("invalid remote target", bucket, err)
+	}
+
+	log.Printf("[DEBUG] Adding remote target %s/%s to bucket %s", target.Endpoint, target.TargetBucket, bucket)
+	arn, err := m.S3Admin.SetRemoteTarget(ctx, bucket, target)
+	if err != nil {
+		return NewResourceError("unable to add remote target", bucket, err)
+	}
+	remoteTargetCache(m).Invalidate(bucket)
+
+	d.SetId(bucketRemoteTargetID(bucket, arn))
+
+	return minioReadBucketRemoteTarget(ctx, d, meta)
+}
+
+func minioReadBucketRemoteTarget(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	bucket, arn, err := parseBucketRemoteTargetID(d.Id())
+	if err != nil {
+		return NewResourceError("invalid remote target ID", d.Id(), err)
+	}
+
+	targets, err := remoteTargetCache(meta.(*S3MinioClient)).ListRemoteTargets(ctx, bucket)
+	if err != nil {
+		return NewResourceError("unable to list remote targets", bucket, err)
+	}
+
+	var target *madmin.BucketTarget
+	for i := range targets {
+		if targets[i].Arn == arn {
+			target = &targets[i]
+		}
+	}
+	if target == nil {
+		log.Printf("[WARN] Remote target %s of bucket %s not found, removing it from state", arn, bucket)
+		d.SetId("")
+		return nil
+	}
+
+	targetPath, targetBucket := splitReplicationTargetBucket(target.TargetBucket)
+
+	// The secret key cannot be read back, the configured one is kept
+	_ = d.Set("bucket", bucket)
+	_ = d.Set("arn", arn)
+	_ = d.Set("target_bucket", targetBucket)
+	_ = d.Set("path", targetPath)
+	_ = d.Set("host", target.Endpoint)
+	_ = d.Set("secure", target.Secure)
+	_ = d.Set("path_style", target.Path)
+	_ = d.Set("target_region", target.Region)
+	_ = d.Set("synchronous", target.ReplicationSync)
+	_ = d.Set("health_check_period", shortDur(target.HealthCheckDuration))
+	_ = d.Set("bandwidth_limit", humanize.Bytes(uint64(target.BandwidthLimit)))
+	if target.Credentials != nil {
+		_ = d.Set("access_key", target.Credentials.AccessKey)
+	}
+
+	return nil
+}
+
+func minioUpdateBucketRemoteTarget(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	m := meta.(*S3MinioClient)
+	bucket, arn, err := parseBucketRemoteTargetID(d.Id())
+	if err != nil {
+		return NewResourceError("invalid remote target ID", d.Id(), err)
+	}
+
+	target, err := bucketRemoteTarget(d)
+	if err != nil {
+		return NewResourceError("invalid remote target", bucket, err)
+	}
+	target.Arn = arn
+
+	// The other settings replace the target, the replication rules using it keep working while it is edited
+	ops := []madmin.TargetUpdateType{}
+	if d.HasChanges("access_key", "secret_key") {
+		ops = append(ops, madmin.CredentialsUpdateType)
+	}
+	if d.HasChange("synchronous") {
+		ops = append(ops, madmin.SyncUpdateType)
+	}
+	if d.HasChange("bandwidth_limit") {
+		ops = append(ops, madmin.BandwidthLimitUpdateType)
+	}
+	if d.HasChange("health_check_period") {
+		ops = append(ops, madmin.HealthCheckDurationUpdateType)
+	}
+	if d.HasChange("path_style") {
+		ops = append(ops, madmin.PathUpdateType)
+	}
+
+	if len(ops) != 0 {
+		log.Printf("[DEBUG] Updating remote target %s of bucket %s", arn, bucket)
+		if _, err := m.S3Admin.UpdateRemoteTarget(ctx, target, ops...); err != nil {
+			return NewResourceError("unable to update remote target", bucket, err)
+		}
+		remoteTargetCache(m).Invalidate(bucket)
+	}
+
+	return minioReadBucketRemoteTarget(ctx, d, meta)
+}
+
+func minioDeleteBucketRemoteTarget(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	m := meta.(*S3MinioClient)
+	bucket, arn, err := parseBucketRemoteTargetID(d.Id())
+	if err != nil {
+		return NewResourceError("invalid remote target ID", d.Id(), err)
+	}
+
+	log.Printf("[DEBUG] Removing remote target %s of bucket %s", arn, bucket)
+	if err := m.S3Admin.RemoveRemoteTarget(ctx, bucket, arn); err != nil {
+		return NewResourceError("unable to remove remote target", bucket, err)
+	}
+	remoteTargetCache(m).Invalidate(bucket)
+
+	return nil
+}
+
+// bucketRemoteTarget returns the remote target configured by the resource
+func bucketRemoteTarget(d *schema.ResourceData) (*madmin.BucketTarget, error) {
+	bandwidthLimit, err := parseBandwidthLimit(d.Get("bandwidth_limit").(string))
+	if err != nil {
+		return nil, err
+	}
+	healthCheckPeriod, err := parseReplicationHealthCheckPeriod(d.Get("health_check_period").(string))
+	if err != nil {
+		return nil, err
+	}
+
+	return &madmin.BucketTarget{
+		SourceBucket: d.Get("bucket").(string),
+		TargetBucket: replicationTargetBucketPath(d.Get("path").(string), d.Get("target_bucket").(string)),
+		Endpoint:     normalizeReplicationTargetHost(d.Get("host").(string)),
+		Secure:       d.Get("secure").(bool),
+		Credentials: &madmin.Credentials{
+			AccessKey: d.Get("access_key").(string),
+			SecretKey: d.Get("secret_key").(string),
+		},
+		Path:                strings.ToLower(d.Get("path_style").(string)),
+		API:                 "s3v4",
+		Type:                madmin.ReplicationService,
+		Region:              d.Get("target_region").(string),
+		BandwidthLimit:      int64(bandwidthLimit),
+		ReplicationSync:     d.Get("synchronous").(bool),
+		HealthCheckDuration: healthCheckPeriod,
+	}, nil
+}
+
+// remoteTargetCache returns the cache the replication resources read the remote targets from, so it is
+// invalidated when they change
+func remoteTargetCache(m *S3MinioClient) *replicationCache {
+	if m.ReplicationCache == nil {
+		return newReplicationCache(m.S3Client, m.S3Admin, 0)
+	}
+	return m.ReplicationCache
+}
+
+// bucketRemoteTargetID returns the ID of a remote target, its bucket and ARN separated by a slash which bucket
+// names cannot contain
+func bucketRemoteTargetID(bucket string, arn string) string {
+	return bucket + "/" + arn
+}
+
+func parseBucketRemoteTargetID(id string) (bucket string, arn string, err error) {
+	bucket, arn, found := strings.Cut(id, "/")
+	if !found || bucket == "" || arn == "" {
+		return "", "", fmt.Errorf("expected an ID of the form bucket/arn, got %q", id)
+	}
+	return bucket, arn, nil
+}
+
+func validateRemoteTargetBandwidthLimit(v interface{}, k string) (ws []string, errors []error) {
+	value, err := parseBandwidthLimit(v.(string))
+	if err != nil {
+		errors = append(errors, fmt.Errorf("%q: %w", k, err))
+		return
+	}
+	if value != 0 && value < uint64(100*humanize.BigMByte.Int64()) {
+		errors = append(errors, fmt.Errorf("%q must be at least 100MB when set, got %s", k, humanize.Bytes(value)))
+	}
+	return
+}
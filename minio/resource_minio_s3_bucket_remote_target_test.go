@@ -0,0 +1,250 @@
+package minio
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccS3BucketRemoteTarget_sharedByRules(t *testing.T) {
+	bucketName := acctest.RandomWithPrefix("tf-acc-test-a")
+	secondBucketName := acctest.RandomWithPrefix("tf-acc-test-b")
+	username := acctest.RandomWithPrefix("tf-acc-usr")
+
+	primaryMinioEndpoint := os.Getenv("MINIO_ENDPOINT")
+	secondaryMinioEndpoint := os.Getenv("SECOND_MINIO_ENDPOINT")
+
+	config := func(bandwidthLimit string) string {
+		return testAccBucketReplicationConfigLocals(primaryMinioEndpoint, secondaryMinioEndpoint) +
+			testAccBucketReplicationConfigBucket("my_bucket_in_a", "minio", bucketName) +
+			testAccBucketReplicationConfigBucket("my_bucket_in_b", "secondminio", secondBucketName) +
+			testAccBucketReplicationConfigPolicy(bucketName, secondBucketName) +
+			testAccBucketReplicationConfigServiceAccount(username, 2) +
+			fmt.Sprintf(`
+resource "minio_s3_bucket_remote_target" "target_in_b" {
+  bucket          = minio_s3_bucket.my_bucket_in_a.bucket
+  target_bucket   = minio_s3_bucket.my_bucket_in_b.bucket
+  host            = local.second_minio_host
+  secure          = false
+  bandwidth_limit = %q
+  access_key      = minio_iam_service_account.replication_in_b.access_key
+  secret_key      = minio_iam_service_account.replication_in_b.secret_key
+
+  depends_on = [
+    minio_s3_bucket_versioning.my_bucket_in_a,
+    minio_s3_bucket_versioning.my_bucket_in_b
+  ]
+}
+
+resource "minio_s3_bucket_replication" "replication_in_b" {
+  bucket = minio_s3_bucket.my_bucket_in_a.bucket
+
+  rule {
+    priority          = 1
+    prefix            = "reports/"
+    remote_target_arn = minio_s3_bucket_remote_target.target_in_b.arn
+  }
+
+  rule {
+    priority          = 2
+    prefix            = "invoices/"
+    remote_target_arn = minio_s3_bucket_remote_target.target_in_b.arn
+  }
+}
+`, bandwidthLimit)
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckMinioS3BucketRemoteTargetDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config("100M"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("minio_s3_bucket_remote_target.target_in_b", "arn"),
+					resource.TestCheckResourceAttr("minio_s3_bucket_remote_target.target_in_b", "bandwidth_limit", "100 MB"),
+					resource.TestCheckResourceAttrPair(
+						"minio_s3_bucket_replication.replication_in_b", "rule.0.arn",
+						"minio_s3_bucket_remote_target.target_in_b", "arn",
+					),
+					resource.TestCheckResourceAttrPair(
+						"minio_s3_bucket_replication.replication_in_b", "rule.1.arn",
+						"minio_s3_bucket_remote_target.target_in_b", "arn",
+					),
+					resource.TestCheckResourceAttr("minio_s3_bucket_replication.replication_in_b", "rule.0.target.#", "0"),
+				),
+			},
+			{
+				// Edited in place, the rules keep referencing the target
+				Config: config("200M"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("minio_s3_bucket_remote_target.target_in_b", "bandwidth_limit", "200 MB"),
+					resource.TestCheckResourceAttrPair(
+						"minio_s3_bucket_replication.replication_in_b", "rule.0.arn",
+						"minio_s3_bucket_remote_target.target_in_b", "arn",
+					),
+				),
+			},
+			{
+				ResourceName:      "minio_s3_bucket_remote_target.target_in_b",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"secret_key",
+				},
+			},
+		},
+	})
+}
+
+func TestAccS3BucketRemoteTarget_unreferencedWithPurge(t *testing.T) {
+	bucketName := acctest.RandomWithPrefix("tf-acc-test-a")
+	secondBucketName := acctest.RandomWithPrefix("tf-acc-test-b")
+	thirdBucketName := acctest.RandomWithPrefix("tf-acc-test-c")
+
+	primaryMinioEndpoint := os.Getenv("MINIO_ENDPOINT")
+	secondaryMinioEndpoint := os.Getenv("SECOND_MINIO_ENDPOINT")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckMinioS3BucketRemoteTargetDestroy,
+		Steps: []resource.TestStep{
+			{
+				// No rule references the standalone target, the replication resource must neither purge nor report it
+				Config: testAccBucketReplicationConfigLocals(primaryMinioEndpoint, secondaryMinioEndpoint) +
+					testAccBucketReplicationConfigBucket("my_bucket_in_a", "minio", bucketName) +
+					testAccBucketReplicationConfigBucket("my_bucket_in_b", "secondminio", secondBucketName) +
+					testAccBucketReplicationConfigBucket("my_bucket_in_c", "secondminio", thirdBucketName) +
+					fmt.Sprintf(`
+resource "minio_s3_bucket_remote_target" "target_in_b" {
+  bucket        = minio_s3_bucket.my_bucket_in_a.bucket
+  target_bucket = minio_s3_bucket.my_bucket_in_b.bucket
+  host          = local.second_minio_host
+  secure        = false
+  access_key    = %[1]q
+  secret_key    = %[2]q
+
+  depends_on = [
+    minio_s3_bucket_versioning.my_bucket_in_a,
+    minio_s3_bucket_versioning.my_bucket_in_b
+  ]
+}
+
+resource "minio_s3_bucket_replication" "replication_in_c" {
+  bucket               = minio_s3_bucket.my_bucket_in_a.bucket
+  purge_orphan_targets = true
+
+  rule {
+    target {
+      bucket     = minio_s3_bucket.my_bucket_in_c.bucket
+      host       = local.second_minio_host
+      secure     = false
+      access_key = %[1]q
+      secret_key = %[2]q
+    }
+  }
+
+  depends_on = [
+    minio_s3_bucket_remote_target.target_in_b,
+    minio_s3_bucket_versioning.my_bucket_in_c
+  ]
+}
+`, os.Getenv("SECOND_MINIO_USER"), os.Getenv("SECOND_MINIO_PASSWORD")),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMinioS3BucketRemoteTargetExists("minio_s3_bucket_remote_target.target_in_b"),
+					resource.TestCheckResourceAttr("minio_s3_bucket_replication.replication_in_c", "created_target_arns.#", "1"),
+					resource.TestCheckResourceAttrPair(
+						"minio_s3_bucket_replication.replication_in_c", "created_target_arns.0",
+						"minio_s3_bucket_replication.replication_in_c", "rule.0.arn",
+					),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckMinioS3BucketRemoteTargetExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+
+		bucket, arn, err := parseBucketRemoteTargetID(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		targets, err := testAccProvider.Meta().(*S3MinioClient).S3Admin.ListRemoteTargets(context.Background(), bucket, "")
+		if err != nil {
+			return err
+		}
+		for _, target := range targets {
+			if target.Arn == arn {
+				return nil
+			}
+		}
+		return fmt.Errorf("remote target %s of bucket %s no longer exists", arn, bucket)
+	}
+}
+
+func testAccCheckMinioS3BucketRemoteTargetDestroy(s *terraform.State) error {
+	minioC := testAccProvider.Meta().(*S3MinioClient).S3Admin
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "minio_s3_bucket_remote_target" {
+			continue
+		}
+
+		bucket, arn, err := parseBucketRemoteTargetID(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		targets, err := minioC.ListRemoteTargets(context.Background(), bucket, "")
+		if err != nil {
+			// The bucket is removed along with its targets
+			continue
+		}
+		for _, target := range targets {
+			if target.Arn == arn {
+				return fmt.Errorf("remote target %s of bucket %s still exists", arn, bucket)
+			}
+		}
+	}
+
+	return nil
+}
+
+func TestParseBucketRemoteTargetID(t *testing.T) {
+	arn := "arn:minio:replication::d0b4e7ae-3e4b-4a35-9f1c-2a7f0c3f5d7e:target-bucket"
+	bucket, parsedArn, err := parseBucketRemoteTargetID(bucketRemoteTargetID("source-bucket", arn))
+	if err != nil || bucket != "source-bucket" || parsedArn != arn {
+		t.Errorf("got (%q, %q, %v), want (%q, %q)", bucket, parsedArn, err, "source-bucket", arn)
+	}
+
+	for _, id := range []string{"", "source-bucket", "source-bucket/", "/" + arn} {
+		if _, _, err := parseBucketRemoteTargetID(id); err == nil {
+			t.Errorf("%q: expected an error", id)
+		}
+	}
+}
+
+func TestValidateRemoteTargetBandwidthLimit(t *testing.T) {
+	for value, valid := range map[string]bool{
+		"0":    true,
+		"100M": true,
+		"1G":   true,
+		"10M":  false,
+		"fast": false,
+	} {
+		if _, errs := validateRemoteTargetBandwidthLimit(value, "bandwidth_limit"); (len(errs) == 0) != valid {
+			t.Errorf("%q: expected valid to be %t, got %v", value, valid, errs)
+		}
+	}
+}
@@ -2,12 +2,21 @@ package minio
 
 import (
 	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"math"
+	"net"
+	"net/http"
+	"net/url"
 	"path"
 	"reflect"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -15,34 +24,141 @@ import (
 	"github.com/dustin/go-humanize"
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/minio/madmin-go"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
 	"github.com/minio/minio-go/v7/pkg/replication"
 	"github.com/minio/minio-go/v7/pkg/s3utils"
 	"github.com/rs/xid"
 	"golang.org/x/exp/slices"
 )
 
+const (
+	replicationTargetTypeMinIO = "minio"
+	replicationTargetTypeS3    = "s3"
+)
+
+const (
+	replicationPriorityExplicit      = "explicit"
+	replicationPriorityAutoIncrement = "auto-increment"
+	replicationPriorityIndex         = "index"
+)
+
 func resourceMinioBucketReplication() *schema.Resource {
-	return &schema.Resource{
+	r := &schema.Resource{
 		CreateContext: minioPutBucketReplication,
 		ReadContext:   minioReadBucketReplication,
 		UpdateContext: minioPutBucketReplication,
 		DeleteContext: minioDeleteBucketReplication,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: minioImportBucketReplication,
 		},
+		CustomizeDiff: customdiff.All(
+			minioValidateBucketReplicationPriorities,
+			minioValidateBucketReplicationCredentials,
+			minioValidateBucketReplicationFeatures,
+			minioRenderBucketReplicationRules,
+		),
 		Schema: map[string]*schema.Schema{
 			"bucket": {
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateMinioBucketName,
+			},
+			"rendered_rules": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "JSON of the replication rules stored by MinIO, as generated from the rule blocks",
+			},
+			"validate_credentials": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Connect to every target during plan to make sure its credentials can access the target bucket",
+			},
+			"enable_versioning": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Enable versioning on the source bucket before configuring the rules",
+			},
+			"enable_target_versioning": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Enable versioning on every target bucket, using the target credentials, before configuring the rules",
+			},
+			"ignore_unmanaged_rules": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Only manage the rules created by this resource, leaving the other rules of the bucket and their remote targets untouched",
+			},
+			"purge_orphan_targets": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Remove the remote targets created by this resource which no replication rule references, e.g. left behind by a failed apply. They are only reported as a warning otherwise. The other remote targets of the bucket, such as those of a `minio_s3_bucket_remote_target`, are never removed nor reported",
+			},
+			"created_target_arns": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "ARNs of the remote targets created by this resource, including those a failed apply left unreferenced. Only these targets are resumed, purged or reported as orphans by a later apply",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"priority_strategy": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      replicationPriorityIndex,
+				ValidateFunc: validation.StringInSlice([]string{replicationPriorityExplicit, replicationPriorityAutoIncrement, replicationPriorityIndex}, false),
+				Description:  "How omitted rule priorities are assigned: index uses the rule position (starting at 1), auto-increment counts up from the highest explicit priority and explicit requires every rule to set its priority",
+			},
+			"managed_rule_ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "IDs of the rules managed by this resource",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"config_hash": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Hash of the replication rules read from the server. An update fails when the rules no longer match it, as they were changed outside of Terraform since the plan",
+			},
+			"fail_if_offline": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Report on refresh the targets which have been unreachable for too long. The targets are probed from the machine running Terraform, not from the server: the madmin version in use does not expose the health the server sees, so a target only reachable from the network of the server is reported offline",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"threshold": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Duration since the target was first seen offline after which it is reported (e.g. 1h)",
+							ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+								if _, err := time.ParseDuration(v.(string)); err != nil {
+									errors = append(errors, fmt.Errorf("%q must be a valid duration: %v", k, err))
+								}
+								return
+							},
+						},
+						"strict": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Fail the refresh instead of emitting a warning",
+						},
+					},
+				},
 			},
 			"rule": {
 				Type:     schema.TypeList,
 				Optional: true,
-				MaxItems: 10, // Is there a max?
+				MaxItems: 1000, // Same limit as S3, enforced by MinIO when the configuration is set
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"id": {
@@ -66,8 +182,23 @@ func resourceMinioBucketReplication() *schema.Resource {
 								oldVal, _ := strconv.Atoi(oldValue)
 								newVal, _ := strconv.Atoi(newValue)
 
-								log.Printf("[DEBUG] Priority diff: %s(%d) %s(%d) -> %t", oldValue, oldVal, newValue, newVal, oldVal < 0 && newVal == 0 || oldVal == newVal)
-								return oldVal < 0 && newVal == 0 || oldVal == newVal
+								// Omitted priorities are stored with the value assigned by the strategy, or negative in older states
+								suppress := oldVal == newVal || newVal == 0 && oldVal < 0
+								if newVal == 0 && oldVal > 0 {
+									var idx int
+									if _, err := fmt.Sscanf(k, "rule.%d.priority", &idx); err == nil {
+										rules, _ := d.Get("rule").([]interface{})
+										priorities := make([]int, len(rules))
+										for i := range rules {
+											priorities[i], _ = d.Get(fmt.Sprintf("rule.%d.priority", i)).(int)
+										}
+										resolved, err := replicationRulePriorities(priorities, replicationPriorityStrategy(d))
+										suppress = err == nil && idx < len(resolved) && resolved[idx] == oldVal
+									}
+								}
+
+								log.Printf("[DEBUG] Priority diff: %s(%d) %s(%d) -> %t", oldValue, oldVal, newValue, newVal, suppress)
+								return suppress
 							},
 						},
 						"prefix": {
@@ -76,12 +207,13 @@ func resourceMinioBucketReplication() *schema.Resource {
 							Default:  "",
 						},
 						"tags": {
-							Type:     schema.TypeMap,
-							Optional: true,
+							Type:        schema.TypeMap,
+							Optional:    true,
+							Description: "Tags the replicated objects must have, the values may be empty",
 							ValidateDiagFunc: validation.AllDiag(
-								validation.MapValueMatch(regexp.MustCompile(`^[a-zA-Z0-9-+\-._:/@ ]+$`), ""),
-								validation.MapKeyMatch(regexp.MustCompile(`^[a-zA-Z0-9-+\-._:/@ ]+$`), ""),
-								validation.MapValueLenBetween(1, 256),
+								validation.MapValueMatch(regexp.MustCompile(`^[a-zA-Z0-9-+\-._:/@= ]*$`), ""),
+								validation.MapKeyMatch(regexp.MustCompile(`^[a-zA-Z0-9-+\-._:/@= ]+$`), ""),
+								validation.MapValueLenBetween(0, 256),
 								validation.MapKeyLenBetween(1, 128),
 							),
 						},
@@ -90,41 +222,89 @@ func resourceMinioBucketReplication() *schema.Resource {
 							Optional: true,
 						},
 						"delete_marker_replication": {
-							Type:     schema.TypeBool,
-							Optional: true,
+							Type:             schema.TypeBool,
+							Optional:         true,
+							Computed:         true,
+							DiffSuppressFunc: suppressUnsetReplicationRuleAttribute,
+							Description:      "Whether delete markers are replicated. When omitted, the server default applies and is kept in state",
 						},
 						"existing_object_replication": {
 							Type:     schema.TypeBool,
 							Optional: true,
 						},
+						"replica_modifications": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Computed:    true,
+							Description: "Whether the metadata changes made on the replicas, such as tags or retention, are synced back to this bucket. Required by two-way replication",
+						},
 						"metadata_sync": {
-							Type:     schema.TypeBool,
-							Optional: true,
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Computed:    true,
+							Description: "Alias of replica_modifications",
+							Deprecated:  "Use replica_modifications instead, it is the replication status MinIO calls metadata sync",
+						},
+						"remote_target_arn": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "ARN of a remote target managed by a minio_s3_bucket_remote_target, which several rules can share, instead of a target",
 						},
 						"target": {
-							Type:     schema.TypeList,
-							MinItems: 1,
-							MaxItems: 1,
-							Required: true,
+							Type:        schema.TypeList,
+							MinItems:    1,
+							MaxItems:    1,
+							Optional:    true,
+							Description: "Remote target created for the rule, required unless remote_target_arn is set",
 							Elem: &schema.Resource{
 								Schema: map[string]*schema.Schema{
+									"type": {
+										Type:         schema.TypeString,
+										Optional:     true,
+										Default:      replicationTargetTypeMinIO,
+										ValidateFunc: validation.StringInSlice([]string{replicationTargetTypeMinIO, replicationTargetTypeS3}, false),
+										Description:  "Kind of server the target is: minio, or s3 for the other S3 compatible services (AWS, Wasabi...), which do not support replica_modifications and existing_object_replication",
+									},
 									"bucket": {
-										Type:     schema.TypeString,
-										Required: true,
+										Type:             schema.TypeString,
+										Required:         true,
+										ValidateDiagFunc: validateMinioBucketName,
 									},
 									"storage_class": {
 										Type:     schema.TypeString,
 										Optional: true,
 									},
 									"host": {
-										Type:     schema.TypeString,
-										Required: true,
+										Type:             schema.TypeString,
+										Required:         true,
+										Description:      "Host of the target, optionally followed by its port, e.g. minio.example.com:9000 or [2001:db8::1]:9000 for an IPv6 address",
+										ValidateDiagFunc: validateReplicationTargetHost,
+										DiffSuppressFunc: func(k, oldValue, newValue string, d *schema.ResourceData) bool {
+											return normalizeReplicationTargetHost(oldValue) == normalizeReplicationTargetHost(newValue)
+										},
 									},
 									"secure": {
 										Type:     schema.TypeBool,
 										Optional: true,
 										Default:  true,
 									},
+									"probe_insecure_skip_verify": {
+										Type:        schema.TypeBool,
+										Optional:    true,
+										Default:     false,
+										Description: "Skip the verification of the target certificate in the connections of the provider to the target only (validate_credentials, enable_target_versioning, fail_if_offline and the object lock check). It is not sent to the server, which replicates to the target trusting the certificates of its certs/CAs directory",
+									},
+									"probe_cacert_pem": {
+										Type:        schema.TypeString,
+										Optional:    true,
+										Description: "PEM encoded CA certificate verifying the target certificate in the connections of the provider to the target only (validate_credentials, enable_target_versioning, fail_if_offline and the object lock check). It is not sent to the server, which replicates to the target trusting the certificates of its certs/CAs directory",
+										ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+											if value := v.(string); value != "" && !isValidCertificate([]byte(value)) {
+												errors = append(errors, fmt.Errorf("%q must be a PEM encoded x509 certificate", k))
+											}
+											return
+										},
+									},
 									"path_style": {
 										Type:         schema.TypeString,
 										Optional:     true,
@@ -132,8 +312,13 @@ func resourceMinioBucketReplication() *schema.Resource {
 										ValidateFunc: validation.StringInSlice([]string{"on", "off", "auto"}, true),
 									},
 									"path": {
-										Type:     schema.TypeString,
-										Optional: true,
+										Type:        schema.TypeString,
+										Optional:    true,
+										Description: "Folder of the target bucket on the remote server, stored normalized without leading or trailing slashes (\"\", \"/\" and \".\" meaning the root)",
+										DiffSuppressFunc: func(k, oldValue, newValue string, d *schema.ResourceData) bool {
+											return normalizeReplicationTargetPath(oldValue) == normalizeReplicationTargetPath(newValue)
+										},
+										ValidateFunc: validation.StringDoesNotMatch(regexp.MustCompile(`(^|/)\.\.(/|$)`), "must not contain parent directory references"),
 									},
 									"syncronous": {
 										Type:     schema.TypeBool,
@@ -141,21 +326,24 @@ func resourceMinioBucketReplication() *schema.Resource {
 										Default:  false,
 									},
 									"health_check_period": {
-										Type:     schema.TypeString,
-										Optional: true,
-										Default:  "30s",
+										Type:        schema.TypeString,
+										Optional:    true,
+										Default:     "30s",
+										Description: "Interval of the health checks of the target, stored normalized (90s becoming 1m30s)",
+										StateFunc: func(v interface{}) string {
+											return normalizeReplicationHealthCheckPeriod(v.(string))
+										},
 										DiffSuppressFunc: func(k, oldValue, newValue string, d *schema.ResourceData) bool {
-											newVal, err := time.ParseDuration(newValue)
-											return err == nil && shortDur(newVal) == oldValue
+											return normalizeReplicationHealthCheckPeriod(oldValue) == normalizeReplicationHealthCheckPeriod(newValue)
 										},
-										ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[0-9]+\s?[s|m|h]$`), "must be a valid golang duration"),
+										ValidateFunc: validateReplicationHealthCheckPeriod,
 									},
 									"bandwidth_limt": {
 										Type:     schema.TypeString,
 										Optional: true,
 										Default:  "0",
 										DiffSuppressFunc: func(k, oldValue, newValue string, d *schema.ResourceData) bool {
-											newVal, err := humanize.ParseBytes(newValue)
+											newVal, err := parseBandwidthLimit(newValue)
 											return err == nil && humanize.Bytes(newVal) == oldValue
 										},
 										ValidateDiagFunc: func(i interface{}, _ cty.Path) (diags diag.Diagnostics) {
@@ -172,11 +360,12 @@ func resourceMinioBucketReplication() *schema.Resource {
 												return
 											}
 
-											val, err := humanize.ParseBytes(v)
+											val, err := parseBandwidthLimit(v)
 											if err != nil {
 												diags = append(diags, diag.Diagnostic{
 													Severity: diag.Error,
 													Summary:  "bandwidth_limt must be a positive value. It may use traditional suffixes (k, m, g, ..) ",
+													Detail:   err.Error(),
 												})
 												return
 											}
@@ -199,6 +388,16 @@ func resourceMinioBucketReplication() *schema.Resource {
 										Required:     true,
 										ValidateFunc: validation.StringIsNotEmpty,
 									},
+									"online": {
+										Type:        schema.TypeBool,
+										Computed:    true,
+										Description: "Whether the target was reachable from the machine running Terraform on the last refresh, only checked when fail_if_offline is set",
+									},
+									"offline_since": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "Date (RFC3339) of the refresh which first found the target unreachable from the machine running Terraform, empty while it is reachable. It only changes when the target goes offline or back online",
+									},
 									"secret_key": {
 										Type:         schema.TypeString,
 										Optional:     true, // This is optional to allow import and then prevent credential changes
@@ -213,6 +412,9 @@ func resourceMinioBucketReplication() *schema.Resource {
 			},
 		},
 	}
+
+	withReplicationRuleReorderSuppression(r.Schema["rule"].Elem.(*schema.Resource))
+	return r
 }
 
 func minioPutBucketReplication(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -225,7 +427,53 @@ func minioPutBucketReplication(ctx context.Context, d *schema.ResourceData, meta
 
 	log.Printf("[DEBUG] S3 bucket: %s, put replication configuration: %v", bucketReplicationConfig.MinioBucket, replicationConfig)
 
-	cfg, err := convertBucketReplicationConfig(bucketReplicationConfig, replicationConfig)
+	// Reads done from now on must see the changes below
+	defer bucketReplicationConfig.ReplicationCache.Invalidate(bucketReplicationConfig.MinioBucket)
+
+	// The changes made since the plan, e.g. with mc, must not be silently overwritten
+	if expectedHash, _ := d.GetChange("config_hash"); !d.IsNewResource() && expectedHash.(string) != "" {
+		hash, err := bucketReplicationRulesHash(ctx, bucketReplicationConfig)
+		if err != nil {
+			return NewResourceError("unable to read bucket replication configuration", bucketReplicationConfig.MinioBucket, err)
+		}
+		if hash != expectedHash.(string) {
+			return NewResourceError("replication configuration changed outside Terraform since plan", bucketReplicationConfig.MinioBucket,
+				errors.New("the replication rules of the server no longer match those read during the plan, refresh and plan again to review the changes"))
+		}
+	}
+
+	if d.Get("enable_versioning").(bool) {
+		if err := ensureBucketVersioning(ctx, bucketReplicationConfig.MinioClient, bucketReplicationConfig.MinioBucket); err != nil {
+			return NewResourceError("unable to enable versioning on the source bucket", bucketReplicationConfig.MinioBucket, err)
+		}
+	}
+
+	if d.Get("enable_target_versioning").(bool) {
+		for i, rule := range replicationConfig {
+			if rule.RemoteTargetArn != "" {
+				log.Printf("[DEBUG] rule[%d] uses the remote target %q, skipping the versioning of its target", i, rule.RemoteTargetArn)
+				continue
+			}
+			if rule.Target.SecretKey == "" {
+				return NewResourceError("unable to enable versioning on the target bucket", bucketReplicationConfig.MinioBucket, fmt.Errorf("rule[%d].target.secret_key is required to enable versioning on the target", i))
+			}
+
+			client, err := newReplicationTargetClient(rule.Target)
+			if err != nil {
+				return NewResourceError("unable to enable versioning on the target bucket", rule.Target.Bucket, err)
+			}
+			if err := ensureBucketVersioning(ctx, client, rule.Target.Bucket); err != nil {
+				return NewResourceError("unable to enable versioning on the target bucket", rule.Target.Bucket, fmt.Errorf("rule[%d].target on %q: %w", i, rule.Target.Host, err))
+			}
+		}
+	}
+
+	cfg, orphanARNs, err := convertBucketReplicationConfig(bucketReplicationConfig, replicationConfig)
+
+	// The targets created so far must be known to the next apply, even when this one fails. A failed creation
+	// is kept in state, tainted, so they are removed when it is replaced
+	d.SetId(bucketReplicationConfig.MinioBucket)
+	_ = d.Set("created_target_arns", bucketReplicationConfig.CreatedRemoteTargetARNs)
 
 	if err != nil {
 		return NewResourceError(fmt.Sprintf("error generating bucket replication configuration for %q", bucketReplicationConfig.MinioBucket), d.Id(), err)
@@ -241,9 +489,47 @@ func minioPutBucketReplication(ctx context.Context, d *schema.ResourceData, meta
 		return NewResourceError(fmt.Sprintf("error putting bucket replication configuration for %q", bucketReplicationConfig.MinioBucket), d.Id(), err)
 	}
 
-	d.SetId(bucketReplicationConfig.MinioBucket)
+	managedRuleIDs := make([]string, len(replicationConfig))
+	createdARNs := []string{}
+	for i, rule := range replicationConfig {
+		managedRuleIDs[i] = rule.Id
+		if rule.RemoteTargetArn == "" {
+			createdARNs = append(createdARNs, rule.Arn)
+		}
+	}
+	for _, arn := range orphanARNs {
+		if slices.Contains(bucketReplicationConfig.CreatedRemoteTargetARNs, arn) {
+			createdARNs = append(createdARNs, arn)
+		}
+	}
+	_ = d.Set("created_target_arns", createdARNs)
+	_ = d.Set("managed_rule_ids", managedRuleIDs)
 
-	return nil
+	bucketReplicationConfig.ManagedRuleIDs = managedRuleIDs
+	configHash, err := bucketReplicationRulesHash(ctx, bucketReplicationConfig)
+	if err != nil {
+		return NewResourceError("unable to read bucket replication configuration", bucketReplicationConfig.MinioBucket, err)
+	}
+	_ = d.Set("config_hash", configHash)
+
+	rendered, err := renderBucketReplicationRules(replicationConfig)
+	if err != nil {
+		return NewResourceError(fmt.Sprintf("error rendering bucket replication configuration for %q", bucketReplicationConfig.MinioBucket), d.Id(), err)
+	}
+	_ = d.Set("rendered_rules", rendered)
+
+	// Only checked when the rules are applied, a refresh does not connect to the targets
+	warnings := checkReplicationTargetsObjectLock(ctx, bucketReplicationConfig.MinioClient, bucketReplicationConfig.MinioBucket, bucketReplicationConfig.ReplicationRules)
+
+	if len(orphanARNs) != 0 {
+		warnings = append(warnings, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Orphan remote targets",
+			Detail:   fmt.Sprintf("The remote targets %s of bucket %q are referenced by no replication rule, set purge_orphan_targets to remove them", strings.Join(orphanARNs, ", "), bucketReplicationConfig.MinioBucket),
+		})
+	}
+
+	return warnings
 }
 
 func minioReadBucketReplication(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -253,8 +539,7 @@ func minioReadBucketReplication(ctx context.Context, d *schema.ResourceData, met
 		return diags
 	}
 
-	client := bucketReplicationConfig.MinioClient
-	admclient := bucketReplicationConfig.MinioAdmin
+	cache := bucketReplicationConfig.ReplicationCache
 	bucketName := d.Id()
 
 	// Reverse index to store rule definition read from Minio to macth the order they have in the IaC. This prevent Terrfaform from try to re-order rule each time
@@ -262,25 +547,35 @@ func minioReadBucketReplication(ctx context.Context, d *schema.ResourceData, met
 	// Reverse index to store arn and index in the rule set. This is used to match bucket config and remote target order
 	ruleArnMap := map[string]int{}
 
-	if bucketReplicationConfig.ReplicationRules != nil {
-		for idx, rule := range bucketReplicationConfig.ReplicationRules {
-			priority := rule.Priority
-			if priority == 0 {
-				priority = -idx - 1
-			}
-			rulePriorityMap[priority] = idx
-		}
+	for idx, rule := range bucketReplicationConfig.ReplicationRules {
+		rulePriorityMap[rule.Priority] = idx
 	}
 
 	log.Printf("[DEBUG] S3 bucket replication, read for bucket: %s", bucketName)
 
 	// First, gather the bucket replication config
-	rcfg, err := client.GetBucketReplication(ctx, bucketName)
+	rcfg, err := cache.GetBucketReplication(ctx, bucketName)
 	if err != nil {
 		log.Printf("[WARN] Unable to fetch bucket replication config for %q: %v", bucketName, err)
 		return diag.FromErr(fmt.Errorf("error reading bucket replication configuration: %s", err))
 	}
 
+	if bucketReplicationConfig.IgnoreUnmanagedRules {
+		rcfg.Rules = filterReplicationRules(rcfg.Rules, bucketReplicationConfig.ManagedRuleIDs, true)
+	}
+
+	configHash, err := replicationRulesHash(rcfg.Rules)
+	if err != nil {
+		return NewResourceError("unable to hash replication configuration", bucketName, err)
+	}
+	_ = d.Set("config_hash", configHash)
+
+	// Rules created outside of Terraform may omit some statuses, the server applies its defaults for them
+	defaults := replicationRuleDefaults{}
+	if replicationRulesHaveImplicitStatus(rcfg.Rules) {
+		defaults = minioReplicationRuleDefaults(ctx, bucketReplicationConfig.ServerRelease)
+	}
+
 	rules := make([]map[string]interface{}, len(rcfg.Rules))
 
 	for idx, rule := range rcfg.Rules {
@@ -289,67 +584,98 @@ func minioReadBucketReplication(ctx context.Context, d *schema.ResourceData, met
 		if ruleIdx, ok = rulePriorityMap[rule.Priority]; !ok {
 			ruleIdx = idx
 		}
-		if _, ok = ruleArnMap[rule.Destination.Bucket]; ok {
-			log.Printf("[WARN] Conflict detetcted between two rules containing the same ARN for %q: %q", bucketName, rule.Destination.Bucket)
-			return diag.FromErr(fmt.Errorf("conflict detetcted between two rules containing the same ARN for %q: %q", bucketName, rule.Destination.Bucket))
+
+		// The remote targets managed by minio_s3_bucket_remote_target may be shared by several rules
+		configured, isConfigured := configuredReplicationRule(bucketReplicationConfig.ReplicationRules, rule.ID, rule.Destination.Bucket, ruleIdx)
+		remoteTargetArn := ""
+		if isConfigured && configured.RemoteTargetArn != "" {
+			remoteTargetArn = rule.Destination.Bucket
+		} else {
+			if _, ok = ruleArnMap[rule.Destination.Bucket]; ok {
+				log.Printf("[WARN] Conflict detetcted between two rules containing the same ARN for %q: %q", bucketName, rule.Destination.Bucket)
+				return diag.FromErr(fmt.Errorf("conflict detetcted between two rules containing the same ARN for %q: %q", bucketName, rule.Destination.Bucket))
+			}
+			ruleArnMap[rule.Destination.Bucket] = ruleIdx
 		}
-		ruleArnMap[rule.Destination.Bucket] = ruleIdx
 		target := map[string]interface{}{
+			"type":          replicationTargetTypeMinIO,
 			"storage_class": rule.Destination.StorageClass,
 		}
-		priority := rule.Priority
-		if len(bucketReplicationConfig.ReplicationRules) > ruleIdx && priority == -bucketReplicationConfig.ReplicationRules[ruleIdx].Priority {
-			priority = -priority
-		}
 		rules[ruleIdx] = map[string]interface{}{
 			"id":                          rule.ID,
 			"arn":                         rule.Destination.Bucket,
 			"enabled":                     rule.Status == replication.Enabled,
-			"priority":                    priority,
+			"priority":                    rule.Priority,
 			"prefix":                      rule.Prefix(),
-			"delete_replication":          rule.DeleteReplication.Status == replication.Enabled,
-			"delete_marker_replication":   rule.DeleteMarkerReplication.Status == replication.Enabled,
-			"existing_object_replication": rule.ExistingObjectReplication.Status == replication.Enabled,
-			"metadata_sync":               rule.SourceSelectionCriteria.ReplicaModifications.Status == replication.Enabled,
+			"delete_replication":          replicationStatusEnabled(rule.DeleteReplication.Status, defaults.DeleteReplication),
+			"delete_marker_replication":   replicationStatusEnabled(rule.DeleteMarkerReplication.Status, defaults.DeleteMarkerReplication),
+			"existing_object_replication": replicationStatusEnabled(rule.ExistingObjectReplication.Status, defaults.ExistingObjectReplication),
+			"replica_modifications":       replicationStatusEnabled(rule.SourceSelectionCriteria.ReplicaModifications.Status, defaults.ReplicaModifications),
+			"metadata_sync":               replicationStatusEnabled(rule.SourceSelectionCriteria.ReplicaModifications.Status, defaults.ReplicaModifications),
+			"remote_target_arn":           remoteTargetArn,
 		}
 
 		log.Printf("[DEBUG] Rule data for rule#%d is: %q", ruleIdx, rule)
 
-		if len(rule.Filter.And.Tags) != 0 || rule.Filter.And.Prefix != "" {
-			tags := map[string]string{}
-			for _, tag := range rule.Filter.And.Tags {
-				if tag.IsEmpty() {
-					continue
-				}
-				tags[tag.Key] = tag.Value
-			}
+		if tags := replicationRuleTags(rule); len(tags) != 0 || rule.Filter.And.Prefix != "" {
 			rules[ruleIdx]["tags"] = tags
-		} else if rule.Filter.Tag.Key != "" {
-			rules[ruleIdx]["tags"] = map[string]string{
-				rule.Filter.Tag.Key: rule.Filter.Tag.Value,
-			}
 		} else {
 			rules[ruleIdx]["tags"] = nil
 		}
 
 		// During import, there is no rules defined. Furthermore, since it is impossible to read the secret from the API, we
-		// default it to an empty string, allowing user to prevent remote changes by also using an empty string or omiting the secret_key
-		if len(bucketReplicationConfig.ReplicationRules) > ruleIdx {
-			target["secret_key"] = bucketReplicationConfig.ReplicationRules[ruleIdx].Target.SecretKey
+		// default it to an empty string, allowing user to prevent remote changes by also using an empty string or omiting the secret_key.
+		// The remote target API has no TLS settings nor kind of target either, these only exist in the configuration
+		if remoteTargetArn != "" {
+			rules[ruleIdx]["target"] = []interface{}{}
+			continue
+		}
+		if isConfigured {
+			target["type"] = configured.Target.Type
+			target["secret_key"] = configured.Target.SecretKey
+			target["probe_insecure_skip_verify"] = configured.Target.ProbeInsecureSkipVerify
+			target["probe_cacert_pem"] = configured.Target.ProbeCACertPEM
 		}
 
 		rules[ruleIdx]["target"] = []interface{}{target}
 	}
 
 	// Second, we read the remote bucket config
-	existingRemoteTargets, err := admclient.ListRemoteTargets(ctx, bucketName, "")
+	existingRemoteTargets, err := cache.ListRemoteTargets(ctx, bucketName)
 	if err != nil {
 		log.Printf("[WARN] Unable to fetch existing remote target config for %q: %v", bucketName, err)
 		return diag.FromErr(fmt.Errorf("error reading replication remote target configuration: %s", err))
 	}
 
-	if len(existingRemoteTargets) != len(rules) {
-		return diag.FromErr(fmt.Errorf("inconsistent number of remote target and bucket replication rules (%d != %d)", len(existingRemoteTargets), len(rules)))
+	// The targets created by the resource are those of its rules, and the orphans of a failed apply still on the server
+	createdARNs := make([]string, 0, len(ruleArnMap))
+	for _, rule := range rules {
+		arn, _ := rule["arn"].(string)
+		if remoteTargetArn, _ := rule["remote_target_arn"].(string); remoteTargetArn == "" && arn != "" && !slices.Contains(createdARNs, arn) {
+			createdARNs = append(createdARNs, arn)
+		}
+	}
+	for _, arn := range d.Get("created_target_arns").([]interface{}) {
+		arn, _ := arn.(string)
+		if !slices.Contains(createdARNs, arn) && slices.ContainsFunc(existingRemoteTargets, func(target madmin.BucketTarget) bool { return target.Arn == arn }) {
+			createdARNs = append(createdARNs, arn)
+		}
+	}
+	_ = d.Set("created_target_arns", createdARNs)
+
+	// Remote targets referenced by no rule are either unmanaged or orphans, which are reported on apply
+	managedRemoteTargets := []madmin.BucketTarget{}
+	for _, remoteTarget := range existingRemoteTargets {
+		if _, ok := ruleArnMap[remoteTarget.Arn]; ok {
+			managedRemoteTargets = append(managedRemoteTargets, remoteTarget)
+		} else {
+			log.Printf("[DEBUG] Ignoring remote target %q of %q which no managed rule references", remoteTarget.Arn, bucketName)
+		}
+	}
+	existingRemoteTargets = managedRemoteTargets
+
+	if len(existingRemoteTargets) != len(ruleArnMap) {
+		return diag.FromErr(fmt.Errorf("inconsistent number of remote target and bucket replication rules (%d != %d)", len(existingRemoteTargets), len(ruleArnMap)))
 	}
 
 	for _, remoteTarget := range existingRemoteTargets {
@@ -367,21 +693,41 @@ func minioReadBucketReplication(ctx context.Context, d *schema.ResourceData, met
 			return diag.FromErr(fmt.Errorf("unable to extract the target information for the this remote target configuration associated on %s", bucketName))
 		}
 
-		pathComponent := strings.Split(remoteTarget.TargetBucket, "/")
-
 		log.Printf("[DEBUG] absolute remote target path is %s", remoteTarget.TargetBucket)
 
-		target["bucket"] = pathComponent[len(pathComponent)-1]
+		targetPath, targetBucket := splitReplicationTargetBucket(remoteTarget.TargetBucket)
+		target["bucket"] = targetBucket
 		target["host"] = remoteTarget.Endpoint
 		target["secure"] = remoteTarget.Secure
 		target["path_style"] = remoteTarget.Path
-		target["path"] = strings.Join(pathComponent[:len(pathComponent)-1], "/")
+		target["path"] = targetPath
 		target["syncronous"] = remoteTarget.ReplicationSync
 		target["health_check_period"] = shortDur(remoteTarget.HealthCheckDuration)
 		target["bandwidth_limt"] = humanize.Bytes(uint64(remoteTarget.BandwidthLimit))
 		target["region"] = remoteTarget.Region
 		target["access_key"] = remoteTarget.Credentials.AccessKey
 
+		if offline, ok := d.Get("fail_if_offline").([]interface{}); ok && len(offline) == 1 && offline[0] != nil {
+			offlineConfig := offline[0].(map[string]interface{})
+			threshold, _ := time.ParseDuration(offlineConfig["threshold"].(string))
+			offlineSince, _ := d.Get(fmt.Sprintf("rule.%d.target.0.offline_since", ruleIdx)).(string)
+
+			online, offlineSince, err := checkReplicationTargetOnline(ctx, remoteTarget, target, offlineSince, threshold, time.Now())
+			target["online"] = online
+			target["offline_since"] = offlineSince
+			if err != nil {
+				severity := diag.Warning
+				if offlineConfig["strict"].(bool) {
+					severity = diag.Error
+				}
+				diags = append(diags, diag.Diagnostic{
+					Severity: severity,
+					Summary:  fmt.Sprintf("rule[%d].target is offline", ruleIdx),
+					Detail:   err.Error(),
+				})
+			}
+		}
+
 		log.Printf("[DEBUG] serialise remote target data is %v", target)
 
 		rules[ruleIdx]["target"] = []interface{}{target}
@@ -395,6 +741,28 @@ func minioReadBucketReplication(ctx context.Context, d *schema.ResourceData, met
 		return diag.FromErr(fmt.Errorf("error setting replication configuration: %w", err))
 	}
 
+	managedRuleIDs := make([]string, len(rcfg.Rules))
+	for i, rule := range rcfg.Rules {
+		managedRuleIDs[i] = rule.ID
+	}
+	_ = d.Set("managed_rule_ids", managedRuleIDs)
+
+	if replicationRules, errs := getBucketReplicationConfig(d.Get("rule").([]interface{})); !errs.HasError() {
+		rendered, err := renderBucketReplicationRules(replicationRules)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("error rendering replication configuration: %w", err))
+		}
+		_ = d.Set("rendered_rules", rendered)
+	}
+
+	// Only used during plan, but kept in state so imports match the default value
+	_ = d.Set("validate_credentials", d.Get("validate_credentials").(bool))
+	_ = d.Set("ignore_unmanaged_rules", d.Get("ignore_unmanaged_rules").(bool))
+	_ = d.Set("purge_orphan_targets", d.Get("purge_orphan_targets").(bool))
+	_ = d.Set("priority_strategy", replicationPriorityStrategy(d))
+	_ = d.Set("enable_versioning", d.Get("enable_versioning").(bool))
+	_ = d.Set("enable_target_versioning", d.Get("enable_target_versioning").(bool))
+
 	return diags
 }
 
@@ -410,12 +778,18 @@ func minioDeleteBucketReplication(ctx context.Context, d *schema.ResourceData, m
 	client := bucketReplicationConfig.MinioClient
 	admclient := bucketReplicationConfig.MinioAdmin
 
-	rcfg, err := client.GetBucketReplication(ctx, bucketReplicationConfig.MinioBucket)
+	defer bucketReplicationConfig.ReplicationCache.Invalidate(bucketReplicationConfig.MinioBucket)
+
+	rcfg, err := bucketReplicationConfig.ReplicationCache.GetBucketReplication(ctx, bucketReplicationConfig.MinioBucket)
 	if err != nil {
 		log.Printf("[WARN] Unable to fetch bucket replication config for %q: %v", bucketReplicationConfig.MinioBucket, err)
 		return diag.FromErr(fmt.Errorf("error reading bucket replication configuration: %s", err))
 	}
 
+	if bucketReplicationConfig.IgnoreUnmanagedRules {
+		return minioDeleteManagedBucketReplicationRules(ctx, bucketReplicationConfig, rcfg)
+	}
+
 	log.Printf("[DEBUG] S3 bucket: %s, disabling replication", bucketReplicationConfig.MinioBucket)
 
 	rcfg.Rules = []replication.Rule{}
@@ -430,187 +804,1396 @@ func minioDeleteBucketReplication(ctx context.Context, d *schema.ResourceData, m
 		log.Printf("[WARN] Unable to fetch existing remote target config for %q: %v", bucketReplicationConfig.MinioBucket, err)
 		return diag.FromErr(fmt.Errorf("error reading replication remote target configuration: %s", err))
 	}
-	if len(existingRemoteTargets) != 0 {
-		return diag.FromErr(fmt.Errorf("%d remote targets are still present on the bukcet while none are expected", len(existingRemoteTargets)))
+	remainingRemoteTargets := 0
+	for _, remoteTarget := range existingRemoteTargets {
+		if slices.Contains(bucketReplicationConfig.CreatedRemoteTargetARNs, remoteTarget.Arn) && !slices.Contains(bucketReplicationConfig.ExternalRemoteTargetARNs, remoteTarget.Arn) {
+			remainingRemoteTargets++
+		}
+	}
+	if remainingRemoteTargets != 0 {
+		return diag.FromErr(fmt.Errorf("%d remote targets are still present on the bukcet while none are expected", remainingRemoteTargets))
 	}
 
 	return diags
 }
-func toEnableFlag(b bool) string {
-	if b {
-		return "enable"
+
+// minioDeleteManagedBucketReplicationRules removes the managed rules and their remote targets, keeping the other rules of the bucket
+func minioDeleteManagedBucketReplicationRules(ctx context.Context, bucketReplicationConfig *S3MinioBucketReplication, rcfg replication.Config) diag.Diagnostics {
+	bucket := bucketReplicationConfig.MinioBucket
+	managedRules := filterReplicationRules(rcfg.Rules, bucketReplicationConfig.ManagedRuleIDs, true)
+	rcfg.Rules = filterReplicationRules(rcfg.Rules, bucketReplicationConfig.ManagedRuleIDs, false)
+
+	log.Printf("[DEBUG] S3 bucket: %s, removing %d managed replication rules, keeping %d", bucket, len(managedRules), len(rcfg.Rules))
+
+	var err error
+	if len(rcfg.Rules) == 0 {
+		err = bucketReplicationConfig.MinioClient.RemoveBucketReplication(ctx, bucket)
+	} else {
+		err = bucketReplicationConfig.MinioClient.SetBucketReplication(ctx, bucket, rcfg)
+	}
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error writing bucket replication configuration: %s", err))
 	}
-	return "disable"
-}
 
-func convertBucketReplicationConfig(bucketReplicationConfig *S3MinioBucketReplication, c []S3MinioBucketReplicationRule) (rcfg replication.Config, err error) {
-	// TODO do we want to fetch the existing config?
-	client := bucketReplicationConfig.MinioClient
-	admclient := bucketReplicationConfig.MinioAdmin
+	for _, rule := range managedRules {
+		if slices.Contains(bucketReplicationConfig.ExternalRemoteTargetARNs, rule.Destination.Bucket) {
+			continue
+		}
+		if err := bucketReplicationConfig.MinioAdmin.RemoveRemoteTarget(ctx, bucket, rule.Destination.Bucket); err != nil {
+			return diag.FromErr(fmt.Errorf("error removing replication remote target %q: %s", rule.Destination.Bucket, err))
+		}
+	}
 
-	ctx := context.Background() // TODO global context?
+	return nil
+}
 
-	rcfg, err = client.GetBucketReplication(ctx, bucketReplicationConfig.MinioBucket)
+// minioImportBucketReplication imports all the rules of a bucket, or a single one with the bucket:rule_id format.
+// A single rule is adopted with ignore_unmanaged_rules, so the other rules can be managed elsewhere.
+func minioImportBucketReplication(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	bucket, ruleID, err := parseBucketReplicationImportID(d.Id())
 	if err != nil {
-		log.Printf("[WARN] Unable to fetch bucket replication config for %q: %v", bucketReplicationConfig.MinioBucket, err)
-		return
+		return nil, err
+	}
+	if ruleID == "" {
+		return []*schema.ResourceData{d}, nil
 	}
 
-	usedARNs := make([]string, len(c))
-	existingRemoteTargets, err := admclient.ListRemoteTargets(ctx, bucketReplicationConfig.MinioBucket, "")
+	rcfg, err := meta.(*S3MinioClient).S3Client.GetBucketReplication(ctx, bucket)
 	if err != nil {
-		log.Printf("[WARN] Unable to fetch existing remote target config for %q: %v", bucketReplicationConfig.MinioBucket, err)
-		return
+		return nil, fmt.Errorf("error reading bucket replication configuration of %s: %w", bucket, err)
+	}
+	if len(filterReplicationRules(rcfg.Rules, []string{ruleID}, true)) == 0 {
+		return nil, fmt.Errorf("bucket %s has no replication rule with ID %q", bucket, ruleID)
 	}
 
-	// TODO all rules will be regenerated, potentially overriding some. We need to add some logging
-	for i, rule := range c {
-		err = s3utils.CheckValidBucketName(rule.Target.Bucket)
-		if err != nil {
-			log.Printf("[WARN] Invalid bucket name for %q: %v", rule.Target.Bucket, err)
-			return
-		}
+	d.SetId(bucket)
+	_ = d.Set("ignore_unmanaged_rules", true)
+	_ = d.Set("managed_rule_ids", []string{ruleID})
 
-		tgtBucket := rule.Target.Bucket
-		if rule.Target.Path != "" {
-			tgtBucket = path.Clean("./" + rule.Target.Path + "/" + tgtBucket)
-		}
-		log.Printf("[DEBUG] Full path to target bucket is %s", tgtBucket)
-
-		creds := &madmin.Credentials{AccessKey: rule.Target.AccessKey, SecretKey: rule.Target.SecretKey}
-		bktTarget := &madmin.BucketTarget{
-			TargetBucket:        tgtBucket,
-			Secure:              rule.Target.Secure,
-			Credentials:         creds,
-			Endpoint:            rule.Target.Host,
-			Path:                rule.Target.PathStyle.String(),
-			API:                 "s3v4",
-			Type:                madmin.ReplicationService,
-			Region:              rule.Target.Region,
-			BandwidthLimit:      rule.Target.BandwidthLimit,
-			ReplicationSync:     rule.Target.Syncronous,
-			DisableProxy:        false, // TODO support?
-			HealthCheckDuration: rule.Target.HealthCheckPeriod,
-		}
-		// TODO use ListRemoteTarget if r.Id is set and fetch the existing ARN if no changes are required for the target
-		targets, _ := admclient.ListRemoteTargets(ctx, bucketReplicationConfig.MinioBucket, string(madmin.ReplicationService))
-		log.Printf("[DEBUG] Existing remote targets %q: %v", bucketReplicationConfig.MinioBucket, targets)
-		var arn string
-		log.Printf("[DEBUG] Adding new remote target %v for %q", *bktTarget, bucketReplicationConfig.MinioBucket)
-		arn, err = admclient.SetRemoteTarget(ctx, bucketReplicationConfig.MinioBucket, bktTarget)
-		if err != nil {
-			log.Printf("[WARN] Unable to configure remote target %v for %q: %v", *bktTarget, bucketReplicationConfig.MinioBucket, err)
-			return
-		}
+	return []*schema.ResourceData{d}, nil
+}
 
-		tagList := []string{}
-		for k, v := range rule.Tags {
-			tagList = append(tagList, fmt.Sprintf("%s=%s", k, v)) // TODO assert key content to ensure no ampersand?
-		}
+// parseBucketReplicationImportID splits an import ID, either bucket or bucket:rule_id
+func parseBucketReplicationImportID(id string) (bucket string, ruleID string, err error) {
+	bucket, ruleID, found := strings.Cut(id, ":")
+	if bucket == "" || (found && ruleID == "") {
+		return "", "", fmt.Errorf("unexpected import ID %q, expected bucket or bucket:rule_id", id)
+	}
+	return bucket, ruleID, nil
+}
 
-		opts := replication.Options{
-			TagString:               strings.Join(tagList, "&"),
-			IsTagSet:                len(tagList) != 0,
-			StorageClass:            rule.Target.StorageClass,
-			Priority:                strconv.Itoa(int(math.Abs(float64(rule.Priority)))),
-			Prefix:                  rule.Prefix,
-			RuleStatus:              toEnableFlag(rule.Enabled),
-			ID:                      rule.Id,
-			DestBucket:              arn,
-			ReplicateDeleteMarkers:  toEnableFlag(rule.DeleteMarkerReplication),
-			ReplicateDeletes:        toEnableFlag(rule.DeleteReplication),
-			ReplicaSync:             toEnableFlag(rule.MetadataSync),
-			ExistingObjectReplicate: toEnableFlag(rule.ExistingObjectReplication),
-		}
-		log.Printf("[DEBUG] Adding/editing replication option for rule#%d: %v", i, opts)
-		if strings.TrimSpace(opts.ID) == "" {
-			rule.Id = xid.New().String()
-			opts.ID = rule.Id
-			opts.Op = replication.AddOption
-			err = rcfg.AddRule(opts)
-		} else {
-			opts.Op = replication.SetOption
-			err = rcfg.EditRule(opts)
+// filterReplicationRules returns the rules whose ID is in ids when managed is true, or the other ones otherwise
+func filterReplicationRules(rules []replication.Rule, ids []string, managed bool) []replication.Rule {
+	filtered := []replication.Rule{}
+	for _, rule := range rules {
+		if slices.Contains(ids, rule.ID) == managed {
+			filtered = append(filtered, rule)
 		}
+	}
+	return filtered
+}
 
-		if err != nil {
-			return
-		}
-		usedARNs[i] = arn
+func minioValidateBucketReplicationPriorities(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.HasChange("rule") {
+		return nil
 	}
 
-	for _, existingRemoteTarget := range existingRemoteTargets {
-		if !slices.Contains(usedARNs, existingRemoteTarget.Arn) {
-			err = admclient.RemoveRemoteTarget(ctx, bucketReplicationConfig.MinioBucket, existingRemoteTarget.Arn)
+	rules, _ := d.Get("rule").([]interface{})
+	configured := make([]int, len(rules))
+	for i := range rules {
+		key := fmt.Sprintf("rule.%d.priority", i)
+		if !d.NewValueKnown(key) {
+			log.Printf("[DEBUG] rule[%d].priority is not known yet, skipping the priorities check", i)
+			return nil
 		}
+		configured[i] = d.Get(key).(int)
+	}
 
-		if err != nil {
-			return
+	resolved, err := replicationRulePriorities(configured, replicationPriorityStrategy(d))
+	if err != nil {
+		return err
+	}
+
+	priorities := map[int]int{}
+	for i, priority := range resolved {
+		if other, ok := priorities[priority]; ok {
+			return fmt.Errorf("rule[%d] and rule[%d] have the same priority %d, priorities must be unique", other, i, priority)
 		}
+		priorities[priority] = i
 	}
 
-	return
-}
+	if !d.NewValueKnown("bucket") || meta == nil {
+		return nil
+	}
 
-func getBucketReplicationConfig(v []interface{}) (result []S3MinioBucketReplicationRule, errs diag.Diagnostics) {
-	if len(v) == 0 || v[0] == nil {
-		return
+	bucket := d.Get("bucket").(string)
+	m := meta.(*S3MinioClient)
+	var serverConfig replication.Config
+	if m.ReplicationCache != nil {
+		serverConfig, err = m.ReplicationCache.GetBucketReplication(ctx, bucket)
+	} else {
+		serverConfig, err = m.S3Client.GetBucketReplication(ctx, bucket)
+	}
+	if err != nil {
+		log.Printf("[DEBUG] Unable to read the replication configuration of %q, skipping the priorities check against the server: %v", bucket, err)
+		return nil
 	}
 
-	result = make([]S3MinioBucketReplicationRule, len(v))
-	for i, rule := range v {
-		var ok bool
-		tfMap, ok := rule.(map[string]interface{})
-		if !ok {
-			errs = append(errs, diag.Errorf("Unable to extra the rule %d", i)...)
+	managed := map[string]bool{}
+	oldRules, _ := d.GetChange("rule")
+	for _, rule := range oldRules.([]interface{}) {
+		if rule, ok := rule.(map[string]interface{}); ok {
+			managed[rule["id"].(string)] = true
+		}
+	}
+
+	for _, rule := range serverConfig.Rules {
+		if managed[rule.ID] {
 			continue
 		}
-		log.Printf("[DEBUG] rule[%d] contains %v", i, tfMap)
+		if i, ok := priorities[rule.Priority]; ok {
+			return fmt.Errorf("rule[%d] has the same priority %d as the rule %q, which is not managed by Terraform", i, rule.Priority, rule.ID)
+		}
+	}
 
-		result[i].Arn, _ = tfMap["arn"].(string)
-		result[i].Id, _ = tfMap["id"].(string)
+	return nil
+}
 
-		if result[i].Enabled, ok = tfMap["enabled"].(bool); !ok {
-			log.Printf("[DEBUG] rule[%d].enabled omitted. Defaulting to true", i)
-			result[i].Enabled = true
+// minioValidateBucketReplicationFeatures fails the plan when the server release or the target does not support a
+// rule setting
+func minioValidateBucketReplicationFeatures(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	rules, _ := d.Get("rule").([]interface{})
+	for i := range rules {
+		// replica_modifications and metadata_sync are computed, only the values set in the configuration are checked
+		replicaModifications := false
+		for _, attribute := range []string{"replica_modifications", "metadata_sync"} {
+			if value, ok := replicationRuleAttribute(d.GetRawConfig(), i, attribute); ok && value.IsKnown() && !value.IsNull() && value.True() {
+				replicaModifications = true
+			}
 		}
-
-		if result[i].Priority, ok = tfMap["priority"].(int); !ok || result[i].Priority == 0 {
-			// Since priorities are always positive, we use a negative value to indicate they were automatically generated
-			result[i].Priority = -i - 1
-			log.Printf("[DEBUG] rule[%d].priority omitted. Defaulting to index (%d)", i, -result[i].Priority) // TODO shall we use max(rules[].priority) + 1 instead?
+		targetType, _ := d.Get(fmt.Sprintf("rule.%d.target.0.type", i)).(string)
+		unsupported := unsupportedReplicationTargetFeatures(targetType, d.Get(fmt.Sprintf("rule.%d.existing_object_replication", i)).(bool), replicaModifications)
+		if len(unsupported) != 0 {
+			return fmt.Errorf("rule[%d]: %s cannot be enabled with a target of type %s", i, strings.Join(unsupported, " and "), targetType)
 		}
 
-		result[i].Prefix, _ = tfMap["prefix"].(string)
-
-		if tags, ok := tfMap["tags"].(map[string]interface{}); ok {
-			log.Printf("[DEBUG] rule[%d].tags map contains: %v", i, tags)
-			tagMap := map[string]string{}
-			for k, val := range tags {
-				var valOk bool
-				tagMap[k], valOk = val.(string)
-				if !valOk {
-					errs = append(errs, diag.Errorf("rule[%d].tags[%s] value must be a string, not a %s", i, k, reflect.TypeOf(val))...)
-				}
+		if d.Get(fmt.Sprintf("rule.%d.existing_object_replication", i)).(bool) {
+			if err := requireMinioFeatures(ctx, meta, minioFeatureExistingObjectReplication); err != nil {
+				return fmt.Errorf("rule[%d]: %w", i, err)
 			}
-			result[i].Tags = tagMap
-		} else {
-			errs = append(errs, diag.Errorf("unable to extarct rule[%d].tags of type %s", i, reflect.TypeOf(tfMap["tags"]))...)
 		}
+	}
+	return nil
+}
 
-		log.Printf("[DEBUG] rule[%d].tags are: %v", i, result[i].Tags)
+func minioValidateBucketReplicationCredentials(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.Get("validate_credentials").(bool) {
+		return nil
+	}
 
-		result[i].DeleteReplication, ok = tfMap["delete_replication"].(bool)
-		result[i].DeleteReplication = result[i].DeleteReplication && ok
-		result[i].DeleteMarkerReplication, ok = tfMap["delete_marker_replication"].(bool)
-		result[i].DeleteMarkerReplication = result[i].DeleteMarkerReplication && ok
-		result[i].ExistingObjectReplication, ok = tfMap["existing_object_replication"].(bool)
-		result[i].ExistingObjectReplication = result[i].ExistingObjectReplication && ok
+	rules, _ := d.Get("rule").([]interface{})
+	for i := range rules {
+		prefix := fmt.Sprintf("rule.%d.target.0.", i)
 
-		// TODO target
-		var targets []interface{}
-		if targets, ok = tfMap["target"].([]interface{}); !ok || len(targets) != 1 {
-			errs = append(errs, diag.Errorf("Unexpected value type for rule[%d].target. Exactly one target configuration is expected", i)...)
+		known := true
+		for _, key := range []string{"bucket", "host", "secure", "probe_insecure_skip_verify", "probe_cacert_pem", "region", "access_key", "secret_key"} {
+			known = known && d.NewValueKnown(prefix+key)
+		}
+		if !known {
+			log.Printf("[DEBUG] rule[%d].target is not fully known yet, skipping credentials validation", i)
+			continue
+		}
+
+		target := S3MinioBucketReplicationRuleTarget{
+			Bucket:    d.Get(prefix + "bucket").(string),
+			Host:      d.Get(prefix + "host").(string),
+			Secure:    d.Get(prefix + "secure").(bool),
+			Region:    d.Get(prefix + "region").(string),
+			AccessKey: d.Get(prefix + "access_key").(string),
+			SecretKey: d.Get(prefix + "secret_key").(string),
+
+			ProbeInsecureSkipVerify: d.Get(prefix + "probe_insecure_skip_verify").(bool),
+			ProbeCACertPEM:          d.Get(prefix + "probe_cacert_pem").(string),
+		}
+		if target.SecretKey == "" {
+			log.Printf("[DEBUG] rule[%d].target.secret_key is not set, skipping credentials validation", i)
+			continue
+		}
+
+		if err := validateReplicationTargetCredentials(ctx, target); err != nil {
+			return fmt.Errorf("rule[%d].target: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+// validateReplicationTargetCredentials checks the target credentials can reach the target bucket
+func validateReplicationTargetCredentials(ctx context.Context, target S3MinioBucketReplicationRuleTarget) error {
+	client, err := newReplicationTargetClient(target)
+	if err != nil {
+		return err
+	}
+
+	exists, err := client.BucketExists(ctx, target.Bucket)
+	if err != nil {
+		return fmt.Errorf("unable to access bucket %q on %q with access key %q: %w", target.Bucket, target.Host, target.AccessKey, err)
+	}
+	if !exists {
+		return fmt.Errorf("bucket %q does not exist on %q", target.Bucket, target.Host)
+	}
+
+	return nil
+}
+
+// checkReplicationTargetOnline probes the target liveness endpoint from the provider. It returns whether the target
+// is online, since when it is offline and an error once it has been offline for longer than threshold.
+// The remote target API of the supported madmin version does not expose the downtime seen by the server,
+// so the provider keeps track of it across refreshes. The date is only set when the target goes offline, a
+// target staying online or offline leaves the state unchanged.
+func checkReplicationTargetOnline(ctx context.Context, remoteTarget madmin.BucketTarget, target map[string]interface{}, offlineSince string, threshold time.Duration, now time.Time) (bool, string, error) {
+	insecureSkipVerify, _ := target["probe_insecure_skip_verify"].(bool)
+	caCertPEM, _ := target["probe_cacert_pem"].(string)
+	tr, err := replicationTargetTransport(S3MinioBucketReplicationRuleTarget{
+		Secure:                  remoteTarget.Secure,
+		ProbeInsecureSkipVerify: insecureSkipVerify,
+		ProbeCACertPEM:          caCertPEM,
+	})
+	if err != nil {
+		return false, offlineSince, err
+	}
+
+	scheme := "http"
+	if remoteTarget.Secure {
+		scheme = "https"
+	}
+	liveURL := fmt.Sprintf("%s://%s/minio/health/live", scheme, remoteTarget.Endpoint)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, liveURL, nil)
+	if err != nil {
+		return false, offlineSince, err
+	}
+
+	// Any answer means the target can be reached, even when it is not a MinIO server
+	resp, err := (&http.Client{Transport: tr, Timeout: 10 * time.Second}).Do(req)
+	if err == nil {
+		resp.Body.Close()
+		return true, "", nil
+	}
+
+	log.Printf("[WARN] Replication target %s is unreachable: %v", remoteTarget.Endpoint, err)
+
+	since, parseErr := time.Parse(time.RFC3339, offlineSince)
+	if parseErr != nil {
+		since = now.UTC().Truncate(time.Second)
+		offlineSince = since.Format(time.RFC3339)
+	}
+	if downtime := now.Sub(since); downtime > threshold {
+		return false, offlineSince, fmt.Errorf("target %s has been unreachable from the provider for %s (since %s): %w", remoteTarget.Endpoint, shortDur(downtime.Truncate(time.Second)), offlineSince, err)
+	}
+
+	return false, offlineSince, nil
+}
+
+// newReplicationTargetClient returns a client connecting to the target with its credentials
+func newReplicationTargetClient(target S3MinioBucketReplicationRuleTarget) (*minio.Client, error) {
+	tr, err := replicationTargetTransport(target)
+	if err != nil {
+		return nil, fmt.Errorf("unable to configure TLS for %q: %w", target.Host, err)
+	}
+
+	client, err := minio.New(target.Host, &minio.Options{
+		Creds:     credentials.NewStaticV4(target.AccessKey, target.SecretKey, ""),
+		Secure:    target.Secure,
+		Region:    target.Region,
+		Transport: tr,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to build a client for %q: %w", target.Host, err)
+	}
+
+	return client, nil
+}
+
+// replicationObjectLockCheckTimeout bounds the object lock check of the targets, so an unreachable target does not
+// hold the apply
+const replicationObjectLockCheckTimeout = 10 * time.Second
+
+// checkReplicationTargetsObjectLock warns about the targets without object lock when the source bucket has it, as
+// the locked objects cannot be replicated to them. Targets whose secret key is unknown, such as after an import,
+// or which cannot be read with their credentials within replicationObjectLockCheckTimeout are not checked.
+func checkReplicationTargetsObjectLock(ctx context.Context, client *minio.Client, bucket string, rules []S3MinioBucketReplicationRule) (diags diag.Diagnostics) {
+	ctx, cancel := context.WithTimeout(ctx, replicationObjectLockCheckTimeout)
+	defer cancel()
+
+	sourceLock, err := bucketObjectLockEnabled(ctx, client, bucket)
+	if err != nil {
+		log.Printf("[WARN] Unable to read the object lock configuration of %q: %v", bucket, err)
+		return nil
+	}
+	if !sourceLock {
+		return nil
+	}
+
+	for i, rule := range rules {
+		if rule.Target.SecretKey == "" || rule.Target.Host == "" {
+			continue
+		}
+
+		targetClient, err := newReplicationTargetClient(rule.Target)
+		if err != nil {
+			log.Printf("[WARN] Unable to check the object lock of rule[%d].target: %v", i, err)
+			continue
+		}
+		targetLock, err := bucketObjectLockEnabled(ctx, targetClient, rule.Target.Bucket)
+		if err != nil {
+			log.Printf("[WARN] Unable to check the object lock of rule[%d].target: %v", i, err)
+			continue
+		}
+		if !targetLock {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("rule[%d].target has no object lock", i),
+				Detail:   fmt.Sprintf("Bucket %q has object lock but its target bucket %q on %q does not, the locked objects will fail to replicate. Object lock can only be enabled when the target bucket is created.", bucket, rule.Target.Bucket, rule.Target.Host),
+			})
+		}
+	}
+	return diags
+}
+
+// configuredReplicationRule returns the configured rule a rule read from the server matches, by its ID, then its
+// ARN, so the settings which cannot be read back stay with their rule when the rules are reordered. The rule at
+// the same index is only used for the rules not created yet, which have neither.
+func configuredReplicationRule(rules []S3MinioBucketReplicationRule, id string, arn string, idx int) (S3MinioBucketReplicationRule, bool) {
+	for _, rule := range rules {
+		if id != "" && rule.Id == id {
+			return rule, true
+		}
+	}
+	for _, rule := range rules {
+		if arn != "" && rule.Arn == arn {
+			return rule, true
+		}
+	}
+	if idx < len(rules) && rules[idx].Id == "" && rules[idx].Arn == "" {
+		return rules[idx], true
+	}
+	return S3MinioBucketReplicationRule{}, false
+}
+
+// bucketObjectLockEnabled returns whether object lock is enabled on a bucket
+func bucketObjectLockEnabled(ctx context.Context, client *minio.Client, bucket string) (bool, error) {
+	objectLock, _, _, _, err := client.GetObjectLockConfig(ctx, bucket)
+	if err != nil {
+		if minio.ToErrorResponse(err).Code == "ObjectLockConfigurationNotFoundError" {
+			return false, nil
+		}
+		return false, err
+	}
+	return objectLock == string(minio.Enabled), nil
+}
+
+// ensureBucketVersioning enables versioning on the bucket unless it already is
+func ensureBucketVersioning(ctx context.Context, client *minio.Client, bucket string) error {
+	versioning, err := client.GetBucketVersioning(ctx, bucket)
+	if err != nil {
+		return err
+	}
+	if versioning.Enabled() {
+		return nil
+	}
+
+	log.Printf("[DEBUG] Enabling versioning on bucket %q (currently %q)", bucket, versioning.Status)
+	if err := client.EnableVersioning(ctx, bucket); err != nil {
+		if objectLock, _, _, _, lockErr := client.GetObjectLockConfig(ctx, bucket); lockErr == nil && objectLock != "" {
+			return fmt.Errorf("versioning cannot be changed on bucket %q because object lock is %s: %w", bucket, objectLock, err)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// replicationTargetTransport returns the transport used by the provider to connect to the target
+func replicationTargetTransport(target S3MinioBucketReplicationRuleTarget) (*http.Transport, error) {
+	tr, err := minio.DefaultTransport(target.Secure)
+	if err != nil || !target.Secure {
+		return tr, err
+	}
+
+	tr.TLSClientConfig = &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: target.ProbeInsecureSkipVerify,
+	}
+
+	if target.ProbeCACertPEM != "" {
+		rootCAs, _ := x509.SystemCertPool()
+		if rootCAs == nil {
+			rootCAs = x509.NewCertPool()
+		}
+		if !rootCAs.AppendCertsFromPEM([]byte(target.ProbeCACertPEM)) {
+			return nil, fmt.Errorf("probe_cacert_pem is not a valid x509 certificate")
+		}
+		tr.TLSClientConfig.RootCAs = rootCAs
+	}
+
+	return tr, nil
+}
+
+// applyReplicationRuleMetadataSync uses the deprecated metadata_sync of the rules which do not configure
+// replica_modifications. Both attributes must agree when they are set together.
+func applyReplicationRuleMetadataSync(rules []S3MinioBucketReplicationRule, rawConfig cty.Value) (errs diag.Diagnostics) {
+	if rawConfig.IsNull() || !rawConfig.IsKnown() {
+		return
+	}
+
+	for i := range rules {
+		metadataSync, ok := replicationRuleAttribute(rawConfig, i, "metadata_sync")
+		if !ok || metadataSync.IsNull() || !metadataSync.IsKnown() {
+			continue
+		}
+
+		replicaModifications, _ := replicationRuleAttribute(rawConfig, i, "replica_modifications")
+		if replicaModifications.IsNull() {
+			rules[i].ReplicaModifications = metadataSync.True()
+		} else if replicaModifications.IsKnown() && replicaModifications.True() != metadataSync.True() {
+			errs = append(errs, diag.Errorf("rule[%d].metadata_sync and rule[%d].replica_modifications are set to different values, only set replica_modifications", i, i)...)
+		}
+	}
+	return
+}
+
+// replicationRuleAttributeIsNull returns true when the attribute of the rule at index is omitted in the configuration
+func replicationRuleAttributeIsNull(rawConfig cty.Value, index int, attribute string) bool {
+	value, ok := replicationRuleAttribute(rawConfig, index, attribute)
+	return ok && value.IsNull()
+}
+
+// suppressUnsetReplicationRuleAttribute suppresses the diff of a rule attribute omitted in the configuration, the
+// value read from the server being the default it applied. It covers the rules moved in the list, whose prior
+// state is not the one of their new index.
+func suppressUnsetReplicationRuleAttribute(k, old, new string, d *schema.ResourceData) bool {
+	return old != "" && replicationRuleKeyIsNull(d.GetRawConfig(), k)
+}
+
+// replicationRuleComputedAttributes are the rule attributes the server applies a default to when they are omitted,
+// which then take the value of the rule previously at the same index
+var replicationRuleComputedAttributes = []string{"delete_marker_replication", "replica_modifications", "metadata_sync"}
+
+// withReplicationRuleReorderSuppression suppresses the diff of every attribute of the rules when the rule blocks
+// were only reordered in the configuration, which would otherwise edit each rule in place with the settings of
+// another one.
+func withReplicationRuleReorderSuppression(r *schema.Resource) {
+	for _, s := range r.Schema {
+		if elem, ok := s.Elem.(*schema.Resource); ok {
+			withReplicationRuleReorderSuppression(elem)
+			continue
+		}
+		if !s.Optional && !s.Required {
+			continue
+		}
+
+		next := s.DiffSuppressFunc
+		s.DiffSuppressFunc = func(k, old, new string, d *schema.ResourceData) bool {
+			if next != nil && next(k, old, new, d) {
+				return true
+			}
+			return replicationRulesOnlyReordered(d)
+		}
+	}
+}
+
+// replicationRulesOnlyReordered returns whether the planned rules are the rules of the state in another order
+func replicationRulesOnlyReordered(d *schema.ResourceData) bool {
+	oldValue, newValue := d.GetChange("rule")
+	oldRules, _ := oldValue.([]interface{})
+	newRules, _ := newValue.([]interface{})
+
+	// Omitted priorities are resolved from the position of the rules, a reorder then changes them
+	priorities := make([]int, len(newRules))
+	for i := range newRules {
+		priorities[i], _ = d.Get(fmt.Sprintf("rule.%d.priority", i)).(int)
+	}
+	resolved, err := replicationRulePriorities(priorities, replicationPriorityStrategy(d))
+	if err != nil {
+		return false
+	}
+
+	rawConfig := d.GetRawConfig()
+	return replicationRulesReordered(oldRules, newRules, resolved, func(index int, attribute string) bool {
+		return replicationRuleAttributeIsNull(rawConfig, index, attribute)
+	})
+}
+
+// replicationRulesReordered returns whether the new rules, with their resolved priorities, are a permutation of
+// the old rules, which is not a pure reorder when both lists are in the same order. The attributes omitted in the
+// configuration of a new rule match any value.
+func replicationRulesReordered(oldRules []interface{}, newRules []interface{}, priorities []int, omitted func(index int, attribute string) bool) bool {
+	if len(oldRules) != len(newRules) || len(newRules) != len(priorities) {
+		return false
+	}
+
+	moved := false
+	matched := make([]bool, len(oldRules))
+	for i, newRule := range newRules {
+		newFingerprint := replicationRuleFingerprint(newRule, priorities[i])
+		for _, attribute := range replicationRuleComputedAttributes {
+			if omitted(i, attribute) {
+				delete(newFingerprint, attribute)
+			}
+		}
+
+		found := false
+		for j, oldRule := range oldRules {
+			if matched[j] {
+				continue
+			}
+			oldFingerprint := replicationRuleFingerprint(oldRule, 0)
+			for _, attribute := range replicationRuleComputedAttributes {
+				if _, ok := newFingerprint[attribute]; !ok {
+					delete(oldFingerprint, attribute)
+				}
+			}
+			if reflect.DeepEqual(oldFingerprint, newFingerprint) {
+				matched[j], found = true, true
+				moved = moved || i != j
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return moved
+}
+
+// replicationRuleFingerprint flattens the configurable attributes of a rule into comparable strings, the values
+// being normalized as their diff suppression does. A priority overrides the one of the rule when not zero.
+func replicationRuleFingerprint(rule interface{}, priority int) map[string]string {
+	fingerprint := map[string]string{}
+	flattenReplicationRule(fingerprint, "", rule)
+
+	delete(fingerprint, "id")
+	delete(fingerprint, "arn")
+	delete(fingerprint, "target.0.online")
+	delete(fingerprint, "target.0.offline_since")
+	if priority != 0 {
+		fingerprint["priority"] = strconv.Itoa(priority)
+	}
+
+	if host, ok := fingerprint["target.0.host"]; ok {
+		fingerprint["target.0.host"] = normalizeReplicationTargetHost(host)
+	}
+	if targetPath, ok := fingerprint["target.0.path"]; ok {
+		fingerprint["target.0.path"] = normalizeReplicationTargetPath(targetPath)
+	}
+	if period, ok := fingerprint["target.0.health_check_period"]; ok {
+		fingerprint["target.0.health_check_period"] = normalizeReplicationHealthCheckPeriod(period)
+	}
+	if limit, err := parseBandwidthLimit(fingerprint["target.0.bandwidth_limt"]); err == nil {
+		fingerprint["target.0.bandwidth_limt"] = humanize.Bytes(limit)
+	}
+	return fingerprint
+}
+
+func flattenReplicationRule(fingerprint map[string]string, key string, value interface{}) {
+	child := func(name string) string {
+		if key == "" {
+			return name
+		}
+		return key + "." + name
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for name, item := range v {
+			flattenReplicationRule(fingerprint, child(name), item)
+		}
+	case map[string]string:
+		for name, item := range v {
+			fingerprint[child(name)] = item
+		}
+	case []interface{}:
+		for i, item := range v {
+			flattenReplicationRule(fingerprint, child(strconv.Itoa(i)), item)
+		}
+	case nil:
+	default:
+		fingerprint[key] = fmt.Sprint(v)
+	}
+}
+
+// replicationRuleKeyIsNull returns true when the rule attribute of a key such as rule.0.delete_marker_replication
+// is omitted in the configuration
+func replicationRuleKeyIsNull(rawConfig cty.Value, k string) bool {
+	parts := strings.Split(k, ".")
+	if len(parts) != 3 || parts[0] != "rule" {
+		return false
+	}
+	index, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return false
+	}
+	return replicationRuleAttributeIsNull(rawConfig, index, parts[2])
+}
+
+// replicationRuleAttribute returns the configured value of the attribute of the rule at index, if the rule is known
+func replicationRuleAttribute(rawConfig cty.Value, index int, attribute string) (cty.Value, bool) {
+	if rawConfig.IsNull() || !rawConfig.IsKnown() {
+		return cty.NilVal, false
+	}
+
+	rules := rawConfig.GetAttr("rule")
+	if rules.IsNull() || !rules.IsKnown() || rules.LengthInt() <= index {
+		return cty.NilVal, false
+	}
+
+	rule := rules.Index(cty.NumberIntVal(int64(index)))
+	if rule.IsNull() || !rule.IsKnown() {
+		return cty.NilVal, false
+	}
+
+	return rule.GetAttr(attribute), true
+}
+
+// replicationReplicaModificationsRelease is the first server release syncing the metadata changes of replicas,
+// and enabling it when a rule does not set it.
+var replicationReplicaModificationsRelease = time.Date(2021, time.April, 22, 0, 0, 0, 0, time.UTC)
+
+// replicationRuleDefaults holds the values the server uses for the statuses a replication rule omits
+type replicationRuleDefaults struct {
+	DeleteReplication         bool
+	DeleteMarkerReplication   bool
+	ExistingObjectReplication bool
+	ReplicaModifications      bool
+}
+
+// replicationRuleDefaultsForRelease returns the replication rule defaults of a server release. A zero release,
+// when the version cannot be determined, gets the defaults of the current releases.
+func replicationRuleDefaultsForRelease(release time.Time) replicationRuleDefaults {
+	return replicationRuleDefaults{
+		ReplicaModifications: release.IsZero() || !release.Before(replicationReplicaModificationsRelease),
+	}
+}
+
+// minioReplicationRuleDefaults returns the replication rule defaults of the server, falling back to the defaults
+// of the current releases when its version cannot be read.
+func minioReplicationRuleDefaults(ctx context.Context, release *serverRelease) replicationRuleDefaults {
+	if release == nil {
+		return replicationRuleDefaultsForRelease(time.Time{})
+	}
+
+	serverRelease, err := release.Get(ctx)
+	if err != nil {
+		log.Printf("[WARN] Unable to read the server version, assuming the latest replication defaults: %v", err)
+	}
+	return replicationRuleDefaultsForRelease(serverRelease)
+}
+
+// replicationRulesHaveImplicitStatus tells whether a rule omits one of the statuses read back into the state
+func replicationRulesHaveImplicitStatus(rules []replication.Rule) bool {
+	for _, rule := range rules {
+		if rule.DeleteReplication.Status == "" || rule.DeleteMarkerReplication.Status == "" ||
+			rule.ExistingObjectReplication.Status == "" || rule.SourceSelectionCriteria.ReplicaModifications.Status == "" {
+			return true
+		}
+	}
+	return false
+}
+
+// replicationStatusEnabled returns whether a rule status is enabled, using the default when the status is absent
+func replicationStatusEnabled(status replication.Status, def bool) bool {
+	if status == "" {
+		return def
+	}
+	return status == replication.Enabled
+}
+
+// unsupportedReplicationTargetFeatures returns the rule settings a target of the given type cannot replicate, as
+// they rely on the MinIO extensions of the replication API
+func unsupportedReplicationTargetFeatures(targetType string, existingObjectReplication bool, replicaModifications bool) []string {
+	unsupported := []string{}
+	if targetType != replicationTargetTypeS3 {
+		return unsupported
+	}
+	if replicaModifications {
+		unsupported = append(unsupported, "replica_modifications")
+	}
+	if existingObjectReplication {
+		unsupported = append(unsupported, "existing_object_replication")
+	}
+	return unsupported
+}
+
+// normalizeReplicationTargetHost removes the scheme and the trailing slashes of a target host, which the
+// server stores as host[:port] only. IP addresses are written in their canonical form, IPv6 ones between
+// brackets, e.g. [::1]:9000, so their port can be told apart.
+func normalizeReplicationTargetHost(host string) string {
+	host = strings.TrimSpace(host)
+	if i := strings.Index(host, "://"); i >= 0 {
+		host = host[i+len("://"):]
+	}
+	host = strings.TrimRight(host, "/")
+
+	// An IPv6 address without brackets has no port
+	if ip := net.ParseIP(host); ip != nil {
+		return formatReplicationTargetIP(ip, "")
+	}
+	if strings.HasPrefix(host, "[") && strings.HasSuffix(host, "]") {
+		if ip := net.ParseIP(host[1 : len(host)-1]); ip != nil {
+			return formatReplicationTargetIP(ip, "")
+		}
+	}
+	if hostname, port, err := net.SplitHostPort(host); err == nil {
+		if ip := net.ParseIP(hostname); ip != nil {
+			return formatReplicationTargetIP(ip, port)
+		}
+	}
+	return host
+}
+
+func formatReplicationTargetIP(ip net.IP, port string) string {
+	switch {
+	case port != "":
+		return net.JoinHostPort(ip.String(), port)
+	case ip.To4() == nil:
+		return "[" + ip.String() + "]"
+	default:
+		return ip.String()
+	}
+}
+
+// validateReplicationTargetHost checks that a target host is a host optionally followed by a port. A scheme
+// is only tolerated, the secure attribute selecting it.
+func validateReplicationTargetHost(v interface{}, p cty.Path) (diags diag.Diagnostics) {
+	value := v.(string)
+	if i := strings.Index(value, "://"); i >= 0 {
+		if scheme := strings.ToLower(value[:i]); scheme != "http" && scheme != "https" {
+			return diag.Errorf("host %q: unsupported scheme %q", value, scheme)
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity:      diag.Warning,
+			Summary:       "Scheme ignored in replication target host",
+			Detail:        fmt.Sprintf("The scheme of %q is ignored, the secure attribute selects between HTTP and HTTPS.", value),
+			AttributePath: p,
+		})
+	}
+
+	host := normalizeReplicationTargetHost(value)
+	u, err := url.Parse("//" + host)
+	if host == "" || err != nil || u.Host != host || u.Hostname() == "" || u.User != nil {
+		return append(diags, diag.Errorf("host %q must be a host optionally followed by a port, without path, e.g. minio.example.com:9000 or [2001:db8::1]:9000", value)...)
+	}
+	if strings.Contains(u.Hostname(), ":") && net.ParseIP(u.Hostname()) == nil {
+		return append(diags, diag.Errorf("host %q: invalid IPv6 address %q", value, u.Hostname())...)
+	}
+	if port := u.Port(); port != "" {
+		if n, err := strconv.Atoi(port); err != nil || n < 1 || n > 65535 {
+			return append(diags, diag.Errorf("host %q: invalid port %q", value, port)...)
+		}
+	} else if strings.Contains(u.Hostname(), ":") && !strings.Contains(value, "[") {
+		diags = append(diags, diag.Diagnostic{
+			Severity:      diag.Warning,
+			Summary:       "IPv6 replication target host without port",
+			Detail:        fmt.Sprintf("%q is read as the IPv6 address %s, without port. Write it as [address]:port to set a port.", value, host),
+			AttributePath: p,
+		})
+	}
+	return diags
+}
+
+func toEnableFlag(b bool) string {
+	if b {
+		return "enable"
+	}
+	return "disable"
+}
+
+// convertBucketReplicationConfig returns the replication configuration to set on the bucket, creating the
+// remote targets of the rules and removing the ones no longer used. Orphan remote targets, referenced by no
+// rule of the bucket, are only removed when PurgeOrphanTargets is set and returned otherwise. The targets the
+// resource did not create, such as those of a minio_s3_bucket_remote_target, are never removed nor returned.
+func convertBucketReplicationConfig(bucketReplicationConfig *S3MinioBucketReplication, c []S3MinioBucketReplicationRule) (rcfg replication.Config, orphanARNs []string, err error) {
+	cache := bucketReplicationConfig.ReplicationCache
+	admclient := bucketReplicationConfig.MinioAdmin
+
+	ctx := context.Background() // TODO global context?
+
+	rcfg, err = cache.GetBucketReplication(ctx, bucketReplicationConfig.MinioBucket)
+	if err != nil {
+		log.Printf("[WARN] Unable to fetch bucket replication config for %q: %v", bucketReplicationConfig.MinioBucket, err)
+		return
+	}
+
+	serverRules := append([]replication.Rule(nil), rcfg.Rules...)
+	usedARNs := make([]string, len(c))
+	existingRemoteTargets, err := cache.ListRemoteTargets(ctx, bucketReplicationConfig.MinioBucket)
+	if err != nil {
+		log.Printf("[WARN] Unable to fetch existing remote target config for %q: %v", bucketReplicationConfig.MinioBucket, err)
+		return
+	}
+
+	// TODO all rules will be regenerated, potentially overriding some. We need to add some logging
+	for i, rule := range c {
+		var arn string
+		if rule.RemoteTargetArn != "" {
+			// The remote target is managed by a minio_s3_bucket_remote_target, the rule only references it
+			if !slices.ContainsFunc(existingRemoteTargets, func(target madmin.BucketTarget) bool { return target.Arn == rule.RemoteTargetArn }) {
+				err = fmt.Errorf("rule[%d].remote_target_arn: bucket %q has no remote target %q", i, bucketReplicationConfig.MinioBucket, rule.RemoteTargetArn)
+				return
+			}
+			arn = rule.RemoteTargetArn
+		} else {
+			err = s3utils.CheckValidBucketName(rule.Target.Bucket)
+			if err != nil {
+				log.Printf("[WARN] Invalid bucket name for %q: %v", rule.Target.Bucket, err)
+				return
+			}
+
+			tgtBucket := replicationTargetBucketPath(rule.Target.Path, rule.Target.Bucket)
+			log.Printf("[DEBUG] Full path to target bucket is %s", tgtBucket)
+
+			creds := &madmin.Credentials{AccessKey: rule.Target.AccessKey, SecretKey: rule.Target.SecretKey}
+			bktTarget := &madmin.BucketTarget{
+				TargetBucket:        tgtBucket,
+				Secure:              rule.Target.Secure,
+				Credentials:         creds,
+				Endpoint:            rule.Target.Host,
+				Path:                rule.Target.PathStyle.String(),
+				API:                 "s3v4",
+				Type:                madmin.ReplicationService,
+				Region:              rule.Target.Region,
+				BandwidthLimit:      rule.Target.BandwidthLimit,
+				ReplicationSync:     rule.Target.Syncronous,
+				DisableProxy:        false, // TODO support?
+				HealthCheckDuration: rule.Target.HealthCheckPeriod,
+			}
+			// TODO use ListRemoteTarget if r.Id is set and fetch the existing ARN if no changes are required for the target
+			log.Printf("[DEBUG] Existing remote targets %q: %v", bucketReplicationConfig.MinioBucket, existingRemoteTargets)
+			existing, ops, ok := editableRemoteTarget(existingRemoteTargets, usedARNs, rule, *bktTarget)
+			if ok {
+				// Only the settings the server can edit changed, the target keeps its ARN and the rule is not interrupted
+				log.Printf("[DEBUG] Keeping remote target %q for rule#%d of %q", existing.Arn, i, bucketReplicationConfig.MinioBucket)
+			} else if existing, ops, ok = findOrphanRemoteTarget(existingRemoteTargets, rcfg, usedARNs, bucketReplicationConfig.CreatedRemoteTargetARNs, rule, *bktTarget); ok {
+				// A previous apply failed after creating this target: resume from it instead of adding it again
+				log.Printf("[DEBUG] Resuming with remote target %q left by a previous apply for rule#%d of %q", existing.Arn, i, bucketReplicationConfig.MinioBucket)
+			}
+
+			if ok {
+				arn = existing.Arn
+				if len(ops) != 0 {
+					bktTarget.Arn = existing.Arn
+					bktTarget.SourceBucket = bucketReplicationConfig.MinioBucket
+					log.Printf("[DEBUG] Updating remote target %q of %q in place", existing.Arn, bucketReplicationConfig.MinioBucket)
+					if arn, err = admclient.UpdateRemoteTarget(ctx, bktTarget, ops...); err != nil {
+						log.Printf("[WARN] Unable to update remote target %q of %q: %v", existing.Arn, bucketReplicationConfig.MinioBucket, err)
+						return
+					}
+				}
+			} else {
+				log.Printf("[DEBUG] Adding new remote target %v for %q", *bktTarget, bucketReplicationConfig.MinioBucket)
+				arn, err = admclient.SetRemoteTarget(ctx, bucketReplicationConfig.MinioBucket, bktTarget)
+				if err != nil {
+					log.Printf("[WARN] Unable to configure remote target %v for %q: %v", *bktTarget, bucketReplicationConfig.MinioBucket, err)
+					return
+				}
+				bucketReplicationConfig.CreatedRemoteTargetARNs = append(bucketReplicationConfig.CreatedRemoteTargetARNs, arn)
+			}
+		}
+
+		if strings.TrimSpace(rule.Id) == "" {
+			rule.Id = xid.New().String()
+		}
+
+		opts := bucketReplicationRuleOptions(rule, arn)
+		log.Printf("[DEBUG] Adding/editing replication option for rule#%d: %v", i, opts)
+		if err = addOrEditReplicationRule(&rcfg, opts); err != nil {
+			return
+		}
+		usedARNs[i] = arn
+		c[i].Id = rule.Id
+		c[i].Arn = arn
+	}
+
+	if bucketReplicationConfig.IgnoreUnmanagedRules {
+		// Rules managed so far but removed from the configuration are dropped, the other rules are kept
+		// with their remote targets
+		usedIDs := make([]string, len(c))
+		for i := range c {
+			usedIDs[i] = c[i].Id
+		}
+		for _, rule := range filterReplicationRules(rcfg.Rules, bucketReplicationConfig.ManagedRuleIDs, true) {
+			if slices.Contains(usedIDs, rule.ID) {
+				continue
+			}
+			log.Printf("[DEBUG] Removing replication rule %q which is no longer managed for %q", rule.ID, bucketReplicationConfig.MinioBucket)
+			if err = rcfg.RemoveRule(replication.Options{ID: rule.ID}); err != nil {
+				return
+			}
+		}
+		for _, rule := range rcfg.Rules {
+			usedARNs = append(usedARNs, rule.Destination.Bucket)
+		}
+	}
+
+	orphans := orphanRemoteTargets(existingRemoteTargets, serverRules, usedARNs)
+	for _, existingRemoteTarget := range existingRemoteTargets {
+		if slices.Contains(usedARNs, existingRemoteTarget.Arn) || slices.Contains(bucketReplicationConfig.ExternalRemoteTargetARNs, existingRemoteTarget.Arn) {
+			continue
+		}
+		if !slices.Contains(bucketReplicationConfig.CreatedRemoteTargetARNs, existingRemoteTarget.Arn) {
+			// e.g. a minio_s3_bucket_remote_target which no rule references yet
+			log.Printf("[DEBUG] Ignoring remote target %q of %q which was not created by this resource", existingRemoteTarget.Arn, bucketReplicationConfig.MinioBucket)
+			continue
+		}
+		if slices.Contains(orphans, existingRemoteTarget.Arn) {
+			if !bucketReplicationConfig.PurgeOrphanTargets {
+				log.Printf("[WARN] Keeping orphan remote target %q of %q as purge_orphan_targets is not set", existingRemoteTarget.Arn, bucketReplicationConfig.MinioBucket)
+				orphanARNs = append(orphanARNs, existingRemoteTarget.Arn)
+				continue
+			}
+			log.Printf("[DEBUG] Purging orphan remote target %q of %q", existingRemoteTarget.Arn, bucketReplicationConfig.MinioBucket)
+		}
+
+		if err = admclient.RemoveRemoteTarget(ctx, bucketReplicationConfig.MinioBucket, existingRemoteTarget.Arn); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// bucketReplicationRulesHash fetches the replication rules of the bucket, bypassing the cache, and returns their hash
+// as computed on read
+func bucketReplicationRulesHash(ctx context.Context, bucketReplicationConfig *S3MinioBucketReplication) (string, error) {
+	bucketReplicationConfig.ReplicationCache.Invalidate(bucketReplicationConfig.MinioBucket)
+	rcfg, err := bucketReplicationConfig.ReplicationCache.GetBucketReplication(ctx, bucketReplicationConfig.MinioBucket)
+	if err != nil {
+		return "", err
+	}
+
+	if bucketReplicationConfig.IgnoreUnmanagedRules {
+		rcfg.Rules = filterReplicationRules(rcfg.Rules, bucketReplicationConfig.ManagedRuleIDs, true)
+	}
+	return replicationRulesHash(rcfg.Rules)
+}
+
+// replicationRulesHash returns the hex encoded SHA256 of the rules, sorted by ID so their order does not matter
+func replicationRulesHash(rules []replication.Rule) (string, error) {
+	sorted := append([]replication.Rule(nil), rules...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].ID < sorted[j].ID })
+
+	content, err := json.Marshal(sorted)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(content)), nil
+}
+
+// orphanRemoteTargets returns the ARNs of the remote targets which no rule of the bucket references and no
+// rule of the current apply claimed, typically created by a failed apply before the rules were set.
+func orphanRemoteTargets(targets []madmin.BucketTarget, rules []replication.Rule, usedARNs []string) []string {
+	orphans := []string{}
+	for _, target := range targets {
+		if slices.Contains(usedARNs, target.Arn) {
+			continue
+		}
+
+		referenced := false
+		for _, rule := range rules {
+			referenced = referenced || rule.Destination.Bucket == target.Arn
+		}
+		if !referenced {
+			orphans = append(orphans, target.Arn)
+		}
+	}
+	return orphans
+}
+
+// parseReplicationHealthCheckPeriod parses a health check period, allowing a space before its unit as the
+// previous validation did
+func parseReplicationHealthCheckPeriod(value string) (time.Duration, error) {
+	return time.ParseDuration(strings.Join(strings.Fields(value), ""))
+}
+
+// normalizeReplicationHealthCheckPeriod returns a health check period as the server reports it, e.g. 1m30s
+// for 90s, so both are compared equal. Invalid values are returned as is.
+func normalizeReplicationHealthCheckPeriod(value string) string {
+	period, err := parseReplicationHealthCheckPeriod(value)
+	if err != nil {
+		return value
+	}
+	return shortDur(period)
+}
+
+func validateReplicationHealthCheckPeriod(v interface{}, k string) (ws []string, errors []error) {
+	period, err := parseReplicationHealthCheckPeriod(v.(string))
+	if err != nil {
+		errors = append(errors, fmt.Errorf("%q must be a valid golang duration, e.g. 30s or 1m30s: %w", k, err))
+	} else if period < 0 {
+		errors = append(errors, fmt.Errorf("%q must not be negative", k))
+	}
+	return
+}
+
+// normalizeReplicationTargetPath returns the folder of a target bucket without leading or trailing slashes,
+// the root being an empty path whether it is given as "", "/" or "."
+func normalizeReplicationTargetPath(p string) string {
+	return strings.TrimPrefix(path.Clean("/"+p), "/")
+}
+
+// replicationTargetBucketPath returns the target bucket of a remote target, prefixed by its folder
+func replicationTargetBucketPath(p string, bucket string) string {
+	if p = normalizeReplicationTargetPath(p); p != "" {
+		return p + "/" + bucket
+	}
+	return bucket
+}
+
+// splitReplicationTargetBucket returns the normalized folder and the bucket name of a remote target bucket
+func splitReplicationTargetBucket(targetBucket string) (string, string) {
+	targetBucket = strings.Trim(targetBucket, "/")
+	idx := strings.LastIndex(targetBucket, "/")
+	if idx < 0 {
+		return "", targetBucket
+	}
+	return normalizeReplicationTargetPath(targetBucket[:idx]), targetBucket[idx+1:]
+}
+
+// bandwidthLimitPattern matches a bandwidth limit, possibly fractional, followed by an optional unit
+var bandwidthLimitPattern = regexp.MustCompile(`^([0-9]+(?:\.[0-9]+)?)\s*([A-Za-z]*)$`)
+
+// parseBandwidthLimit parses a bandwidth limit in bytes per second, e.g. "100M" or "1.5GiB". Units ending with
+// a lowercase b, such as "100Mb", are rejected as they usually mean bits while MinIO counts bytes.
+func parseBandwidthLimit(s string) (uint64, error) {
+	match := bandwidthLimitPattern.FindStringSubmatch(strings.TrimSpace(s))
+	if match == nil {
+		return 0, fmt.Errorf("invalid bandwidth limit %q, expected a number followed by an optional unit", s)
+	}
+
+	unit := match[2]
+	if len(unit) > 1 && strings.HasSuffix(unit, "b") {
+		return 0, fmt.Errorf("ambiguous bandwidth limit unit %q, the limit is in bytes per second: use %sB instead", unit, strings.TrimSuffix(unit, "b"))
+	}
+
+	return humanize.ParseBytes(match[1] + unit)
+}
+
+// findOrphanRemoteTarget returns the remote target of a new rule which a failed apply created without
+// referencing it in the replication configuration, along with the edits to make. Only the targets created by the
+// resource whose settings match the wanted target, access key included, are returned: a target used by a rule,
+// claimed by another rule of the same apply or created elsewhere, e.g. by a minio_s3_bucket_remote_target, never is.
+func findOrphanRemoteTarget(targets []madmin.BucketTarget, rcfg replication.Config, usedARNs []string, createdARNs []string, rule S3MinioBucketReplicationRule, wanted madmin.BucketTarget) (madmin.BucketTarget, []madmin.TargetUpdateType, bool) {
+	if rule.Arn != "" {
+		return madmin.BucketTarget{}, nil, false
+	}
+
+	for _, target := range targets {
+		if !slices.Contains(createdARNs, target.Arn) || slices.Contains(usedARNs, target.Arn) {
+			continue
+		}
+		if target.Credentials == nil || wanted.Credentials == nil || target.Credentials.AccessKey != wanted.Credentials.AccessKey {
+			continue
+		}
+		ops, ok := remoteTargetUpdateOps(target, wanted)
+		if !ok {
+			continue
+		}
+
+		referenced := false
+		for _, existingRule := range rcfg.Rules {
+			referenced = referenced || existingRule.Destination.Bucket == target.Arn
+		}
+		if !referenced {
+			return target, ops, true
+		}
+	}
+
+	return madmin.BucketTarget{}, nil, false
+}
+
+// editableRemoteTarget returns the remote target of the rule in state when the wanted target only differs from it
+// by settings the server can edit in place, along with the edits to make
+func editableRemoteTarget(targets []madmin.BucketTarget, usedARNs []string, rule S3MinioBucketReplicationRule, wanted madmin.BucketTarget) (madmin.BucketTarget, []madmin.TargetUpdateType, bool) {
+	if rule.Arn == "" || slices.Contains(usedARNs, rule.Arn) {
+		return madmin.BucketTarget{}, nil, false
+	}
+
+	for _, target := range targets {
+		if target.Arn != rule.Arn {
+			continue
+		}
+		ops, ok := remoteTargetUpdateOps(target, wanted)
+		return target, ops, ok
+	}
+
+	return madmin.BucketTarget{}, nil, false
+}
+
+// remoteTargetUpdateOps returns the edits turning an existing remote target into the wanted one, as mc admin bucket
+// remote edit would do, or false when a setting which cannot be edited, such as the endpoint, differs. The secret
+// key cannot be read back, the credentials are updated whenever it is known.
+func remoteTargetUpdateOps(existing madmin.BucketTarget, wanted madmin.BucketTarget) ([]madmin.TargetUpdateType, bool) {
+	if normalizeReplicationTargetHost(existing.Endpoint) != normalizeReplicationTargetHost(wanted.Endpoint) || existing.TargetBucket != wanted.TargetBucket || existing.Secure != wanted.Secure ||
+		existing.Region != wanted.Region || existing.Type != wanted.Type {
+		return nil, false
+	}
+
+	ops := []madmin.TargetUpdateType{}
+	if wanted.Credentials != nil && wanted.Credentials.SecretKey != "" {
+		ops = append(ops, madmin.CredentialsUpdateType)
+	} else if existing.Credentials == nil || wanted.Credentials == nil || existing.Credentials.AccessKey != wanted.Credentials.AccessKey {
+		// A new access key without its secret key cannot be set
+		return nil, false
+	}
+	if existing.ReplicationSync != wanted.ReplicationSync {
+		ops = append(ops, madmin.SyncUpdateType)
+	}
+	if existing.BandwidthLimit != wanted.BandwidthLimit {
+		ops = append(ops, madmin.BandwidthLimitUpdateType)
+	}
+	if existing.HealthCheckDuration != wanted.HealthCheckDuration {
+		ops = append(ops, madmin.HealthCheckDurationUpdateType)
+	}
+	if existing.Path != wanted.Path {
+		ops = append(ops, madmin.PathUpdateType)
+	}
+	return ops, true
+}
+
+// addOrEditReplicationRule edits the rule when the server has it, and adds it otherwise. A rule known to the
+// state may have been deleted out of band, it is then added back with the same ID.
+func addOrEditReplicationRule(rcfg *replication.Config, opts replication.Options) error {
+	tags, err := decodeReplicationRuleTags(opts.TagString)
+	if err != nil {
+		return err
+	}
+	// The replication package splits the tags on & and = without decoding them, the filter is set afterwards
+	opts.TagString, opts.IsTagSet = "", false
+
+	edited := false
+	for _, existingRule := range rcfg.Rules {
+		if existingRule.ID == opts.ID {
+			opts.Op = replication.SetOption
+			err = rcfg.EditRule(opts)
+			edited = true
+			break
+		}
+	}
+	if !edited {
+		log.Printf("[DEBUG] Replication rule %q does not exist on the server, adding it", opts.ID)
+		opts.Op = replication.AddOption
+		err = rcfg.AddRule(opts)
+	}
+	if err != nil {
+		return err
+	}
+
+	for i := range rcfg.Rules {
+		if rcfg.Rules[i].ID == opts.ID {
+			rcfg.Rules[i].Filter = replicationRuleFilter(opts.Prefix, tags)
+		}
+	}
+	return nil
+}
+
+// encodeReplicationRuleTags returns the tags of a rule as the TagString of its replication options, URL encoded so
+// the empty values and the reserved characters survive the round trip
+func encodeReplicationRuleTags(tags map[string]string) string {
+	values := url.Values{}
+	for k, v := range tags {
+		values.Set(k, v)
+	}
+	return values.Encode()
+}
+
+// decodeReplicationRuleTags returns the tags of a TagString encoded by encodeReplicationRuleTags, sorted by key
+func decodeReplicationRuleTags(tagString string) ([]replication.Tag, error) {
+	values, err := url.ParseQuery(tagString)
+	if err != nil {
+		return nil, fmt.Errorf("invalid replication rule tags %q: %w", tagString, err)
+	}
+
+	tags := []replication.Tag{}
+	for k := range values {
+		tag := replication.Tag{Key: k, Value: values.Get(k)}
+		if err := tag.Validate(); err != nil {
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+	sort.Slice(tags, func(i, j int) bool { return tags[i].Key < tags[j].Key })
+	return tags, nil
+}
+
+// replicationRuleFilter returns the filter of a rule, laid out as the replication package does: a single tag
+// without prefix is set on its own, the other combinations in an And block
+func replicationRuleFilter(prefix string, tags []replication.Tag) replication.Filter {
+	if len(tags) > 1 || prefix != "" {
+		return replication.Filter{And: replication.And{Prefix: prefix, Tags: tags}}
+	}
+	if len(tags) == 1 {
+		return replication.Filter{Tag: tags[0]}
+	}
+	return replication.Filter{}
+}
+
+// replicationRuleTags returns the tags of the filter of a rule, which Rule.Tags joins without encoding them
+func replicationRuleTags(rule replication.Rule) map[string]string {
+	tags := map[string]string{}
+	if len(rule.Filter.And.Tags) != 0 {
+		for _, tag := range rule.Filter.And.Tags {
+			if !tag.IsEmpty() {
+				tags[tag.Key] = tag.Value
+			}
+		}
+	} else if !rule.Filter.Tag.IsEmpty() {
+		tags[rule.Filter.Tag.Key] = rule.Filter.Tag.Value
+	}
+	return tags
+}
+
+func bucketReplicationRuleOptions(rule S3MinioBucketReplicationRule, arn string) replication.Options {
+	opts := replication.Options{
+		TagString:               encodeReplicationRuleTags(rule.Tags),
+		IsTagSet:                len(rule.Tags) != 0,
+		StorageClass:            rule.Target.StorageClass,
+		Priority:                strconv.Itoa(int(math.Abs(float64(rule.Priority)))),
+		Prefix:                  rule.Prefix,
+		RuleStatus:              toEnableFlag(rule.Enabled),
+		ID:                      rule.Id,
+		DestBucket:              arn,
+		ReplicateDeleteMarkers:  toEnableFlag(rule.DeleteMarkerReplication),
+		ReplicateDeletes:        toEnableFlag(rule.DeleteReplication),
+		ReplicaSync:             toEnableFlag(rule.ReplicaModifications),
+		ExistingObjectReplicate: toEnableFlag(rule.ExistingObjectReplication),
+	}
+	if rule.DeleteMarkerReplicationDefault {
+		// An empty flag keeps the existing value on edit and lets the server decide on add
+		opts.ReplicateDeleteMarkers = ""
+	}
+	return opts
+}
+
+// renderBucketReplicationRules returns the JSON of the replication configuration generated from the rules. Every rule must
+// have its ID and ARN set.
+func renderBucketReplicationRules(rules []S3MinioBucketReplicationRule) (string, error) {
+	cfg := replication.Config{}
+	for _, rule := range rules {
+		if err := addOrEditReplicationRule(&cfg, bucketReplicationRuleOptions(rule, rule.Arn)); err != nil {
+			return "", err
+		}
+	}
+
+	rendered, err := json.Marshal(cfg)
+	if err != nil {
+		return "", err
+	}
+	return string(rendered), nil
+}
+
+func minioRenderBucketReplicationRules(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.HasChange("rule") {
+		return nil
+	}
+
+	rules, _ := d.Get("rule").([]interface{})
+	if !d.NewValueKnown("rule") {
+		return d.SetNewComputed("rendered_rules")
+	}
+
+	for i := range rules {
+		// ID and ARN of new rules are generated, and a target change may produce a new ARN
+		if d.Get(fmt.Sprintf("rule.%d.id", i)).(string) == "" ||
+			d.Get(fmt.Sprintf("rule.%d.arn", i)).(string) == "" ||
+			d.HasChange(fmt.Sprintf("rule.%d.target", i)) {
+			return d.SetNewComputed("rendered_rules")
+		}
+	}
+
+	replicationRules, diags := getBucketReplicationConfig(rules)
+	diags = append(diags, applyReplicationRuleMetadataSync(replicationRules, d.GetRawConfig())...)
+	if diags.HasError() || applyReplicationPriorityStrategy(replicationRules, replicationPriorityStrategy(d)) != nil {
+		// Errors are reported during apply
+		return d.SetNewComputed("rendered_rules")
+	}
+
+	rendered, err := renderBucketReplicationRules(replicationRules)
+	if err != nil {
+		return fmt.Errorf("unable to render the replication rules: %w", err)
+	}
+	return d.SetNew("rendered_rules", rendered)
+}
+
+func getBucketReplicationConfig(v []interface{}) (result []S3MinioBucketReplicationRule, errs diag.Diagnostics) {
+	if len(v) == 0 || v[0] == nil {
+		return
+	}
+
+	result = make([]S3MinioBucketReplicationRule, len(v))
+	for i, rule := range v {
+		var ok bool
+		tfMap, ok := rule.(map[string]interface{})
+		if !ok {
+			errs = append(errs, diag.Errorf("Unable to extra the rule %d", i)...)
+			continue
+		}
+		log.Printf("[DEBUG] rule[%d] contains %v", i, tfMap)
+
+		result[i].Arn, _ = tfMap["arn"].(string)
+		result[i].Id, _ = tfMap["id"].(string)
+
+		if result[i].Enabled, ok = tfMap["enabled"].(bool); !ok {
+			log.Printf("[DEBUG] rule[%d].enabled omitted. Defaulting to true", i)
+			result[i].Enabled = true
+		}
+
+		if result[i].Priority, ok = tfMap["priority"].(int); !ok || result[i].Priority == 0 {
+			// Since priorities are always positive, we use a negative value to indicate they must be assigned
+			// according to priority_strategy, see applyReplicationPriorityStrategy
+			result[i].Priority = -i - 1
+			log.Printf("[DEBUG] rule[%d].priority omitted", i)
+		}
+
+		result[i].Prefix, _ = tfMap["prefix"].(string)
+
+		if tags, ok := tfMap["tags"].(map[string]interface{}); ok {
+			log.Printf("[DEBUG] rule[%d].tags map contains: %v", i, tags)
+			tagMap := map[string]string{}
+			for k, val := range tags {
+				var valOk bool
+				tagMap[k], valOk = val.(string)
+				if !valOk {
+					errs = append(errs, diag.Errorf("rule[%d].tags[%s] value must be a string, not a %s", i, k, reflect.TypeOf(val))...)
+				}
+			}
+			result[i].Tags = tagMap
+		} else {
+			errs = append(errs, diag.Errorf("unable to extarct rule[%d].tags of type %s", i, reflect.TypeOf(tfMap["tags"]))...)
+		}
+
+		log.Printf("[DEBUG] rule[%d].tags are: %v", i, result[i].Tags)
+
+		result[i].DeleteReplication, ok = tfMap["delete_replication"].(bool)
+		result[i].DeleteReplication = result[i].DeleteReplication && ok
+		result[i].DeleteMarkerReplication, ok = tfMap["delete_marker_replication"].(bool)
+		result[i].DeleteMarkerReplication = result[i].DeleteMarkerReplication && ok
+		result[i].ExistingObjectReplication, ok = tfMap["existing_object_replication"].(bool)
+		result[i].ExistingObjectReplication = result[i].ExistingObjectReplication && ok
+		result[i].ReplicaModifications, ok = tfMap["replica_modifications"].(bool)
+		result[i].ReplicaModifications = result[i].ReplicaModifications && ok
+
+		var targets []interface{}
+		targets, _ = tfMap["target"].([]interface{})
+		if result[i].RemoteTargetArn, _ = tfMap["remote_target_arn"].(string); result[i].RemoteTargetArn != "" {
+			if len(targets) != 0 {
+				errs = append(errs, diag.Errorf("rule[%d].target and rule[%d].remote_target_arn cannot be both set", i, i)...)
+			}
+			result[i].Arn = result[i].RemoteTargetArn
+			continue
+		}
+		if len(targets) != 1 {
+			errs = append(errs, diag.Errorf("rule[%d] requires either a target or a remote_target_arn", i)...)
 			continue
 		}
 		var target map[string]interface{}
@@ -623,11 +2206,20 @@ func getBucketReplicationConfig(v []interface{}) (result []S3MinioBucketReplicat
 			errs = append(errs, diag.Errorf("rule[%d].target.bucket cannot be omitted", i)...)
 		}
 
+		if result[i].Target.Type, _ = target["type"].(string); result[i].Target.Type == "" {
+			result[i].Target.Type = replicationTargetTypeMinIO
+		}
+		if result[i].Target.Type == replicationTargetTypeS3 {
+			// Only MinIO targets sync the replica modifications back, the server default must not apply
+			result[i].ReplicaModifications = false
+		}
+
 		result[i].Target.StorageClass, _ = target["storage_class"].(string)
 
 		if result[i].Target.Host, ok = target["host"].(string); !ok {
 			errs = append(errs, diag.Errorf("rule[%d].target.host cannot be omitted", i)...)
 		}
+		result[i].Target.Host = normalizeReplicationTargetHost(result[i].Target.Host)
 
 		result[i].Target.Path, _ = target["path"].(string)
 		result[i].Target.Region, _ = target["region"].(string)
@@ -648,6 +2240,12 @@ func getBucketReplicationConfig(v []interface{}) (result []S3MinioBucketReplicat
 			})
 		}
 
+		result[i].Target.ProbeInsecureSkipVerify, _ = target["probe_insecure_skip_verify"].(bool)
+		result[i].Target.ProbeCACertPEM, _ = target["probe_cacert_pem"].(string)
+		if !result[i].Target.Secure && (result[i].Target.ProbeInsecureSkipVerify || result[i].Target.ProbeCACertPEM != "") {
+			errs = append(errs, diag.Errorf("rule[%d].target.probe_insecure_skip_verify and rule[%d].target.probe_cacert_pem require rule[%d].target.secure", i, i, i)...)
+		}
+
 		result[i].Target.Syncronous, ok = target["syncronous"].(bool)
 		result[i].Target.Syncronous = result[i].Target.Syncronous && ok
 
@@ -655,7 +2253,7 @@ func getBucketReplicationConfig(v []interface{}) (result []S3MinioBucketReplicat
 		var bandwidth uint64
 		var err error
 		if bandwidthStr, ok = target["bandwidth_limt"].(string); ok {
-			bandwidth, err = humanize.ParseBytes(bandwidthStr)
+			bandwidth, err = parseBandwidthLimit(bandwidthStr)
 			if err != nil {
 				log.Printf("[WARN] invalid bandwidth value %q: %v", result[i].Target.BandwidthLimit, err)
 				errs = append(errs, diag.Errorf("rule[%d].target.bandwidth_limt is invalid. Make sure to use k, m, g as preffix only", i)...)
@@ -666,7 +2264,7 @@ func getBucketReplicationConfig(v []interface{}) (result []S3MinioBucketReplicat
 
 		var healthcheckDuration string
 		if healthcheckDuration, ok = target["health_check_period"].(string); ok {
-			result[i].Target.HealthCheckPeriod, err = time.ParseDuration(healthcheckDuration)
+			result[i].Target.HealthCheckPeriod, err = parseReplicationHealthCheckPeriod(healthcheckDuration)
 			if err != nil {
 				log.Printf("[WARN] invalid healthcheck value %q: %v", result[i].Target.HealthCheckPeriod, err)
 				errs = append(errs, diag.Errorf("rule[%d].target.health_check_period is invalid. Make sure to use a valid golang time duration notation", i)...)
@@ -693,3 +2291,55 @@ func getBucketReplicationConfig(v []interface{}) (result []S3MinioBucketReplicat
 	}
 	return
 }
+
+// replicationPriorityStrategy returns the priority strategy of the resource, including imported ones
+func replicationPriorityStrategy(d interface{ Get(string) interface{} }) string {
+	if strategy, _ := d.Get("priority_strategy").(string); strategy != "" {
+		return strategy
+	}
+	return replicationPriorityIndex
+}
+
+// replicationRulePriorities returns the priority of every rule, the omitted ones (zero or negative) being
+// assigned according to strategy
+func replicationRulePriorities(priorities []int, strategy string) ([]int, error) {
+	highest := 0
+	for _, priority := range priorities {
+		if priority > highest {
+			highest = priority
+		}
+	}
+
+	resolved := make([]int, len(priorities))
+	for i, priority := range priorities {
+		switch {
+		case priority > 0:
+			resolved[i] = priority
+		case strategy == replicationPriorityExplicit:
+			return nil, fmt.Errorf("rule[%d].priority must be set when priority_strategy is %q", i, replicationPriorityExplicit)
+		case strategy == replicationPriorityAutoIncrement:
+			highest++
+			resolved[i] = highest
+		default:
+			resolved[i] = i + 1
+		}
+	}
+	return resolved, nil
+}
+
+// applyReplicationPriorityStrategy assigns the omitted priorities of the rules
+func applyReplicationPriorityStrategy(rules []S3MinioBucketReplicationRule, strategy string) error {
+	priorities := make([]int, len(rules))
+	for i, rule := range rules {
+		priorities[i] = rule.Priority
+	}
+
+	resolved, err := replicationRulePriorities(priorities, strategy)
+	if err != nil {
+		return err
+	}
+	for i := range rules {
+		rules[i].Priority = resolved[i]
+	}
+	return nil
+}
@@ -2,18 +2,29 @@ package minio
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"reflect"
+	"regexp"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/dustin/go-humanize"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/minio/madmin-go"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
 	"github.com/minio/minio-go/v7/pkg/replication"
+	"golang.org/x/exp/slices"
 )
 
 func TestAccS3BucketReplication_oneway_simple(t *testing.T) {
@@ -74,7 +85,7 @@ resource "minio_s3_bucket_replication" "replication_in_b" {
 								DeleteReplication:         true,
 								DeleteMarkerReplication:   true,
 								ExistingObjectReplication: true,
-								MetadataSync:              false,
+								ReplicaModifications:      false,
 
 								Target: S3MinioBucketReplicationRuleTarget{
 									Bucket:            secondBucketName,
@@ -99,7 +110,6 @@ resource "minio_s3_bucket_replication" "replication_in_b" {
 				ImportStateVerify: true,
 				ImportStateVerifyIgnore: []string{
 					"rule.0.target.0.secret_key",
-					"rule.0.priority", // This is ommited in our test case, so it gets automatically generated and thus mismatch
 				},
 				Config: `
 resource "minio_s3_bucket_replication" "replication_in_b" {
@@ -243,7 +253,7 @@ resource "minio_s3_bucket_replication" "replication_in_all" {
 								DeleteReplication:         true,
 								DeleteMarkerReplication:   false,
 								ExistingObjectReplication: false,
-								MetadataSync:              false,
+								ReplicaModifications:      false,
 
 								Target: S3MinioBucketReplicationRuleTarget{
 									Bucket:            secondBucketName,
@@ -268,7 +278,7 @@ resource "minio_s3_bucket_replication" "replication_in_all" {
 								DeleteReplication:         false,
 								DeleteMarkerReplication:   true,
 								ExistingObjectReplication: true,
-								MetadataSync:              false,
+								ReplicaModifications:      false,
 
 								Target: S3MinioBucketReplicationRuleTarget{
 									Bucket:            thirdBucketName,
@@ -295,7 +305,7 @@ resource "minio_s3_bucket_replication" "replication_in_all" {
 								DeleteReplication:         true,
 								DeleteMarkerReplication:   false,
 								ExistingObjectReplication: true,
-								MetadataSync:              false,
+								ReplicaModifications:      false,
 
 								Target: S3MinioBucketReplicationRuleTarget{
 									Bucket:            fourthBucketName,
@@ -397,10 +407,213 @@ depends_on = [
 ]
 }`,
 			},
+			{
+				// A single rule is adopted, the others being left unmanaged
+				ResourceName:      "minio_s3_bucket_replication.replication_in_all",
+				ImportState:       true,
+				ImportStateIdFunc: testAccBucketReplicationRuleImportID("minio_s3_bucket_replication.replication_in_all", 1),
+				ImportStateCheck: func(states []*terraform.InstanceState) error {
+					if len(states) != 1 {
+						return fmt.Errorf("expected one imported resource, got %d", len(states))
+					}
+					attributes := states[0].Attributes
+					if attributes["rule.#"] != "1" || attributes["ignore_unmanaged_rules"] != "true" || attributes["managed_rule_ids.#"] != "1" {
+						return fmt.Errorf("expected a single managed rule, got %v", attributes)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestAccS3BucketReplication_validateCredentials(t *testing.T) {
+	bucketName := acctest.RandomWithPrefix("tf-acc-test-a")
+	secondBucketName := acctest.RandomWithPrefix("tf-acc-test-b")
+
+	primaryMinioEndpoint := os.Getenv("MINIO_ENDPOINT")
+	secondaryMinioEndpoint := os.Getenv("SECOND_MINIO_ENDPOINT")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckMinioS3BucketDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBucketReplicationConfigLocals(primaryMinioEndpoint, secondaryMinioEndpoint) +
+					testAccBucketReplicationConfigBucket("my_bucket_in_a", "minio", bucketName) +
+					testAccBucketReplicationConfigBucket("my_bucket_in_b", "secondminio", secondBucketName),
+			},
+			{
+				Config: testAccBucketReplicationConfigLocals(primaryMinioEndpoint, secondaryMinioEndpoint) +
+					testAccBucketReplicationConfigBucket("my_bucket_in_a", "minio", bucketName) +
+					testAccBucketReplicationConfigBucket("my_bucket_in_b", "secondminio", secondBucketName) +
+					`
+resource "minio_s3_bucket_replication" "replication_in_b" {
+  bucket               = minio_s3_bucket.my_bucket_in_a.bucket
+  validate_credentials = true
+
+  rule {
+    target {
+        bucket = minio_s3_bucket.my_bucket_in_b.bucket
+        host = local.second_minio_host
+        secure = false
+        access_key = "invalid-access-key"
+        secret_key = "invalid-secret-key"
+    }
+  }
+
+  depends_on = [
+    minio_s3_bucket_versioning.my_bucket_in_a,
+    minio_s3_bucket_versioning.my_bucket_in_b
+  ]
+}`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("unable to access bucket"),
+			},
 		},
 	})
 }
 
+func TestAccS3BucketReplication_enableVersioning(t *testing.T) {
+	bucketName := acctest.RandomWithPrefix("tf-acc-test-a")
+	secondBucketName := acctest.RandomWithPrefix("tf-acc-test-b")
+
+	primaryMinioEndpoint := os.Getenv("MINIO_ENDPOINT")
+	secondaryMinioEndpoint := os.Getenv("SECOND_MINIO_ENDPOINT")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckMinioS3BucketDestroy,
+		Steps: []resource.TestStep{
+			{
+				// Neither bucket has versioning, the replication enables it on both
+				Config: testAccBucketReplicationConfigLocals(primaryMinioEndpoint, secondaryMinioEndpoint) + fmt.Sprintf(`
+resource "minio_s3_bucket" "my_bucket_in_a" {
+  bucket        = %q
+  force_destroy = true
+}
+
+resource "minio_s3_bucket" "my_bucket_in_b" {
+  provider      = secondminio
+  bucket        = %q
+  force_destroy = true
+}
+
+resource "minio_s3_bucket_replication" "replication_in_b" {
+  bucket                   = minio_s3_bucket.my_bucket_in_a.bucket
+  enable_versioning        = true
+  enable_target_versioning = true
+
+  rule {
+    target {
+      bucket     = minio_s3_bucket.my_bucket_in_b.bucket
+      host       = local.second_minio_host
+      secure     = false
+      access_key = %q
+      secret_key = %q
+    }
+  }
+}
+`, bucketName, secondBucketName, os.Getenv("SECOND_MINIO_USER"), os.Getenv("SECOND_MINIO_PASSWORD")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("minio_s3_bucket_replication.replication_in_b", "enable_versioning", "true"),
+					resource.TestCheckResourceAttr("minio_s3_bucket_replication.replication_in_b", "enable_target_versioning", "true"),
+					testAccCheckBucketVersioningEnabled(testAccProvider, bucketName),
+					testAccCheckBucketVersioningEnabled(testAccSecondProvider, secondBucketName),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckBucketVersioningEnabled(provider *schema.Provider, bucket string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		versioning, err := provider.Meta().(*S3MinioClient).S3Client.GetBucketVersioning(context.Background(), bucket)
+		if err != nil {
+			return err
+		}
+		if !versioning.Enabled() {
+			return fmt.Errorf("expected versioning to be enabled on bucket %s, got %q", bucket, versioning.Status)
+		}
+		return nil
+	}
+}
+
+func TestAccS3BucketReplication_duplicatePriority(t *testing.T) {
+	bucketName := acctest.RandomWithPrefix("tf-acc-test-a")
+	secondBucketName := acctest.RandomWithPrefix("tf-acc-test-b")
+
+	primaryMinioEndpoint := os.Getenv("MINIO_ENDPOINT")
+	secondaryMinioEndpoint := os.Getenv("SECOND_MINIO_ENDPOINT")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckMinioS3BucketDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBucketReplicationConfigLocals(primaryMinioEndpoint, secondaryMinioEndpoint) +
+					testAccBucketReplicationConfigBucket("my_bucket_in_a", "minio", bucketName) +
+					testAccBucketReplicationConfigBucket("my_bucket_in_b", "secondminio", secondBucketName),
+			},
+			{
+				Config: testAccBucketReplicationConfigLocals(primaryMinioEndpoint, secondaryMinioEndpoint) +
+					testAccBucketReplicationConfigBucket("my_bucket_in_a", "minio", bucketName) +
+					testAccBucketReplicationConfigBucket("my_bucket_in_b", "secondminio", secondBucketName) +
+					testAccBucketReplicationConfigDuplicatePriority("index"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("rule\\[0\\] and rule\\[1\\] have the same priority 1"),
+			},
+			{
+				Config: testAccBucketReplicationConfigLocals(primaryMinioEndpoint, secondaryMinioEndpoint) +
+					testAccBucketReplicationConfigBucket("my_bucket_in_a", "minio", bucketName) +
+					testAccBucketReplicationConfigBucket("my_bucket_in_b", "secondminio", secondBucketName) +
+					testAccBucketReplicationConfigDuplicatePriority("explicit"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`rule\[0\]\.priority must be set when priority_strategy is "explicit"`),
+			},
+		},
+	})
+}
+
+func testAccBucketReplicationConfigDuplicatePriority(strategy string) string {
+	return fmt.Sprintf(`
+resource "minio_s3_bucket_replication" "replication_in_b" {
+  bucket            = minio_s3_bucket.my_bucket_in_a.bucket
+  priority_strategy = %q
+
+  rule {
+    prefix = "a/"
+    target {
+        bucket = minio_s3_bucket.my_bucket_in_b.bucket
+        host = local.second_minio_host
+        secure = false
+        access_key = "access-key"
+        secret_key = "secret-key"
+    }
+  }
+
+  rule {
+    prefix   = "b/"
+    priority = 1
+    target {
+        bucket = minio_s3_bucket.my_bucket_in_b.bucket
+        host = local.second_minio_host
+        secure = false
+        access_key = "access-key"
+        secret_key = "secret-key"
+    }
+  }
+
+  depends_on = [
+    minio_s3_bucket_versioning.my_bucket_in_a,
+    minio_s3_bucket_versioning.my_bucket_in_b
+  ]
+}`, strategy)
+}
+
 func TestAccS3BucketReplication_twoway_simple(t *testing.T) {
 	name := acctest.RandomWithPrefix("tf-acc-test")
 	primaryMinioEndpoint := os.Getenv("MINIO_ENDPOINT")
@@ -448,7 +661,7 @@ func TestAccS3BucketReplication_twoway_simple(t *testing.T) {
                 delete_replication = true
                 delete_marker_replication = true
                 existing_object_replication = true
-                metadata_sync = true
+                replica_modifications = true
             
                 target {
                   bucket = local.bucket_name
@@ -474,7 +687,7 @@ func TestAccS3BucketReplication_twoway_simple(t *testing.T) {
 								DeleteReplication:         true,
 								DeleteMarkerReplication:   true,
 								ExistingObjectReplication: true,
-								MetadataSync:              false,
+								ReplicaModifications:      false,
 
 								Target: S3MinioBucketReplicationRuleTarget{
 									Bucket:            name,
@@ -506,7 +719,7 @@ func TestAccS3BucketReplication_twoway_simple(t *testing.T) {
 								DeleteReplication:         true,
 								DeleteMarkerReplication:   true,
 								ExistingObjectReplication: true,
-								MetadataSync:              false,
+								ReplicaModifications:      false,
 
 								Target: S3MinioBucketReplicationRuleTarget{
 									Bucket:            name,
@@ -633,7 +846,7 @@ func TestAccS3BucketReplication_twoway_complex(t *testing.T) {
 								DeleteReplication:         true,
 								DeleteMarkerReplication:   true,
 								ExistingObjectReplication: true,
-								MetadataSync:              false,
+								ReplicaModifications:      false,
 
 								Target: S3MinioBucketReplicationRuleTarget{
 									Bucket:            name,
@@ -907,8 +1120,8 @@ func testAccCheckBucketHasReplication(n string, config []S3MinioBucketReplicatio
 			if (existingRule.DeleteReplication.Status == replication.Enabled) != rule.DeleteReplication {
 				return fmt.Errorf("Mismatch DeleteReplication:\n\nexpected: %v\n\ngot: %v", (existingRule.DeleteReplication.Status == replication.Enabled), rule.DeleteReplication)
 			}
-			if (existingRule.SourceSelectionCriteria.ReplicaModifications.Status == replication.Enabled) != rule.MetadataSync {
-				return fmt.Errorf("Mismatch SourceSelectionCriteria:\n\nexpected: %v\n\ngot: %v", (existingRule.SourceSelectionCriteria.ReplicaModifications.Status == replication.Enabled), rule.MetadataSync)
+			if (existingRule.SourceSelectionCriteria.ReplicaModifications.Status == replication.Enabled) != rule.ReplicaModifications {
+				return fmt.Errorf("Mismatch SourceSelectionCriteria:\n\nexpected: %v\n\ngot: %v", (existingRule.SourceSelectionCriteria.ReplicaModifications.Status == replication.Enabled), rule.ReplicaModifications)
 			}
 			if (existingRule.ExistingObjectReplication.Status == replication.Enabled) != rule.ExistingObjectReplication {
 				return fmt.Errorf("Mismatch ExistingObjectReplication:\n\nexpected: %v\n\ngot: %v", (existingRule.ExistingObjectReplication.Status == replication.Enabled), rule.ExistingObjectReplication)
@@ -925,20 +1138,11 @@ func testAccCheckBucketHasReplication(n string, config []S3MinioBucketReplicatio
 			if existingRule.Prefix() != rule.Prefix {
 				return fmt.Errorf("Mismatch Prefix:\n\nexpected: %v\n\ngot: %v", existingRule.Prefix(), rule.Prefix)
 			}
-			tags := strings.Split(existingRule.Tags(), "&")
-			for i, v := range tags {
-				if v != "" {
-					continue
-				}
-				tags = append(tags[:i], tags[i+1:]...)
-			}
+			tags := replicationRuleTags(existingRule)
 			if len(tags) != len(rule.Tags) {
 				return fmt.Errorf("Mismatch tags:\n\nexpected: %v (size %d)\n\ngot: %v (size %d)", tags, len(tags), rule.Tags, len(rule.Tags))
 			}
-			for _, kv := range tags {
-				val := strings.SplitN(kv, "=", 2)
-				k := val[0]
-				v := val[1]
+			for k, v := range tags {
 				if cv, ok := rule.Tags[k]; !ok || v != cv {
 					return fmt.Errorf("Mismatch tags:\n\nexpected: %s=%q\n\ngot: %s=%q (found: %t)", k, v, k, cv, ok)
 				}
@@ -986,11 +1190,7 @@ func testAccCheckBucketHasReplication(n string, config []S3MinioBucketReplicatio
 			if existingTarget.Secure != rule.Target.Secure {
 				return fmt.Errorf("Mismatch Secure:\n\nexpected: %v\n\ngot: %v", existingTarget.Secure, rule.Target.Secure)
 			}
-			bucket := rule.Target.Bucket
-			cleanPath := strings.TrimPrefix(strings.TrimPrefix(rule.Target.Path, "/"), ".")
-			if cleanPath != "" {
-				bucket = cleanPath + "/" + rule.Target.Bucket
-			}
+			bucket := replicationTargetBucketPath(rule.Target.Path, rule.Target.Bucket)
 			if existingTarget.TargetBucket != bucket {
 				return fmt.Errorf("Mismatch TargetBucket:\n\nexpected: %v\n\ngot: %v", existingTarget.TargetBucket, bucket)
 			}
@@ -1012,3 +1212,930 @@ func testAccCheckBucketHasReplication(n string, config []S3MinioBucketReplicatio
 		return nil
 	}
 }
+
+func TestReplicationRuleAttributeIsNull(t *testing.T) {
+	rawConfig := cty.ObjectVal(map[string]cty.Value{
+		"rule": cty.ListVal([]cty.Value{
+			cty.ObjectVal(map[string]cty.Value{
+				"delete_marker_replication": cty.NullVal(cty.Bool),
+			}),
+			cty.ObjectVal(map[string]cty.Value{
+				"delete_marker_replication": cty.False,
+			}),
+		}),
+	})
+
+	if !replicationRuleAttributeIsNull(rawConfig, 0, "delete_marker_replication") {
+		t.Errorf("expected rule#0 delete_marker_replication to be null")
+	}
+	if replicationRuleAttributeIsNull(rawConfig, 1, "delete_marker_replication") {
+		t.Errorf("expected rule#1 delete_marker_replication to be set")
+	}
+	if replicationRuleAttributeIsNull(rawConfig, 2, "delete_marker_replication") {
+		t.Errorf("expected missing rule#2 not to be reported as null")
+	}
+	if replicationRuleAttributeIsNull(cty.NullVal(rawConfig.Type()), 0, "delete_marker_replication") {
+		t.Errorf("expected null configuration not to be reported as null")
+	}
+
+	if !replicationRuleKeyIsNull(rawConfig, "rule.0.delete_marker_replication") {
+		t.Errorf("expected the omitted rule.0.delete_marker_replication to keep its server default")
+	}
+	if replicationRuleKeyIsNull(rawConfig, "rule.1.delete_marker_replication") || replicationRuleKeyIsNull(rawConfig, "rule.#") {
+		t.Errorf("expected the configured attributes to be planned")
+	}
+}
+
+func TestRenderBucketReplicationRules(t *testing.T) {
+	rules := []S3MinioBucketReplicationRule{
+		{
+			Id:                      "cgf7bsb8k4nhb2rsf3lg",
+			Arn:                     "arn:minio:replication::7a3b7e1f-8b61-4a3c-9f3c-0b0f8c2c7c1e:target",
+			Enabled:                 true,
+			Priority:                -1,
+			Prefix:                  "logs/",
+			Tags:                    map[string]string{"b": "2", "a": "1"},
+			DeleteReplication:       true,
+			DeleteMarkerReplication: true,
+		},
+	}
+
+	rendered, err := renderBucketReplicationRules(rules)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	var cfg replication.Config
+	if err := json.Unmarshal([]byte(rendered), &cfg); err != nil {
+		t.Fatalf("rendered rules are not valid JSON: %s", err)
+	}
+	if len(cfg.Rules) != 1 {
+		t.Fatalf("expected 1 rule, got %d", len(cfg.Rules))
+	}
+
+	rule := cfg.Rules[0]
+	if rule.ID != rules[0].Id || rule.Priority != 1 || rule.Destination.Bucket != rules[0].Arn {
+		t.Errorf("unexpected rule: %+v", rule)
+	}
+	if rule.Tags() != "a=1&b=2" || rule.Prefix() != "logs/" {
+		t.Errorf("unexpected filter: tags=%q prefix=%q", rule.Tags(), rule.Prefix())
+	}
+	if rule.DeleteReplication.Status != replication.Enabled || rule.DeleteMarkerReplication.Status != replication.Enabled {
+		t.Errorf("unexpected delete replication: %+v", rule)
+	}
+
+	again, _ := renderBucketReplicationRules(rules)
+	if again != rendered {
+		t.Errorf("rendering is not stable:\n%s\n%s", rendered, again)
+	}
+}
+
+func TestReplicationTargetTransport(t *testing.T) {
+	tr, err := replicationTargetTransport(S3MinioBucketReplicationRuleTarget{Secure: true, ProbeInsecureSkipVerify: true})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if !tr.TLSClientConfig.InsecureSkipVerify {
+		t.Error("expected the target certificate verification to be skipped")
+	}
+
+	tr, err = replicationTargetTransport(S3MinioBucketReplicationRuleTarget{Secure: true})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if tr.TLSClientConfig.InsecureSkipVerify || tr.TLSClientConfig.RootCAs != nil {
+		t.Error("expected the system certificates to be used")
+	}
+
+	if _, err := replicationTargetTransport(S3MinioBucketReplicationRuleTarget{Secure: true, ProbeCACertPEM: "not a certificate"}); err == nil {
+		t.Error("expected an invalid CA certificate to be rejected")
+	}
+}
+
+func TestCheckReplicationTargetOnline(t *testing.T) {
+	now := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	onlineTarget := madmin.BucketTarget{Endpoint: strings.TrimPrefix(server.URL, "http://")}
+
+	online, offlineSince, err := checkReplicationTargetOnline(context.Background(), onlineTarget, map[string]interface{}{}, "2023-06-01T11:30:00Z", time.Hour, now)
+	if err != nil || !online || offlineSince != "" {
+		t.Errorf("expected the target to be back online, got %t, %q, %v", online, offlineSince, err)
+	}
+
+	// The endpoint is not listening anymore
+	server.Close()
+	offlineTarget := onlineTarget
+
+	online, offlineSince, err = checkReplicationTargetOnline(context.Background(), offlineTarget, map[string]interface{}{}, "", time.Hour, now)
+	if err != nil || online || offlineSince != "2023-06-01T12:00:00Z" {
+		t.Errorf("expected the target to be found offline, got %t, %q, %v", online, offlineSince, err)
+	}
+
+	// The date is kept by the next refreshes, the state does not change
+	online, offlineSince, err = checkReplicationTargetOnline(context.Background(), offlineTarget, map[string]interface{}{}, "2023-06-01T11:30:00Z", time.Hour, now)
+	if err != nil || online || offlineSince != "2023-06-01T11:30:00Z" {
+		t.Errorf("expected the target to be offline within the threshold, got %t, %q, %v", online, offlineSince, err)
+	}
+
+	_, offlineSince, err = checkReplicationTargetOnline(context.Background(), offlineTarget, map[string]interface{}{}, "2023-06-01T10:30:00Z", time.Hour, now)
+	if err == nil || !strings.Contains(err.Error(), "for 1h30m") || offlineSince != "2023-06-01T10:30:00Z" {
+		t.Errorf("expected the target to be reported offline, got %q, %v", offlineSince, err)
+	}
+}
+
+func TestFilterReplicationRules(t *testing.T) {
+	rules := []replication.Rule{{ID: "managed-1"}, {ID: "mc-rule"}, {ID: "managed-2"}}
+	ids := []string{"managed-1", "managed-2", "removed"}
+
+	managed := filterReplicationRules(rules, ids, true)
+	if len(managed) != 2 || managed[0].ID != "managed-1" || managed[1].ID != "managed-2" {
+		t.Errorf("expected the managed rules, got %v", managed)
+	}
+
+	unmanaged := filterReplicationRules(rules, ids, false)
+	if len(unmanaged) != 1 || unmanaged[0].ID != "mc-rule" {
+		t.Errorf("expected the unmanaged rules, got %v", unmanaged)
+	}
+}
+
+func TestReplicationCache(t *testing.T) {
+	requests := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := r.URL.Query()["location"]; ok {
+			_, _ = w.Write([]byte(`<LocationConstraint>us-east-1</LocationConstraint>`))
+			return
+		}
+		requests[r.URL.Path]++
+		if strings.HasSuffix(r.URL.Path, "/list-remote-targets") {
+			_, _ = w.Write([]byte(`[{"arn":"arn:minio:replication::1:target"}]`))
+			return
+		}
+		_, _ = w.Write([]byte(`<ReplicationConfiguration><Rule><ID>rule-1</ID><Status>Enabled</Status><Priority>1</Priority></Rule></ReplicationConfiguration>`))
+	}))
+	defer server.Close()
+
+	config := &S3MinioConfig{S3HostPort: strings.TrimPrefix(server.URL, "http://"), S3UserAccess: "minio", S3UserSecret: "minio123", S3Region: "us-east-1", S3APISignature: "v4"}
+	client, err := config.NewClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cache := client.(*S3MinioClient).ReplicationCache
+
+	for i := 0; i < 2; i++ {
+		rcfg, err := cache.GetBucketReplication(context.Background(), "bucket")
+		if err != nil || len(rcfg.Rules) != 1 || rcfg.Rules[0].ID != "rule-1" {
+			t.Fatalf("unexpected replication configuration %v, %v", rcfg, err)
+		}
+		// Callers own the returned rules
+		rcfg.Rules[0].ID = "changed"
+
+		targets, err := cache.ListRemoteTargets(context.Background(), "bucket")
+		if err != nil || len(targets) != 1 {
+			t.Fatalf("unexpected remote targets %v, %v", targets, err)
+		}
+	}
+	if requests["/bucket/"] != 1 || requests["/minio/admin/v3/list-remote-targets"] != 1 {
+		t.Errorf("expected a single request of each kind, got %v", requests)
+	}
+
+	cache.Invalidate("bucket")
+	if _, err := cache.GetBucketReplication(context.Background(), "bucket"); err != nil {
+		t.Fatal(err)
+	}
+	if requests["/bucket/"] != 2 {
+		t.Errorf("expected the configuration to be fetched again after invalidation, got %v", requests)
+	}
+}
+
+func TestReplicationRulePriorities(t *testing.T) {
+	cases := []struct {
+		strategy string
+		input    []int
+		expected []int
+		err      string
+	}{
+		{replicationPriorityIndex, []int{0, 10, -3}, []int{1, 10, 3}, ""},
+		{replicationPriorityAutoIncrement, []int{0, 10, 0, 5}, []int{11, 10, 12, 5}, ""},
+		{replicationPriorityAutoIncrement, []int{0, 0}, []int{1, 2}, ""},
+		{replicationPriorityExplicit, []int{3, 1}, []int{3, 1}, ""},
+		{replicationPriorityExplicit, []int{3, 0}, nil, `rule[1].priority must be set when priority_strategy is "explicit"`},
+	}
+
+	for _, c := range cases {
+		resolved, err := replicationRulePriorities(c.input, c.strategy)
+		if c.err != "" {
+			if err == nil || err.Error() != c.err {
+				t.Errorf("%s %v: expected error %q, got %v", c.strategy, c.input, c.err, err)
+			}
+			continue
+		}
+		if err != nil || !reflect.DeepEqual(resolved, c.expected) {
+			t.Errorf("%s %v: expected %v, got %v, %v", c.strategy, c.input, c.expected, resolved, err)
+		}
+	}
+}
+
+func TestFindOrphanRemoteTarget(t *testing.T) {
+	target := func(arn string, endpoint string, accessKey string) madmin.BucketTarget {
+		return madmin.BucketTarget{
+			Arn:          arn,
+			Endpoint:     endpoint,
+			TargetBucket: "bucket",
+			Type:         madmin.ReplicationService,
+			Credentials:  &madmin.Credentials{AccessKey: accessKey},
+		}
+	}
+	targets := []madmin.BucketTarget{
+		target("arn:used", "b:9000", "key"),
+		target("arn:claimed", "b:9000", "key"),
+		target("arn:external", "b:9000", "key"),
+		target("arn:other-key", "b:9000", "other"),
+		target("arn:other-host", "c:9000", "key"),
+		target("arn:orphan", "b:9000", "key"),
+	}
+	created := []string{"arn:used", "arn:claimed", "arn:other-key", "arn:other-host", "arn:orphan"}
+	rcfg := replication.Config{Rules: []replication.Rule{{ID: "rule", Destination: replication.Destination{Bucket: "arn:used"}}}}
+	rule := S3MinioBucketReplicationRule{Target: S3MinioBucketReplicationRuleTarget{Host: "b:9000", Bucket: "bucket"}}
+	wanted := target("", "b:9000", "key")
+
+	orphan, ops, ok := findOrphanRemoteTarget(targets, rcfg, []string{"arn:claimed", ""}, created, rule, wanted)
+	if !ok || orphan.Arn != "arn:orphan" || len(ops) != 0 {
+		t.Errorf("expected the orphan target to be found without edits, got %v, %v, %t", orphan, ops, ok)
+	}
+
+	if _, _, ok := findOrphanRemoteTarget(targets, rcfg, []string{"arn:claimed", "arn:orphan"}, created, rule, wanted); ok {
+		t.Error("expected no target once every orphan is claimed")
+	}
+
+	if _, _, ok := findOrphanRemoteTarget(targets, rcfg, []string{"arn:claimed"}, []string{"arn:used", "arn:claimed"}, rule, wanted); ok {
+		t.Error("expected the targets not created by the resource to be left alone")
+	}
+
+	wanted.BandwidthLimit = 100
+	wanted.Credentials = &madmin.Credentials{AccessKey: "key", SecretKey: "secret"}
+	orphan, ops, ok = findOrphanRemoteTarget(targets, rcfg, []string{"arn:claimed"}, created, rule, wanted)
+	if !ok || orphan.Arn != "arn:orphan" || !reflect.DeepEqual(ops, []madmin.TargetUpdateType{madmin.CredentialsUpdateType, madmin.BandwidthLimitUpdateType}) {
+		t.Errorf("expected the orphan target to be found with its edits, got %v, %v, %t", orphan, ops, ok)
+	}
+
+	wanted.Secure = true
+	if _, _, ok := findOrphanRemoteTarget(targets, rcfg, []string{"arn:claimed"}, created, rule, wanted); ok {
+		t.Error("expected no target when a setting which cannot be edited differs")
+	}
+
+	rule.Arn = "arn:existing"
+	if _, _, ok := findOrphanRemoteTarget(targets, rcfg, nil, created, rule, target("", "b:9000", "key")); ok {
+		t.Error("expected existing rules to keep their own target")
+	}
+}
+
+func TestOrphanRemoteTargets(t *testing.T) {
+	targets := []madmin.BucketTarget{
+		{Arn: "arn:used"},
+		{Arn: "arn:claimed"},
+		{Arn: "arn:orphan"},
+		{Arn: "arn:dropped"},
+	}
+	rules := []replication.Rule{
+		{ID: "rule", Destination: replication.Destination{Bucket: "arn:used"}},
+		{ID: "dropped", Destination: replication.Destination{Bucket: "arn:dropped"}},
+	}
+
+	orphans := orphanRemoteTargets(targets, rules, []string{"arn:claimed"})
+	if !reflect.DeepEqual(orphans, []string{"arn:orphan"}) {
+		t.Errorf("expected only the unreferenced target to be an orphan, got %v", orphans)
+	}
+
+	if orphans := orphanRemoteTargets(targets, rules, []string{"arn:claimed", "arn:orphan"}); len(orphans) != 0 {
+		t.Errorf("expected no orphan once every target is claimed, got %v", orphans)
+	}
+}
+
+func TestReplicationTargetBucketPath(t *testing.T) {
+	cases := []struct {
+		path         string
+		targetBucket string
+		normalized   string
+	}{
+		{"", "bucket", ""},
+		{"/", "bucket", ""},
+		{".", "bucket", ""},
+		{"./", "bucket", ""},
+		{"folder", "folder/bucket", "folder"},
+		{"/folder/", "folder/bucket", "folder"},
+		{"./folder", "folder/bucket", "folder"},
+		{"/team/a//replicas/", "team/a/replicas/bucket", "team/a/replicas"},
+	}
+
+	for _, c := range cases {
+		if actual := replicationTargetBucketPath(c.path, "bucket"); actual != c.targetBucket {
+			t.Errorf("expected path %q to give target bucket %q, got %q", c.path, c.targetBucket, actual)
+		}
+
+		p, bucket := splitReplicationTargetBucket(c.targetBucket)
+		if p != c.normalized || bucket != "bucket" {
+			t.Errorf("expected target bucket %q to be split into %q and bucket, got %q and %q", c.targetBucket, c.normalized, p, bucket)
+		}
+		if actual := normalizeReplicationTargetPath(c.path); actual != c.normalized {
+			t.Errorf("expected path %q to be normalized to %q, got %q", c.path, c.normalized, actual)
+		}
+	}
+
+	if p, bucket := splitReplicationTargetBucket("/folder/bucket"); p != "folder" || bucket != "bucket" {
+		t.Errorf("expected a leading slash to be ignored, got %q and %q", p, bucket)
+	}
+}
+
+func TestParseBandwidthLimit(t *testing.T) {
+	cases := []struct {
+		value    string
+		expected uint64
+		valid    bool
+	}{
+		{"0", 0, true},
+		{"100M", 100000000, true},
+		{"100MB", 100000000, true},
+		{"100 MB", 100000000, true},
+		{"100MiB", 104857600, true},
+		{"1.5G", 1500000000, true},
+		{"1.5GiB", 1610612736, true},
+		{"100Mb", 0, false},
+		{"100mb", 0, false},
+		{"1.5Gib", 0, false},
+		{"-100M", 0, false},
+		{"1.5.0G", 0, false},
+		{"100Mbps", 0, false},
+	}
+
+	for _, c := range cases {
+		actual, err := parseBandwidthLimit(c.value)
+		if c.valid && (err != nil || actual != c.expected) {
+			t.Errorf("expected %q to be parsed to %d, got %d, %v", c.value, c.expected, actual, err)
+		}
+		if !c.valid && err == nil {
+			t.Errorf("expected %q to be rejected, got %d", c.value, actual)
+		}
+	}
+}
+
+func TestReplicationRuleDefaults(t *testing.T) {
+	for version, replicaModifications := range map[string]bool{
+		"2023-03-20T20:16:18Z":         true,
+		"RELEASE.2021-04-22T15-44-28Z": true,
+		"2021-03-04T00:53:13Z":         false,
+		"DEVELOPMENT.GOGET":            true,
+	} {
+		defaults := replicationRuleDefaultsForRelease(parseMinioRelease(version))
+		if defaults.ReplicaModifications != replicaModifications {
+			t.Errorf("%s: expected replica modifications default to be %t", version, replicaModifications)
+		}
+		if defaults.DeleteReplication || defaults.DeleteMarkerReplication || defaults.ExistingObjectReplication {
+			t.Errorf("%s: expected the other statuses to be disabled by default, got %+v", version, defaults)
+		}
+	}
+
+	if replicationStatusEnabled("", false) || !replicationStatusEnabled("", true) {
+		t.Error("expected an absent status to use the default")
+	}
+	if !replicationStatusEnabled(replication.Enabled, false) || replicationStatusEnabled(replication.Disabled, true) {
+		t.Error("expected an explicit status to override the default")
+	}
+
+	rule := replication.Rule{
+		DeleteReplication:         replication.DeleteReplication{Status: replication.Disabled},
+		DeleteMarkerReplication:   replication.DeleteMarkerReplication{Status: replication.Disabled},
+		ExistingObjectReplication: replication.ExistingObjectReplication{Status: replication.Disabled},
+	}
+	if !replicationRulesHaveImplicitStatus([]replication.Rule{rule}) {
+		t.Error("expected the missing replica modifications status to be detected")
+	}
+	rule.SourceSelectionCriteria.ReplicaModifications.Status = replication.Enabled
+	if replicationRulesHaveImplicitStatus([]replication.Rule{rule}) {
+		t.Error("expected no implicit status")
+	}
+}
+
+func TestReplicationTargetHost(t *testing.T) {
+	cases := []struct {
+		host       string
+		normalized string
+		valid      bool
+		warning    bool
+	}{
+		{"minio.example.com", "minio.example.com", true, false},
+		{"minio.example.com:9000", "minio.example.com:9000", true, false},
+		{"minio.example.com:9000/", "minio.example.com:9000", true, false},
+		{"https://minio.example.com:9000/", "minio.example.com:9000", true, true},
+		{"[::1]:9000", "[::1]:9000", true, false},
+		{"https://[::1]:9000/", "[::1]:9000", true, true},
+		{"[2001:DB8:0:0::1]:9000", "[2001:db8::1]:9000", true, false},
+		{"[2001:db8::1]", "[2001:db8::1]", true, false},
+		{"2001:db8::1", "[2001:db8::1]", true, true},
+		{"::1", "[::1]", true, true},
+		{"192.168.1.10:9000", "192.168.1.10:9000", true, false},
+		{"[::ffff:192.168.1.10]:9000", "192.168.1.10:9000", true, false},
+		{"[2001:db8::zz]:9000", "[2001:db8::zz]:9000", false, false},
+		{"[::1]:99999", "[::1]:99999", false, false},
+		{"ftp://minio.example.com", "minio.example.com", false, false},
+		{"minio.example.com/bucket", "minio.example.com/bucket", false, false},
+		{"minio.example.com:99999", "minio.example.com:99999", false, false},
+		{"user@minio.example.com", "user@minio.example.com", false, false},
+		{"", "", false, false},
+	}
+
+	for _, c := range cases {
+		if normalized := normalizeReplicationTargetHost(c.host); normalized != c.normalized {
+			t.Errorf("%q: expected %q once normalized, got %q", c.host, c.normalized, normalized)
+		}
+
+		diags := validateReplicationTargetHost(c.host, cty.Path{})
+		if diags.HasError() == c.valid {
+			t.Errorf("%q: expected valid to be %t, got %v", c.host, c.valid, diags)
+		}
+		if c.valid && (len(diags) != 0) != c.warning {
+			t.Errorf("%q: expected warning to be %t, got %v", c.host, c.warning, diags)
+		}
+	}
+}
+
+func TestAddOrEditReplicationRule(t *testing.T) {
+	rule := S3MinioBucketReplicationRule{Id: "rule-1", Enabled: true, Priority: 1, Target: S3MinioBucketReplicationRuleTarget{Bucket: "target"}}
+	arn := "arn:minio:replication::1234:target"
+
+	// The rule is known to the state, but was deleted out of band
+	rcfg := replication.Config{}
+	if err := addOrEditReplicationRule(&rcfg, bucketReplicationRuleOptions(rule, arn)); err != nil {
+		t.Fatalf("unexpected error adding back the rule: %v", err)
+	}
+	if len(rcfg.Rules) != 1 || rcfg.Rules[0].ID != "rule-1" {
+		t.Fatalf("expected the rule to be added with its ID, got %+v", rcfg.Rules)
+	}
+
+	rule.Enabled = false
+	if err := addOrEditReplicationRule(&rcfg, bucketReplicationRuleOptions(rule, arn)); err != nil {
+		t.Fatalf("unexpected error editing the rule: %v", err)
+	}
+	if len(rcfg.Rules) != 1 || rcfg.Rules[0].Status != replication.Disabled {
+		t.Errorf("expected the existing rule to be edited, got %+v", rcfg.Rules)
+	}
+}
+
+func TestParseBucketReplicationImportID(t *testing.T) {
+	for id, want := range map[string][2]string{
+		"bucket":           {"bucket", ""},
+		"bucket:rule-id-1": {"bucket", "rule-id-1"},
+	} {
+		bucket, ruleID, err := parseBucketReplicationImportID(id)
+		if err != nil || bucket != want[0] || ruleID != want[1] {
+			t.Errorf("%s: got (%q, %q, %v), want (%q, %q)", id, bucket, ruleID, err, want[0], want[1])
+		}
+	}
+
+	for _, id := range []string{"", ":rule-id", "bucket:"} {
+		if _, _, err := parseBucketReplicationImportID(id); err == nil {
+			t.Errorf("%q: expected an error", id)
+		}
+	}
+}
+
+func testAccBucketReplicationRuleImportID(resourceName string, index int) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return "", fmt.Errorf("not found: %s", resourceName)
+		}
+		return fmt.Sprintf("%s:%s", rs.Primary.ID, rs.Primary.Attributes[fmt.Sprintf("rule.%d.id", index)]), nil
+	}
+}
+
+func TestApplyReplicationRuleMetadataSync(t *testing.T) {
+	ruleConfig := func(replicaModifications, metadataSync cty.Value) cty.Value {
+		return cty.ObjectVal(map[string]cty.Value{
+			"replica_modifications": replicaModifications,
+			"metadata_sync":         metadataSync,
+		})
+	}
+	rawConfig := cty.ObjectVal(map[string]cty.Value{
+		"rule": cty.ListVal([]cty.Value{
+			ruleConfig(cty.NullVal(cty.Bool), cty.True),
+			ruleConfig(cty.False, cty.NullVal(cty.Bool)),
+			ruleConfig(cty.True, cty.True),
+			ruleConfig(cty.False, cty.True),
+		}),
+	})
+
+	rules := []S3MinioBucketReplicationRule{{}, {}, {ReplicaModifications: true}, {}}
+	diags := applyReplicationRuleMetadataSync(rules, rawConfig)
+
+	if !rules[0].ReplicaModifications {
+		t.Error("expected metadata_sync to apply when replica_modifications is omitted")
+	}
+	if rules[1].ReplicaModifications || !rules[2].ReplicaModifications {
+		t.Errorf("expected replica_modifications to be kept, got %+v", rules)
+	}
+	if len(diags) != 1 || !strings.Contains(diags[0].Summary, "rule[3]") {
+		t.Errorf("expected an error on the conflicting rule[3], got %v", diags)
+	}
+}
+
+func TestCheckReplicationTargetsObjectLock(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := r.URL.Query()["object-lock"]; !ok {
+			w.WriteHeader(http.StatusNotImplemented)
+			return
+		}
+		switch strings.Trim(r.URL.Path, "/") {
+		case "locked-source", "locked-target":
+			fmt.Fprint(w, `<ObjectLockConfiguration><ObjectLockEnabled>Enabled</ObjectLockEnabled></ObjectLockConfiguration>`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `<Error><Code>ObjectLockConfigurationNotFoundError</Code><Message>Object Lock configuration does not exist for this bucket</Message></Error>`)
+		}
+	}))
+	defer server.Close()
+
+	target := func(bucket string, secretKey string) S3MinioBucketReplicationRule {
+		return S3MinioBucketReplicationRule{Target: S3MinioBucketReplicationRuleTarget{
+			Bucket:    bucket,
+			Host:      strings.TrimPrefix(server.URL, "http://"),
+			Region:    "us-east-1",
+			AccessKey: "minio",
+			SecretKey: secretKey,
+		}}
+	}
+	client, err := newReplicationTargetClient(target("locked-source", "minio123").Target)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rules := []S3MinioBucketReplicationRule{
+		target("locked-target", "minio123"),
+		target("unlocked-target", "minio123"),
+		target("unlocked-imported", ""),
+	}
+	diags := checkReplicationTargetsObjectLock(context.Background(), client, "locked-source", rules)
+	if len(diags) != 1 || diags[0].Summary != "rule[1].target has no object lock" {
+		t.Errorf("expected a warning for the unlocked target only, got %+v", diags)
+	}
+
+	if diags := checkReplicationTargetsObjectLock(context.Background(), client, "unlocked-source", rules); len(diags) != 0 {
+		t.Errorf("expected no warning without object lock on the source, got %+v", diags)
+	}
+}
+
+func TestEnsureBucketVersioning(t *testing.T) {
+	var mu sync.Mutex
+	enabled := map[string]bool{"versioned": true}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		bucket := strings.Trim(r.URL.Path, "/")
+		_, objectLock := r.URL.Query()["object-lock"]
+		switch {
+		case objectLock && bucket == "locked":
+			fmt.Fprint(w, `<ObjectLockConfiguration><ObjectLockEnabled>Enabled</ObjectLockEnabled></ObjectLockConfiguration>`)
+		case r.Method == http.MethodGet && enabled[bucket]:
+			fmt.Fprint(w, `<VersioningConfiguration><Status>Enabled</Status></VersioningConfiguration>`)
+		case r.Method == http.MethodGet:
+			fmt.Fprint(w, `<VersioningConfiguration></VersioningConfiguration>`)
+		case r.Method == http.MethodPut && bucket == "locked":
+			w.WriteHeader(http.StatusConflict)
+			fmt.Fprint(w, `<Error><Code>InvalidBucketState</Code><Message>An Object Lock configuration is present on this bucket, so the versioning state cannot be changed.</Message></Error>`)
+		case r.Method == http.MethodPut:
+			enabled[bucket] = true
+		default:
+			w.WriteHeader(http.StatusNotImplemented)
+		}
+	}))
+	defer server.Close()
+
+	client, err := minio.New(strings.TrimPrefix(server.URL, "http://"), &minio.Options{
+		Creds:  credentials.NewStaticV4("minio", "minio123", ""),
+		Region: "us-east-1",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, bucket := range []string{"versioned", "unversioned"} {
+		if err := ensureBucketVersioning(context.Background(), client, bucket); err != nil {
+			t.Errorf("%s: err: %s", bucket, err)
+		}
+		if !enabled[bucket] {
+			t.Errorf("%s: expected versioning to be enabled", bucket)
+		}
+	}
+
+	if err := ensureBucketVersioning(context.Background(), client, "locked"); err == nil || !strings.Contains(err.Error(), "because object lock is Enabled") {
+		t.Errorf("expected the object lock to be reported, got %v", err)
+	}
+}
+
+func TestConfiguredReplicationRule(t *testing.T) {
+	rule := func(id string, arn string, secretKey string) S3MinioBucketReplicationRule {
+		return S3MinioBucketReplicationRule{Id: id, Arn: arn, Target: S3MinioBucketReplicationRuleTarget{SecretKey: secretKey}}
+	}
+	// The rules were reordered in the configuration, the third one is not created yet
+	rules := []S3MinioBucketReplicationRule{
+		rule("rule-b", "arn:minio:replication::b:bucket", "secret-b"),
+		rule("", "arn:minio:replication::a:bucket", "secret-a"),
+		rule("", "", "secret-c"),
+	}
+
+	cases := []struct {
+		id       string
+		arn      string
+		idx      int
+		expected string
+		found    bool
+	}{
+		{"rule-a", "arn:minio:replication::a:bucket", 0, "secret-a", true},
+		{"rule-b", "arn:minio:replication::b:bucket", 1, "secret-b", true},
+		{"rule-c", "arn:minio:replication::c:bucket", 2, "secret-c", true},
+		{"rule-d", "arn:minio:replication::d:bucket", 1, "", false},
+		{"rule-e", "arn:minio:replication::e:bucket", 3, "", false},
+	}
+	for _, c := range cases {
+		configured, found := configuredReplicationRule(rules, c.id, c.arn, c.idx)
+		if found != c.found || configured.Target.SecretKey != c.expected {
+			t.Errorf("rule %s at %d: expected %q (%t), got %q (%t)", c.id, c.idx, c.expected, c.found, configured.Target.SecretKey, found)
+		}
+	}
+}
+
+func TestReplicationRulesReordered(t *testing.T) {
+	rule := func(id string, bucket string, priority int, deleteMarker bool) map[string]interface{} {
+		return map[string]interface{}{
+			"id":                        id,
+			"arn":                       "arn:minio:replication::" + id + ":" + bucket,
+			"priority":                  priority,
+			"delete_marker_replication": deleteMarker,
+			"tags":                      map[string]interface{}{"env": "prod"},
+			"target": []interface{}{map[string]interface{}{
+				"bucket":              bucket,
+				"host":                "minio.example.com:9000",
+				"health_check_period": "30s",
+				"online":              true,
+			}},
+		}
+	}
+	state := []interface{}{rule("a", "bucket-a", 1, true), rule("b", "bucket-b", 2, false)}
+	none := func(int, string) bool { return false }
+
+	// The computed id and arn of the new rules are those previously at their index
+	reordered := []interface{}{rule("a", "bucket-b", 2, false), rule("b", "bucket-a", 1, true)}
+	if !replicationRulesReordered(state, reordered, []int{2, 1}, none) {
+		t.Error("expected swapped rules with explicit priorities to be a reorder")
+	}
+
+	if replicationRulesReordered(state, state, []int{1, 2}, none) {
+		t.Error("expected rules in the same order not to be a reorder")
+	}
+
+	// Priorities resolved from the position change with the order
+	if replicationRulesReordered(state, reordered, []int{1, 2}, none) {
+		t.Error("expected a change of priority not to be a reorder")
+	}
+
+	edited := []interface{}{rule("a", "bucket-b", 2, false), rule("b", "bucket-c", 1, true)}
+	if replicationRulesReordered(state, edited, []int{2, 1}, none) {
+		t.Error("expected an edited rule not to be a reorder")
+	}
+
+	// An omitted delete_marker_replication keeps the value of the rule previously at its index
+	omitted := []interface{}{rule("a", "bucket-b", 2, true), rule("b", "bucket-a", 1, false)}
+	deleteMarkerOmitted := func(_ int, attribute string) bool { return attribute == "delete_marker_replication" }
+	if !replicationRulesReordered(state, omitted, []int{2, 1}, deleteMarkerOmitted) {
+		t.Error("expected omitted attributes to match any value")
+	}
+	if replicationRulesReordered(state, omitted, []int{2, 1}, none) {
+		t.Error("expected configured attributes to be compared")
+	}
+}
+
+func TestReplicationHealthCheckPeriod(t *testing.T) {
+	for value, expected := range map[string]string{
+		"30s":   "30s",
+		"90s":   "1m30s",
+		"1m30s": "1m30s",
+		"60 s":  "1m",
+		"3600s": "1h",
+		"1h30m": "1h30m",
+		"later": "later",
+	} {
+		if normalized := normalizeReplicationHealthCheckPeriod(value); normalized != expected {
+			t.Errorf("%q: expected %q, got %q", value, expected, normalized)
+		}
+	}
+
+	for value, valid := range map[string]bool{
+		"30s":   true,
+		"1m30s": true,
+		"5 m":   true,
+		"0s":    true,
+		"-1s":   false,
+		"30":    false,
+		"later": false,
+	} {
+		if _, errs := validateReplicationHealthCheckPeriod(value, "health_check_period"); (len(errs) == 0) != valid {
+			t.Errorf("%q: expected valid to be %t, got %v", value, valid, errs)
+		}
+	}
+}
+
+func TestUnsupportedReplicationTargetFeatures(t *testing.T) {
+	if unsupported := unsupportedReplicationTargetFeatures(replicationTargetTypeMinIO, true, true); len(unsupported) != 0 {
+		t.Errorf("expected every feature on a MinIO target, got %v unsupported", unsupported)
+	}
+	if unsupported := unsupportedReplicationTargetFeatures(replicationTargetTypeS3, false, false); len(unsupported) != 0 {
+		t.Errorf("expected no unsupported feature without them, got %v", unsupported)
+	}
+
+	expected := []string{"replica_modifications", "existing_object_replication"}
+	if unsupported := unsupportedReplicationTargetFeatures(replicationTargetTypeS3, true, true); !reflect.DeepEqual(unsupported, expected) {
+		t.Errorf("expected %v unsupported on an S3 target, got %v", expected, unsupported)
+	}
+
+	rules, errs := getBucketReplicationConfig([]interface{}{
+		map[string]interface{}{
+			"replica_modifications": true,
+			"tags":                  map[string]interface{}{},
+			"target": []interface{}{map[string]interface{}{
+				"type":       replicationTargetTypeS3,
+				"bucket":     "target",
+				"host":       "s3.amazonaws.com",
+				"secure":     true,
+				"access_key": "access",
+				"secret_key": "secret",
+			}},
+		},
+	})
+	if errs.HasError() {
+		t.Fatal(errs)
+	}
+	if rules[0].Target.Type != replicationTargetTypeS3 || rules[0].ReplicaModifications {
+		t.Errorf("expected an S3 target without replica modifications, got %+v", rules[0])
+	}
+}
+
+func TestReplicationRuleTagsRoundTrip(t *testing.T) {
+	tags := map[string]string{
+		"empty":       "",
+		"key=with=eq": "a&b",
+		"space key":   "1+1=2",
+	}
+
+	decoded, err := decodeReplicationRuleTags(encodeReplicationRuleTags(tags))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []replication.Tag{{Key: "empty", Value: ""}, {Key: "key=with=eq", Value: "a&b"}, {Key: "space key", Value: "1+1=2"}}
+	if !reflect.DeepEqual(decoded, expected) {
+		t.Errorf("expected %v, got %v", expected, decoded)
+	}
+
+	for _, prefix := range []string{"", "logs/"} {
+		rcfg := replication.Config{}
+		rule := S3MinioBucketReplicationRule{Id: "rule", Enabled: true, Priority: 1, Prefix: prefix, Tags: tags}
+		if err := addOrEditReplicationRule(&rcfg, bucketReplicationRuleOptions(rule, "arn:minio:replication::id:target")); err != nil {
+			t.Fatal(err)
+		}
+		if got := replicationRuleTags(rcfg.Rules[0]); !reflect.DeepEqual(got, tags) {
+			t.Errorf("expected the tags %v with prefix %q, got %v", tags, prefix, got)
+		}
+		if rcfg.Rules[0].Prefix() != prefix {
+			t.Errorf("expected prefix %q, got %q", prefix, rcfg.Rules[0].Prefix())
+		}
+	}
+
+	rcfg := replication.Config{}
+	rule := S3MinioBucketReplicationRule{Id: "rule", Enabled: true, Priority: 1, Tags: map[string]string{"single": ""}}
+	if err := addOrEditReplicationRule(&rcfg, bucketReplicationRuleOptions(rule, "arn:minio:replication::id:target")); err != nil {
+		t.Fatal(err)
+	}
+	if filter := rcfg.Rules[0].Filter; filter.Tag.Key != "single" || len(filter.And.Tags) != 0 {
+		t.Errorf("expected a single tag filter, got %+v", filter)
+	}
+
+	// Removing the tags clears the filter of the existing rule
+	rule.Tags = map[string]string{}
+	if err := addOrEditReplicationRule(&rcfg, bucketReplicationRuleOptions(rule, "arn:minio:replication::id:target")); err != nil {
+		t.Fatal(err)
+	}
+	if got := replicationRuleTags(rcfg.Rules[0]); len(got) != 0 {
+		t.Errorf("expected no tags left, got %v", got)
+	}
+}
+
+func TestReplicationRulesHash(t *testing.T) {
+	rules := []replication.Rule{
+		{ID: "a", Priority: 1, Status: replication.Enabled},
+		{ID: "b", Priority: 2, Status: replication.Enabled},
+	}
+
+	hash, err := replicationRulesHash(rules)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reordered, _ := replicationRulesHash([]replication.Rule{rules[1], rules[0]})
+	if reordered != hash {
+		t.Errorf("expected the order of the rules not to change the hash, got %s and %s", hash, reordered)
+	}
+
+	rules[1].Status = replication.Disabled
+	if changed, _ := replicationRulesHash(rules); changed == hash {
+		t.Error("expected a rule change to change the hash")
+	}
+}
+
+func TestRemoteTargetUpdateOps(t *testing.T) {
+	existing := madmin.BucketTarget{
+		Arn:                 "arn:minio:replication::id:target",
+		Endpoint:            "minio:9000",
+		TargetBucket:        "target",
+		Type:                madmin.ReplicationService,
+		Path:                "auto",
+		Credentials:         &madmin.Credentials{AccessKey: "access"},
+		BandwidthLimit:      100000000,
+		HealthCheckDuration: 30 * time.Second,
+	}
+
+	wanted := existing
+	wanted.Credentials = &madmin.Credentials{AccessKey: "access"}
+	wanted.BandwidthLimit = 200000000
+	wanted.HealthCheckDuration = time.Minute
+	ops, ok := remoteTargetUpdateOps(existing, wanted)
+	if expected := []madmin.TargetUpdateType{madmin.BandwidthLimitUpdateType, madmin.HealthCheckDurationUpdateType}; !ok || !reflect.DeepEqual(ops, expected) {
+		t.Errorf("expected %v, got %v (%t)", expected, ops, ok)
+	}
+
+	wanted.Credentials = &madmin.Credentials{AccessKey: "other", SecretKey: "secret"}
+	if ops, ok := remoteTargetUpdateOps(existing, wanted); !ok || !slices.Contains(ops, madmin.CredentialsUpdateType) {
+		t.Errorf("expected the credentials to be updated, got %v (%t)", ops, ok)
+	}
+
+	wanted.Credentials = &madmin.Credentials{AccessKey: "other"}
+	if _, ok := remoteTargetUpdateOps(existing, wanted); ok {
+		t.Error("expected a new access key without secret key not to be editable")
+	}
+
+	wanted = existing
+	wanted.Endpoint = "other:9000"
+	if _, ok := remoteTargetUpdateOps(existing, wanted); ok {
+		t.Error("expected a new endpoint not to be editable")
+	}
+
+	// The IPv6 addresses stored by the server may be written differently
+	existingIPv6 := existing
+	existingIPv6.Endpoint = "[2001:DB8:0::1]:9000"
+	wanted = existing
+	wanted.Endpoint = "[2001:db8::1]:9000"
+	if ops, ok := remoteTargetUpdateOps(existingIPv6, wanted); !ok || len(ops) != 0 {
+		t.Errorf("expected the same IPv6 endpoint to need no change, got %v (%t)", ops, ok)
+	}
+
+	rule := S3MinioBucketReplicationRule{Arn: existing.Arn}
+	if _, _, ok := editableRemoteTarget([]madmin.BucketTarget{existing}, []string{existing.Arn}, rule, existing); ok {
+		t.Error("expected a target already used by another rule not to be edited")
+	}
+	if target, ops, ok := editableRemoteTarget([]madmin.BucketTarget{existing}, nil, rule, existing); !ok || target.Arn != existing.Arn || len(ops) != 0 {
+		t.Errorf("expected the unchanged target to be kept, got %v %v (%t)", target.Arn, ops, ok)
+	}
+}
+
+func TestGetBucketReplicationConfigRemoteTargetArn(t *testing.T) {
+	arn := "arn:minio:replication::d0b4e7ae-3e4b-4a35-9f1c-2a7f0c3f5d7e:target-bucket"
+	rule := func(remoteTargetArn string, targets []interface{}) interface{} {
+		return map[string]interface{}{
+			"enabled":           true,
+			"priority":          1,
+			"tags":              map[string]interface{}{},
+			"remote_target_arn": remoteTargetArn,
+			"target":            targets,
+		}
+	}
+	target := map[string]interface{}{
+		"bucket":     "target-bucket",
+		"host":       "minio.example.com:9000",
+		"secure":     true,
+		"access_key": "access",
+		"secret_key": "secret",
+	}
+
+	rules, errs := getBucketReplicationConfig([]interface{}{rule(arn, []interface{}{})})
+	if errs.HasError() || rules[0].RemoteTargetArn != arn || rules[0].Arn != arn {
+		t.Errorf("expected the rule to reference the remote target, got %+v: %v", rules, errs)
+	}
+
+	if _, errs := getBucketReplicationConfig([]interface{}{rule(arn, []interface{}{target})}); !errs.HasError() {
+		t.Error("expected an error when both target and remote_target_arn are set")
+	}
+	if _, errs := getBucketReplicationConfig([]interface{}{rule("", []interface{}{})}); !errs.HasError() {
+		t.Error("expected an error when neither target nor remote_target_arn is set")
+	}
+}
@@ -4,11 +4,13 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"net/url"
 	"os"
 	"regexp"
 	"strings"
 	"testing"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
@@ -35,8 +37,16 @@ func TestAccMinioS3Bucket_basic(t *testing.T) {
 						resourceName, "arn", testAccBucketArn(rInt)),
 					resource.TestCheckResourceAttr(
 						resourceName, "bucket_domain_name", testAccBucketDomainName(rInt)),
+					resource.TestCheckResourceAttr(
+						resourceName, "endpoint_url", testAccBucketEndpointURL(rInt)),
+					resource.TestCheckResourceAttr(
+						resourceName, "virtual_host_url", ""),
 					resource.TestCheckResourceAttr(
 						resourceName, "acl", testAccBucketACL(acl)),
+					resource.TestCheckResourceAttrSet(
+						resourceName, "creation_date"),
+					resource.TestCheckResourceAttr(
+						resourceName, "owner", os.Getenv("MINIO_USER")),
 				),
 			},
 			{
@@ -44,12 +54,86 @@ func TestAccMinioS3Bucket_basic(t *testing.T) {
 				ImportState:       true,
 				ImportStateVerify: true,
 				ImportStateVerifyIgnore: []string{
-					"force_destroy"},
+					"force_destroy", "force_destroy_workers"},
 			},
 		},
 	})
 }
 
+func TestAccMinioS3Bucket_objectLocking(t *testing.T) {
+	rInt := fmt.Sprintf("tf-test-bucket-%d", acctest.RandInt())
+	resourceName := "minio_s3_bucket.bucket"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckMinioS3BucketDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMinioS3BucketConfigObjectLocking(rInt),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMinioS3BucketExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "object_locking", "true"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"force_destroy", "force_destroy_workers"},
+			},
+		},
+	})
+}
+
+func TestAccMinioS3Bucket_quotaCapacityMargin(t *testing.T) {
+	rInt := fmt.Sprintf("tf-test-bucket-%d", acctest.RandInt())
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckMinioS3BucketDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMinioS3BucketConfigQuotaCapacityMargin(rInt, "1073741824"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMinioS3BucketExists("minio_s3_bucket.bucket"),
+					resource.TestCheckResourceAttr("minio_s3_bucket.bucket", "quota", "1073741824"),
+				),
+			},
+			{
+				// 1 EiB, more than the test cluster holds
+				Config:      testAccMinioS3BucketConfigQuotaCapacityMargin(rInt, "1152921504606846976"),
+				ExpectError: regexp.MustCompile("remaining|left in the cluster"),
+			},
+		},
+	})
+}
+
+func TestCheckBucketQuotaCapacity(t *testing.T) {
+	const gib = 1 << 30
+	cases := []struct {
+		quota, used, free, total, margin uint64
+		valid                            bool
+	}{
+		{quota: 10 * gib, free: 50 * gib, total: 100 * gib, margin: 10, valid: true},
+		{quota: 45 * gib, free: 50 * gib, total: 100 * gib, margin: 10, valid: false},
+		{quota: 40 * gib, free: 50 * gib, total: 100 * gib, margin: 10, valid: true},
+		// The used space is already allocated
+		{quota: 80 * gib, used: 40 * gib, free: 50 * gib, total: 100 * gib, margin: 10, valid: true},
+		{quota: 10 * gib, used: 20 * gib, free: 0, total: 100 * gib, margin: 10, valid: true},
+		{quota: 1, free: 5 * gib, total: 100 * gib, margin: 10, valid: false},
+		{quota: 50 * gib, free: 50 * gib, total: 100 * gib, margin: 0, valid: true},
+	}
+
+	for _, c := range cases {
+		if err := checkBucketQuotaCapacity(c.quota, c.used, c.free, c.total, c.margin); (err == nil) != c.valid {
+			t.Errorf("%+v: expected valid to be %t, got %v", c, c.valid, err)
+		}
+	}
+}
+
 func TestAccMinioS3Bucket_Bucket_EmptyString(t *testing.T) {
 	resourceName := "minio_s3_bucket.test"
 
@@ -70,7 +154,7 @@ func TestAccMinioS3Bucket_Bucket_EmptyString(t *testing.T) {
 				ImportState:       true,
 				ImportStateVerify: true,
 				ImportStateVerifyIgnore: []string{
-					"force_destroy"},
+					"force_destroy", "force_destroy_workers"},
 			},
 		},
 	})
@@ -97,7 +181,33 @@ func TestAccMinioS3Bucket_namePrefix(t *testing.T) {
 				ImportState:       true,
 				ImportStateVerify: true,
 				ImportStateVerifyIgnore: []string{
-					"force_destroy", "bucket_prefix"},
+					"force_destroy", "force_destroy_workers", "bucket_prefix"},
+			},
+		},
+	})
+}
+
+func TestAccMinioS3Bucket_invalidNamePrefix(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccMinioS3BucketConfigInvalidNamePrefix,
+				ExpectError: regexp.MustCompile("must start with a lowercase letter or a digit"),
+			},
+		},
+	})
+}
+
+func TestAccMinioS3Bucket_invalidName(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccMinioS3BucketConfigInvalidName,
+				ExpectError: regexp.MustCompile("invalid bucket name"),
 			},
 		},
 	})
@@ -122,7 +232,7 @@ func TestAccMinioS3Bucket_generatedName(t *testing.T) {
 				ImportState:       true,
 				ImportStateVerify: true,
 				ImportStateVerifyIgnore: []string{
-					"force_destroy", "bucket_prefix"},
+					"force_destroy", "force_destroy_workers", "bucket_prefix"},
 			},
 		},
 	})
@@ -153,7 +263,7 @@ func TestAccMinioS3Bucket_UpdateAcl(t *testing.T) {
 				ImportState:       true,
 				ImportStateVerify: true,
 				ImportStateVerifyIgnore: []string{
-					"force_destroy"},
+					"force_destroy", "force_destroy_workers"},
 			},
 			{
 				ResourceName: resourceName,
@@ -166,7 +276,7 @@ func TestAccMinioS3Bucket_UpdateAcl(t *testing.T) {
 				ImportState:       true,
 				ImportStateVerify: true,
 				ImportStateVerifyIgnore: []string{
-					"force_destroy"},
+					"force_destroy", "force_destroy_workers"},
 			},
 		},
 	})
@@ -207,12 +317,74 @@ func TestAccMinioS3Bucket_forceDestroy(t *testing.T) {
 				Config: testAccMinioS3BucketConfigForceDestroy(bucketName),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckMinioS3BucketExists(resourceName),
+					testAccCheckMinioS3BucketAddObjects(resourceName, 2500),
+				),
+			},
+		},
+	})
+}
+
+func TestAccMinioS3Bucket_forceDestroyBypassGovernance(t *testing.T) {
+	resourceName := "minio_s3_bucket.bucket"
+	bucketName := fmt.Sprintf("tf-test-bucket-%d", acctest.RandInt())
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckMinioS3BucketDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMinioS3BucketConfigForceDestroyBypassGovernance(bucketName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMinioS3BucketExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "force_destroy_bypass_governance", "true"),
+					// Each object gets two versions, every one of them is removed on destroy
+					testAccCheckMinioS3BucketAddObjects(resourceName, 20),
+					testAccCheckMinioS3BucketAddObjects(resourceName, 20),
 				),
 			},
 		},
 	})
 }
 
+func TestAccMinioS3Bucket_enforceObjectEncryption(t *testing.T) {
+	resourceName := "minio_s3_bucket.bucket"
+	bucketName := fmt.Sprintf("tf-test-bucket-%d", acctest.RandInt())
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckMinioS3BucketDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMinioS3BucketConfigEnforceObjectEncryption(bucketName, ""),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMinioS3BucketExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "enforce_object_encryption", "true"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"force_destroy", "force_destroy_workers"},
+			},
+			{
+				// The bucket has no encryption configuration, the object would be stored unencrypted
+				Config: testAccMinioS3BucketConfigEnforceObjectEncryption(bucketName, `
+resource "minio_s3_object" "object" {
+  bucket_name = minio_s3_bucket.bucket.bucket
+  object_name = "object"
+  content     = "unencrypted"
+}
+`),
+				ExpectError: regexp.MustCompile("has no encryption configuration"),
+			},
+		},
+	})
+}
+
 func TestAccMinioS3Bucket_PrivateBucketUnreadable(t *testing.T) {
 	ri := fmt.Sprintf("tf-test-bucket-%d", acctest.RandInt())
 	preConfig := testAccMinioS3BucketConfigWithACL(ri, "private")
@@ -270,6 +442,49 @@ func TestMinioS3BucketName(t *testing.T) {
 	}
 }
 
+func TestMinioS3BucketNameDiag(t *testing.T) {
+	for _, v := range []string{"foobar", "foo.bar", "foo-bar", "1234", strings.Repeat("x", 63)} {
+		if diags := validateMinioBucketName(v, cty.Path{}); diags.HasError() {
+			t.Errorf("%q should be a valid bucket name: %v", v, diags)
+		}
+	}
+
+	for _, v := range []string{"", "ab", "foo..bar", "foo bar", ".foo", "bar.", "192.168.0.1", strings.Repeat("x", 64)} {
+		if diags := validateMinioBucketName(v, cty.Path{}); !diags.HasError() {
+			t.Errorf("%q should not be a valid bucket name", v)
+		}
+	}
+}
+
+func TestMinioS3BucketURLs(t *testing.T) {
+	cases := []struct {
+		endpoint    string
+		bucket      string
+		pathStyle   string
+		virtualHost string
+	}{
+		{"http://localhost:9000", "foo", "http://localhost:9000/foo", ""},
+		{"http://127.0.0.1:9000", "foo", "http://127.0.0.1:9000/foo", ""},
+		{"http://[::1]:9000", "foo", "http://[::1]:9000/foo", ""},
+		{"https://minio.example.com", "foo", "https://minio.example.com/foo", "https://foo.minio.example.com"},
+		{"https://minio.example.com", "foo.bar", "https://minio.example.com/foo.bar", ""},
+		{"http://minio.example.com:9000", "foo.bar", "http://minio.example.com:9000/foo.bar", "http://foo.bar.minio.example.com:9000"},
+	}
+
+	for _, c := range cases {
+		endpoint, err := url.Parse(c.endpoint)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := bucketEndpointURL(c.bucket, endpoint); got != c.pathStyle {
+			t.Errorf("bucketEndpointURL(%q, %q) = %q, want %q", c.bucket, c.endpoint, got, c.pathStyle)
+		}
+		if got := bucketVirtualHostURL(c.bucket, endpoint); got != c.virtualHost {
+			t.Errorf("bucketVirtualHostURL(%q, %q) = %q, want %q", c.bucket, c.endpoint, got, c.virtualHost)
+		}
+	}
+}
+
 func testAccCheckMinioS3BucketDestroy(s *terraform.State) (err error) {
 
 	err = providerMinioS3BucketDestroy(testAccProvider.Meta().(*S3MinioClient).S3Client, s)
@@ -370,6 +585,25 @@ func testAccCheckMinioS3DestroyBucket(n string) resource.TestCheckFunc {
 	}
 }
 
+func testAccCheckMinioS3BucketAddObjects(n string, count int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+
+		conn := testAccProvider.Meta().(*S3MinioClient).S3Client
+		for i := 0; i < count; i++ {
+			content := fmt.Sprintf("object %d", i)
+			_, err := conn.PutObject(context.Background(), rs.Primary.ID, fmt.Sprintf("prefix-%d/object-%d", i%10, i), strings.NewReader(content), int64(len(content)), minio.PutObjectOptions{})
+			if err != nil {
+				return fmt.Errorf("error adding object to bucket (%s): %s", rs.Primary.ID, err)
+			}
+		}
+		return nil
+	}
+}
+
 func testAccCheckMinioS3BucketACLInState(n string, acl string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -405,6 +639,10 @@ func testAccBucketDomainName(randInt string) string {
 	return fmt.Sprintf("http://localhost:9000/minio/%s", randInt)
 }
 
+func testAccBucketEndpointURL(randInt string) string {
+	return fmt.Sprintf("http://localhost:9000/%s", randInt)
+}
+
 func testAccBucketACL(acl string) string {
 	validAcls := map[string]string{
 		"private":           "private",
@@ -422,6 +660,25 @@ func testAccBucketACL(acl string) string {
 	return ""
 }
 
+func testAccMinioS3BucketConfigObjectLocking(randInt string) string {
+	return fmt.Sprintf(`
+resource "minio_s3_bucket" "bucket" {
+  bucket         = "%s"
+  object_locking = true
+}
+`, randInt)
+}
+
+func testAccMinioS3BucketConfigQuotaCapacityMargin(randInt string, quota string) string {
+	return fmt.Sprintf(`
+resource "minio_s3_bucket" "bucket" {
+  bucket                = "%s"
+  quota                 = %s
+  quota_capacity_margin = 10
+}
+`, randInt, quota)
+}
+
 func testAccMinioS3BucketConfig(randInt string) string {
 	return fmt.Sprintf(`
 resource "minio_s3_bucket" "bucket" {
@@ -455,6 +712,34 @@ resource "minio_s3_bucket" "bucket" {
   bucket = "%s"
   acl = "private"
   force_destroy = true
+  force_destroy_workers = 8
+}
+`, bucketName)
+}
+
+func testAccMinioS3BucketConfigEnforceObjectEncryption(bucketName string, objects string) string {
+	return fmt.Sprintf(`
+resource "minio_s3_bucket" "bucket" {
+  bucket                    = "%s"
+  enforce_object_encryption = true
+}
+%s`, bucketName, objects)
+}
+
+func testAccMinioS3BucketConfigForceDestroyBypassGovernance(bucketName string) string {
+	return fmt.Sprintf(`
+resource "minio_s3_bucket" "bucket" {
+  bucket                          = "%s"
+  force_destroy                   = true
+  force_destroy_bypass_governance = true
+}
+
+resource "minio_s3_bucket_versioning" "bucket" {
+  bucket = minio_s3_bucket.bucket.bucket
+
+  versioning_configuration {
+    status = "Enabled"
+  }
 }
 `, bucketName)
 }
@@ -473,6 +758,20 @@ resource "minio_s3_bucket" "test" {
 }
 `
 
+const testAccMinioS3BucketConfigInvalidNamePrefix = `
+resource "minio_s3_bucket" "test" {
+	acl = "private"
+	bucket_prefix = "TF_test-"
+}
+`
+
+const testAccMinioS3BucketConfigInvalidName = `
+resource "minio_s3_bucket" "test" {
+	acl = "private"
+	bucket = "tf-test..bucket"
+}
+`
+
 const testAccMinioS3BucketConfigGeneratedName = `
 resource "minio_s3_bucket" "test" {
 	acl = "private"
@@ -20,11 +20,13 @@ func resourceMinioBucketVersioning() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: minioValidateBucketVersioningFeatures,
 		Schema: map[string]*schema.Schema{
 			"bucket": {
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateMinioBucketName,
 			},
 			"versioning_configuration": {
 				Type:     schema.TypeList,
@@ -38,15 +40,32 @@ func resourceMinioBucketVersioning() *schema.Resource {
 							ValidateFunc: validation.StringInSlice([]string{minio.Enabled, minio.Suspended}, false),
 						},
 						"excluded_prefixes": {
-							Type:     schema.TypeList,
-							Optional: true,
+							Type:        schema.TypeList,
+							Optional:    true,
+							Description: "Prefixes excluded from versioning, and therefore from replication. Requires MinIO RELEASE.2022-07-08T00-05-23Z or later",
 							Elem: &schema.Schema{
 								Type: schema.TypeString,
 							},
 						},
+						"mfa_delete": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Only accepts \"Disabled\" as MinIO does not support MFA delete. Eases the port of AWS configurations",
+							ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+								switch v.(string) {
+								case "", "Disabled":
+								case "Enabled":
+									errors = append(errors, fmt.Errorf("%q cannot be enabled, MinIO does not support MFA delete", k))
+								default:
+									errors = append(errors, fmt.Errorf("expected %q to be \"Disabled\", got %q", k, v))
+								}
+								return
+							},
+						},
 						"exclude_folders": {
-							Type:     schema.TypeBool,
-							Optional: true,
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Description: "Exclude folders (objects ending with /) from versioning, and therefore from replication. Requires MinIO RELEASE.2022-08-02T23-59-16Z or later",
 						},
 					},
 				},
@@ -75,6 +94,17 @@ func minioPutBucketVersioning(ctx context.Context, d *schema.ResourceData, meta
 		return NewResourceError("error putting bucket versioning configuration", bucketVersioningConfig.MinioBucket, err)
 	}
 
+	if versioningConfig.ExcludeFolders || len(versioningConfig.ExcludedPrefixes) != 0 {
+		// Older servers silently drop the exclusion settings, so make sure they were persisted
+		actualConfig, err := bucketVersioningConfig.MinioClient.GetBucketVersioning(ctx, bucketVersioningConfig.MinioBucket)
+		if err != nil {
+			return NewResourceError("failed to load bucket versioning", bucketVersioningConfig.MinioBucket, err)
+		}
+		if err := checkBucketVersioningExclusionSupport(*versioningConfig, actualConfig); err != nil {
+			return NewResourceError("error putting bucket versioning configuration", bucketVersioningConfig.MinioBucket, err)
+		}
+	}
+
 	d.SetId(bucketVersioningConfig.MinioBucket)
 
 	return nil
@@ -103,6 +133,9 @@ func minioReadBucketVersioning(ctx context.Context, d *schema.ResourceData, meta
 
 	config["exclude_folders"] = versioningConfig.ExcludeFolders
 
+	// MFA delete is not supported by MinIO, the configured value is kept as is
+	config["mfa_delete"] = d.Get("versioning_configuration.0.mfa_delete").(string)
+
 	if err := d.Set("bucket", d.Id()); err != nil {
 		return diag.FromErr(err)
 	}
@@ -132,6 +165,28 @@ func minioDeleteBucketVersioning(ctx context.Context, d *schema.ResourceData, me
 	return nil
 }
 
+// minioValidateBucketVersioningFeatures fails the plan when the server release does not support the exclusions
+func minioValidateBucketVersioningFeatures(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	features := []minioFeature{}
+	if prefixes, _ := d.Get("versioning_configuration.0.excluded_prefixes").([]interface{}); len(prefixes) != 0 {
+		features = append(features, minioFeatureVersioningExcludedPrefixes)
+	}
+	if d.Get("versioning_configuration.0.exclude_folders").(bool) {
+		features = append(features, minioFeatureVersioningExcludeFolders)
+	}
+	return requireMinioFeatures(ctx, meta, features...)
+}
+
+func checkBucketVersioningExclusionSupport(wanted S3MinioBucketVersioningConfiguration, actual minio.BucketVersioningConfiguration) error {
+	if wanted.ExcludeFolders && !actual.ExcludeFolders {
+		return fmt.Errorf("the server does not support exclude_folders, a more recent MinIO release is required")
+	}
+	if len(wanted.ExcludedPrefixes) != len(actual.ExcludedPrefixes) {
+		return fmt.Errorf("the server does not support excluded_prefixes, a more recent MinIO release is required")
+	}
+	return nil
+}
+
 func convertBucketVersioningConfig(c S3MinioBucketVersioningConfiguration) minio.BucketVersioningConfiguration {
 	conf := minio.BucketVersioningConfiguration{
 		Status:         c.Status,
@@ -9,7 +9,9 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	minio "github.com/minio/minio-go/v7"
 )
 
 func TestAccS3BucketVersioning_basic(t *testing.T) {
@@ -149,3 +151,41 @@ func testAccCheckBucketHasVersioning(n string, config S3MinioBucketVersioningCon
 		return nil
 	}
 }
+
+func TestCheckBucketVersioningExclusionSupport(t *testing.T) {
+	wanted := S3MinioBucketVersioningConfiguration{
+		Status:           minio.Enabled,
+		ExcludedPrefixes: []string{"tmp/", "cache/"},
+		ExcludeFolders:   true,
+	}
+
+	supported := minio.BucketVersioningConfiguration{
+		Status:           minio.Enabled,
+		ExcludedPrefixes: []minio.ExcludedPrefix{{Prefix: "tmp/"}, {Prefix: "cache/"}},
+		ExcludeFolders:   true,
+	}
+	if err := checkBucketVersioningExclusionSupport(wanted, supported); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	unsupported := minio.BucketVersioningConfiguration{Status: minio.Enabled}
+	if err := checkBucketVersioningExclusionSupport(wanted, unsupported); err == nil {
+		t.Errorf("expected an error when the server drops the exclusions")
+	}
+}
+
+func TestValidateBucketVersioningMFADelete(t *testing.T) {
+	validate := resourceMinioBucketVersioning().Schema["versioning_configuration"].Elem.(*schema.Resource).Schema["mfa_delete"].ValidateFunc
+
+	for _, value := range []string{"", "Disabled"} {
+		if _, errs := validate(value, "mfa_delete"); len(errs) != 0 {
+			t.Errorf("expected %q to be valid, got %v", value, errs)
+		}
+	}
+
+	for _, value := range []string{"Enabled", "disabled"} {
+		if _, errs := validate(value, "mfa_delete"); len(errs) == 0 {
+			t.Errorf("expected %q to be rejected", value)
+		}
+	}
+}
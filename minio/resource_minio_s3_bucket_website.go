@@ -0,0 +1,260 @@
+package minio
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/minio/minio-go/v7"
+	"golang.org/x/exp/slices"
+)
+
+// bucketWebsiteConfigurationKey is the default object holding the website configuration of a bucket
+const bucketWebsiteConfigurationKey = ".website.json"
+
+// bucketWebsiteConfiguration is the website configuration stored in the bucket, as MinIO does not implement
+// the S3 website API. It follows the fields of the S3 configuration so the proxies serving the site can read it.
+type bucketWebsiteConfiguration struct {
+	IndexDocument bucketWebsiteIndexDocument  `json:"IndexDocument"`
+	ErrorDocument *bucketWebsiteErrorDocument `json:"ErrorDocument,omitempty"`
+	Redirects     []bucketWebsiteRedirect     `json:"Redirects,omitempty"`
+}
+
+type bucketWebsiteIndexDocument struct {
+	Suffix string `json:"Suffix"`
+}
+
+type bucketWebsiteErrorDocument struct {
+	Key string `json:"Key"`
+}
+
+type bucketWebsiteRedirect struct {
+	Key      string `json:"Key"`
+	Location string `json:"Location"`
+}
+
+func resourceMinioBucketWebsite() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: minioPutBucketWebsite,
+		ReadContext:   minioReadBucketWebsite,
+		UpdateContext: minioPutBucketWebsite,
+		DeleteContext: minioDeleteBucketWebsite,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"bucket": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateMinioBucketName,
+			},
+			"index_document": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "index.html",
+				ValidateFunc: validation.StringIsNotEmpty,
+				Description:  "Object served for the requests on a folder, relative to it",
+			},
+			"error_document": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Object served when a request fails, e.g. on a missing page",
+			},
+			"redirect": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Redirects, stored as empty objects with the x-amz-website-redirect-location metadata",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+							Description:  "Key of the page to redirect",
+						},
+						"location": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+							Description:  "Target of the redirect, either an absolute URL or a path starting with /",
+						},
+					},
+				},
+			},
+			"configuration_key": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     bucketWebsiteConfigurationKey,
+				Description: "Object of the bucket holding the website configuration, for the proxy serving the site",
+			},
+			"website_endpoint": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Path-style URL of the site",
+			},
+		},
+	}
+}
+
+func minioPutBucketWebsite(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	m := meta.(*S3MinioClient)
+	bucket := d.Get("bucket").(string)
+	configKey := d.Get("configuration_key").(string)
+
+	config := bucketWebsiteConfigurationFromResource(d)
+
+	if d.HasChange("redirect") {
+		oldRedirects, _ := d.GetChange("redirect")
+		for _, redirect := range bucketWebsiteRedirects(oldRedirects.(*schema.Set)) {
+			key := redirect.Key
+			if slices.IndexFunc(config.Redirects, func(r bucketWebsiteRedirect) bool { return r.Key == key }) < 0 {
+				log.Printf("[DEBUG] Removing website redirect %s of bucket %s", redirect.Key, bucket)
+				if err := m.S3Client.RemoveObject(ctx, bucket, redirect.Key, minio.RemoveObjectOptions{}); err != nil {
+					return NewResourceError("unable to remove website redirect", redirect.Key, err)
+				}
+			}
+		}
+	}
+
+	for _, redirect := range config.Redirects {
+		log.Printf("[DEBUG] Redirecting %s of bucket %s to %s", redirect.Key, bucket, redirect.Location)
+		if _, err := m.S3Client.PutObject(ctx, bucket, redirect.Key, bytes.NewReader(nil), 0, minio.PutObjectOptions{
+			WebsiteRedirectLocation: redirect.Location,
+		}); err != nil {
+			return NewResourceError("unable to put website redirect", redirect.Key, err)
+		}
+	}
+
+	content, err := json.Marshal(config)
+	if err != nil {
+		return NewResourceError("unable to encode website configuration", bucket, err)
+	}
+	if _, err := m.S3Client.PutObject(ctx, bucket, configKey, bytes.NewReader(content), int64(len(content)), minio.PutObjectOptions{
+		ContentType: "application/json",
+	}); err != nil {
+		return NewResourceError("unable to put website configuration", bucket, err)
+	}
+
+	d.SetId(bucket)
+
+	return minioReadBucketWebsite(ctx, d, meta)
+}
+
+func minioReadBucketWebsite(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	m := meta.(*S3MinioClient)
+	bucket := d.Id()
+	configKey := d.Get("configuration_key").(string)
+	if configKey == "" {
+		configKey = bucketWebsiteConfigurationKey
+	}
+
+	config, err := readBucketWebsiteConfiguration(ctx, m.S3Client, bucket, configKey)
+	if err != nil {
+		if code := minio.ToErrorResponse(err).Code; code == "NoSuchKey" || code == "NoSuchBucket" {
+			log.Printf("[WARN] Website configuration of bucket %s not found, removing it from state", bucket)
+			d.SetId("")
+			return nil
+		}
+		return NewResourceError("unable to read website configuration", bucket, err)
+	}
+
+	_ = d.Set("bucket", bucket)
+	_ = d.Set("configuration_key", configKey)
+	_ = d.Set("index_document", config.IndexDocument.Suffix)
+	errorDocument := ""
+	if config.ErrorDocument != nil {
+		errorDocument = config.ErrorDocument.Key
+	}
+	_ = d.Set("error_document", errorDocument)
+
+	redirects := make([]map[string]interface{}, len(config.Redirects))
+	for i, redirect := range config.Redirects {
+		redirects[i] = map[string]interface{}{
+			"key":      redirect.Key,
+			"location": redirect.Location,
+		}
+	}
+	if err := d.Set("redirect", redirects); err != nil {
+		return NewResourceError("unable to read website configuration", bucket, err)
+	}
+
+	_ = d.Set("website_endpoint", bucketEndpointURL(bucket, m.S3Client.EndpointURL())+"/")
+
+	return nil
+}
+
+func minioDeleteBucketWebsite(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	m := meta.(*S3MinioClient)
+	bucket := d.Id()
+
+	keys := []string{d.Get("configuration_key").(string)}
+	for _, redirect := range bucketWebsiteRedirects(d.Get("redirect").(*schema.Set)) {
+		keys = append(keys, redirect.Key)
+	}
+
+	for _, key := range keys {
+		log.Printf("[DEBUG] Removing website object %s of bucket %s", key, bucket)
+		if err := m.S3Client.RemoveObject(ctx, bucket, key, minio.RemoveObjectOptions{}); err != nil {
+			if minio.ToErrorResponse(err).Code == "NoSuchBucket" {
+				return nil
+			}
+			return NewResourceError("unable to remove website configuration", key, err)
+		}
+	}
+
+	return nil
+}
+
+// readBucketWebsiteConfiguration reads the website configuration stored in a bucket
+func readBucketWebsiteConfiguration(ctx context.Context, client *minio.Client, bucket string, key string) (*bucketWebsiteConfiguration, error) {
+	object, err := client.GetObject(ctx, bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, err
+	}
+	defer object.Close()
+
+	content, err := io.ReadAll(object)
+	if err != nil {
+		return nil, err
+	}
+
+	config := &bucketWebsiteConfiguration{}
+	if err := json.Unmarshal(content, config); err != nil {
+		return nil, fmt.Errorf("invalid website configuration %s: %w", key, err)
+	}
+	return config, nil
+}
+
+func bucketWebsiteConfigurationFromResource(d *schema.ResourceData) *bucketWebsiteConfiguration {
+	config := &bucketWebsiteConfiguration{}
+	config.IndexDocument.Suffix = d.Get("index_document").(string)
+	if errorDocument := d.Get("error_document").(string); errorDocument != "" {
+		config.ErrorDocument = &bucketWebsiteErrorDocument{Key: errorDocument}
+	}
+	config.Redirects = bucketWebsiteRedirects(d.Get("redirect").(*schema.Set))
+	return config
+}
+
+// bucketWebsiteRedirects returns the redirects of a set, sorted by key so the configuration is stable
+func bucketWebsiteRedirects(set *schema.Set) []bucketWebsiteRedirect {
+	redirects := []bucketWebsiteRedirect{}
+	for _, redirectI := range set.List() {
+		redirect := redirectI.(map[string]interface{})
+		redirects = append(redirects, bucketWebsiteRedirect{
+			Key:      redirect["key"].(string),
+			Location: redirect["location"].(string),
+		})
+	}
+	sort.Slice(redirects, func(i, j int) bool { return redirects[i].Key < redirects[j].Key })
+	return redirects
+}
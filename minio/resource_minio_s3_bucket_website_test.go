@@ -0,0 +1,97 @@
+package minio
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/minio/minio-go/v7"
+)
+
+func TestAccMinioS3BucketWebsite_basic(t *testing.T) {
+	bucketName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "minio_s3_bucket_website.site"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckMinioS3BucketWebsiteDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMinioS3BucketWebsiteConfig(bucketName, "/docs/index.html"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "index_document", "index.html"),
+					resource.TestCheckResourceAttr(resourceName, "error_document", "404.html"),
+					resource.TestCheckResourceAttr(resourceName, "redirect.#", "1"),
+					resource.TestCheckResourceAttrSet(resourceName, "website_endpoint"),
+					testAccCheckMinioS3BucketWebsiteRedirect(bucketName, "old/page.html", "/docs/index.html"),
+				),
+			},
+			{
+				Config: testAccMinioS3BucketWebsiteConfig(bucketName, "https://example.com/"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMinioS3BucketWebsiteRedirect(bucketName, "old/page.html", "https://example.com/"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccMinioS3BucketWebsiteConfig(bucketName string, location string) string {
+	return fmt.Sprintf(`
+resource "minio_s3_bucket" "site" {
+  bucket        = %q
+  acl           = "public-read"
+  force_destroy = true
+}
+
+resource "minio_s3_bucket_website" "site" {
+  bucket         = minio_s3_bucket.site.bucket
+  error_document = "404.html"
+
+  redirect {
+    key      = "old/page.html"
+    location = %q
+  }
+}
+`, bucketName, location)
+}
+
+func testAccCheckMinioS3BucketWebsiteRedirect(bucket string, key string, location string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*S3MinioClient).S3Client
+
+		info, err := client.StatObject(context.Background(), bucket, key, minio.StatObjectOptions{})
+		if err != nil {
+			return fmt.Errorf("unable to read redirect %s: %w", key, err)
+		}
+		if actual := info.Metadata.Get("X-Amz-Website-Redirect-Location"); actual != location {
+			return fmt.Errorf("expected %s to redirect to %s, got %q", key, location, actual)
+		}
+		return nil
+	}
+}
+
+func testAccCheckMinioS3BucketWebsiteDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*S3MinioClient).S3Client
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "minio_s3_bucket_website" {
+			continue
+		}
+
+		_, err := client.StatObject(context.Background(), rs.Primary.ID, rs.Primary.Attributes["configuration_key"], minio.StatObjectOptions{})
+		if err == nil {
+			return fmt.Errorf("website configuration of bucket %s still exists", rs.Primary.ID)
+		}
+	}
+	return nil
+}
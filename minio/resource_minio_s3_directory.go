@@ -0,0 +1,159 @@
+package minio
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/tags"
+)
+
+var directoryPathRegexp = regexp.MustCompile(`^[^/].*$`)
+
+func resourceMinioDirectory() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: minioCreateDirectory,
+		ReadContext:   minioReadDirectory,
+		UpdateContext: minioUpdateDirectory,
+		DeleteContext: minioDeleteDirectory,
+		Importer: &schema.ResourceImporter{
+			StateContext: minioImportDirectory,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"bucket": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateMinioBucketName,
+			},
+			"path": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringMatch(directoryPathRegexp, "must not start with a slash and cannot be empty"),
+				Description:  "Path of the directory. A trailing slash is added when omitted",
+				StateFunc: func(v interface{}) string {
+					return directoryKey(v.(string))
+				},
+			},
+			"tags": tagsSchema(),
+		},
+	}
+}
+
+func minioCreateDirectory(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	m := meta.(*S3MinioClient)
+	bucket := d.Get("bucket").(string)
+	key := directoryKey(d.Get("path").(string))
+
+	log.Printf("[DEBUG] Creating directory %s in bucket %s", key, bucket)
+
+	_, err := m.S3Client.PutObject(ctx, bucket, key, bytes.NewReader([]byte{}), 0, minio.PutObjectOptions{
+		UserTags: directoryTags(d),
+	})
+	if err != nil {
+		return NewResourceError("creating directory failed", key, err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", bucket, key))
+
+	return minioReadDirectory(ctx, d, meta)
+}
+
+func minioReadDirectory(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	m := meta.(*S3MinioClient)
+	bucket := d.Get("bucket").(string)
+	key := directoryKey(d.Get("path").(string))
+
+	_, err := m.S3Client.StatObject(ctx, bucket, key, minio.StatObjectOptions{})
+	if err != nil {
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			log.Printf("[WARN] Directory %s not found in bucket %s, removing from state", key, bucket)
+			d.SetId("")
+			return nil
+		}
+		return NewResourceError("reading directory failed", d.Id(), err)
+	}
+
+	objectTags, err := m.S3Client.GetObjectTagging(ctx, bucket, key, minio.GetObjectTaggingOptions{})
+	if err != nil {
+		return NewResourceError("reading directory tags failed", d.Id(), err)
+	}
+
+	_ = d.Set("path", key)
+	if err := d.Set("tags", objectTags.ToMap()); err != nil {
+		return NewResourceError("reading directory tags failed", d.Id(), err)
+	}
+
+	return nil
+}
+
+func minioUpdateDirectory(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	m := meta.(*S3MinioClient)
+	bucket := d.Get("bucket").(string)
+	key := directoryKey(d.Get("path").(string))
+
+	if d.HasChange("tags") {
+		objectTags, err := tags.NewTags(directoryTags(d), true)
+		if err != nil {
+			return NewResourceError("invalid directory tags", d.Id(), err)
+		}
+
+		if len(objectTags.ToMap()) == 0 {
+			err = m.S3Client.RemoveObjectTagging(ctx, bucket, key, minio.RemoveObjectTaggingOptions{})
+		} else {
+			err = m.S3Client.PutObjectTagging(ctx, bucket, key, objectTags, minio.PutObjectTaggingOptions{})
+		}
+		if err != nil {
+			return NewResourceError("updating directory tags failed", d.Id(), err)
+		}
+	}
+
+	return minioReadDirectory(ctx, d, meta)
+}
+
+func minioDeleteDirectory(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	m := meta.(*S3MinioClient)
+	bucket := d.Get("bucket").(string)
+	key := directoryKey(d.Get("path").(string))
+
+	if err := m.S3Client.RemoveObject(ctx, bucket, key, minio.RemoveObjectOptions{}); err != nil {
+		return NewResourceError("deleting directory failed", d.Id(), err)
+	}
+
+	return nil
+}
+
+func minioImportDirectory(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	idParts := strings.SplitN(d.Id(), "/", 2)
+	if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
+		return nil, fmt.Errorf("unexpected format of ID (%q), expected <bucket>/<path>", d.Id())
+	}
+
+	_ = d.Set("bucket", idParts[0])
+	_ = d.Set("path", directoryKey(idParts[1]))
+	d.SetId(fmt.Sprintf("%s/%s", idParts[0], directoryKey(idParts[1])))
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// directoryKey returns the key of the zero-byte object materialising the directory
+func directoryKey(path string) string {
+	return strings.TrimSuffix(path, "/") + "/"
+}
+
+func directoryTags(d *schema.ResourceData) map[string]string {
+	result := map[string]string{}
+	for k, v := range d.Get("tags").(map[string]interface{}) {
+		result[k] = v.(string)
+	}
+	return result
+}
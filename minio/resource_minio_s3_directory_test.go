@@ -0,0 +1,93 @@
+package minio
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/minio/minio-go/v7"
+)
+
+func TestAccMinioS3Directory_basic(t *testing.T) {
+	bucketName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "minio_s3_directory.dir"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckMinioS3DirectoryDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMinioS3DirectoryConfig(bucketName, "team-a", `{ owner = "team-a" }`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMinioS3DirectoryExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "path", "team-a/"),
+					resource.TestCheckResourceAttr(resourceName, "tags.owner", "team-a"),
+				),
+			},
+			{
+				Config: testAccMinioS3DirectoryConfig(bucketName, "team-a", `{ owner = "team-b" }`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMinioS3DirectoryExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "tags.owner", "team-b"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccMinioS3DirectoryConfig(bucketName string, path string, tags string) string {
+	return fmt.Sprintf(`
+resource "minio_s3_bucket" "bucket" {
+  bucket = %q
+}
+
+resource "minio_s3_directory" "dir" {
+  bucket = minio_s3_bucket.bucket.id
+  path   = %q
+  tags   = %s
+}
+`, bucketName, path, tags)
+}
+
+func testAccCheckMinioS3DirectoryExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+
+		minioC := testAccProvider.Meta().(*S3MinioClient).S3Client
+		_, err := minioC.StatObject(context.Background(), rs.Primary.Attributes["bucket"], rs.Primary.Attributes["path"], minio.StatObjectOptions{})
+		if err != nil {
+			return fmt.Errorf("directory %s not found: %v", rs.Primary.ID, err)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckMinioS3DirectoryDestroy(s *terraform.State) error {
+	minioC := testAccProvider.Meta().(*S3MinioClient).S3Client
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "minio_s3_directory" {
+			continue
+		}
+
+		_, err := minioC.StatObject(context.Background(), rs.Primary.Attributes["bucket"], rs.Primary.Attributes["path"], minio.StatObjectOptions{})
+		if err == nil {
+			return fmt.Errorf("directory %s still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
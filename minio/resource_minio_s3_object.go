@@ -3,13 +3,25 @@ package minio
 import (
 	"bytes"
 	"context"
+	"crypto/sha1"
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
 	"errors"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"io"
+	"log"
+	"os"
+	"time"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/minio/minio-go/v7"
-	"io"
+	"github.com/minio/minio-go/v7/pkg/encrypt"
 )
 
 func resourceMinioObject() *schema.Resource {
@@ -23,10 +35,10 @@ func resourceMinioObject() *schema.Resource {
 
 		Schema: map[string]*schema.Schema{
 			"bucket_name": {
-				Type:         schema.TypeString,
-				Required:     true,
-				ForceNew:     true,
-				ValidateFunc: validation.NoZeroValues,
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateMinioBucketName,
 			},
 			"object_name": {
 				Type:         schema.TypeString,
@@ -42,8 +54,41 @@ func resourceMinioObject() *schema.Resource {
 			"source": {
 				Type:          schema.TypeString,
 				Optional:      true,
+				Description:   "Path of a file to upload, which can be larger than 5GiB",
 				ConflictsWith: []string{"content", "content_base64"},
 			},
+			"source_checksum": {
+				Type:         schema.TypeBool,
+				Optional:     true,
+				Default:      false,
+				Description:  "Compute the SHA256 of the source file when planning, and upload it again only when it changed",
+				RequiredWith: []string{"source"},
+			},
+			"source_sha256": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Hex encoded SHA256 of the uploaded source file, when source_checksum is set",
+			},
+			"part_size": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     0,
+				Description: "Size in bytes of the parts of a multipart upload, from 5MiB to 5GiB (default: 0, computed from the object size)",
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					// Compared as int64, the maximum does not fit in an int on 32-bit platforms
+					if partSize := int64(v.(int)); partSize != 0 && (partSize < objectMinPartSize || partSize > objectMaxPartSize) {
+						errors = append(errors, fmt.Errorf("expected %s to be 0 or between %d and %d, got %d", k, int64(objectMinPartSize), int64(objectMaxPartSize), partSize))
+					}
+					return
+				},
+			},
+			"upload_concurrency": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				Description:  "Number of parts uploaded concurrently in a multipart upload (default: 0, the client default)",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
 			"content": {
 				Type:          schema.TypeString,
 				Optional:      true,
@@ -64,8 +109,59 @@ func resourceMinioObject() *schema.Resource {
 				Optional: true,
 				Computed: true,
 			},
+			"checksum_algorithm": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Compute a checksum of the content with this algorithm (CRC32, CRC32C, SHA1 or SHA256) and have the server verify it on upload",
+				ValidateFunc: validation.StringInSlice(objectChecksumAlgorithms, false),
+			},
+			"checksum_sha256": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Base64 encoded SHA256 checksum of the object, stored when it was uploaded with checksum_algorithm SHA256",
+			},
+			"legal_hold": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Place the object under legal hold. Requires object locking on the bucket",
+			},
+			"retention": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Retention of the object. Requires object locking on the bucket",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"mode": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice([]string{minio.Governance.String(), minio.Compliance.String()}, false),
+						},
+						"retain_until_date": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.IsRFC3339Time,
+							DiffSuppressFunc: func(k, oldValue, newValue string, d *schema.ResourceData) bool {
+								oldDate, oldErr := time.Parse(time.RFC3339, oldValue)
+								newDate, newErr := time.Parse(time.RFC3339, newValue)
+								return oldErr == nil && newErr == nil && oldDate.Equal(newDate)
+							},
+						},
+						"governance_bypass": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Allow shortening or removing a GOVERNANCE retention",
+						},
+					},
+				},
+			},
 		},
-		//CustomizeDiff: customDiff,
+		CustomizeDiff: customdiff.All(
+			minioObjectChecksumDiff,
+			minioObjectSourceDiff,
+		),
 	}
 }
 
@@ -77,33 +173,71 @@ func minioPutObject(ctx context.Context, d *schema.ResourceData, meta interface{
 	m := meta.(*S3MinioClient)
 
 	var body io.ReadSeeker
+	var size int64
+	if source, ok := d.GetOk("source"); ok {
+		file, err := os.Open(source.(string))
+		if err != nil {
+			return NewResourceError("putting object failed", d.Id(), err)
+		}
+		defer file.Close()
 
-	if v, ok := d.GetOk("content"); ok {
-		content := v.(string)
-		body = bytes.NewReader([]byte(content))
-	} else if v, ok := d.GetOk("content_base64"); ok {
-		content := v.(string)
-		contentRaw, err := base64.StdEncoding.DecodeString(content)
+		info, err := file.Stat()
 		if err != nil {
-			return NewResourceError("error decoding content_base64", d.Id(), err)
+			return NewResourceError("putting object failed", d.Id(), err)
 		}
-		body = bytes.NewReader(contentRaw)
-	} else if _, ok := d.GetOk("content_base64"); ok {
-		return NewResourceError("putting object failed", d.Id(), errors.New("sorry, unsupported yet"))
+		body, size = file, info.Size()
 	} else {
-		return NewResourceError("putting object failed", d.Id(), errors.New("one of source / content / content_base64 is not set"))
+		content, err := objectContent(d)
+		if err != nil {
+			return NewResourceError("putting object failed", d.Id(), err)
+		}
+		body, size = bytes.NewReader(content), int64(len(content))
 	}
 
 	options := minio.PutObjectOptions{}
 	if v, ok := d.GetOk("content_type"); ok {
 		options.ContentType = v.(string)
 	}
+	if d.Get("legal_hold").(bool) {
+		options.LegalHold = minio.LegalHoldEnabled
+	}
+	if mode, retainUntilDate, ok := getObjectRetention(d); ok {
+		options.Mode = mode
+		options.RetainUntilDate = retainUntilDate
+	}
+
+	options.PartSize = uint64(d.Get("part_size").(int))
+	options.NumThreads = uint(d.Get("upload_concurrency").(int))
+
+	if algorithm := d.Get("checksum_algorithm").(string); algorithm != "" {
+		checksum, err := objectChecksum(algorithm, body)
+		if err != nil {
+			return NewResourceError("putting object failed", d.Id(), err)
+		}
+		if _, err := body.Seek(0, io.SeekStart); err != nil {
+			return NewResourceError("putting object failed", d.Id(), err)
+		}
+		// The checksum covers the whole content, which must then be sent in a single request
+		options.UserMetadata = map[string]string{"X-Amz-Checksum-" + algorithm: checksum}
+		options.DisableMultipart = true
+	}
 
-	_, err := m.S3Client.PutObject(
+	bucket := d.Get("bucket_name").(string)
+	enforceEncryption, err := bucketEnforcesObjectEncryption(ctx, m.S3Client, bucket)
+	if err != nil {
+		return NewResourceError("putting object failed", d.Id(), err)
+	}
+	if enforceEncryption {
+		if options.ServerSideEncryption, err = bucketObjectEncryption(ctx, m.S3Client, bucket); err != nil {
+			return NewResourceError("putting object failed", d.Id(), err)
+		}
+	}
+
+	uploadInfo, err := m.S3Client.PutObject(
 		ctx,
-		d.Get("bucket_name").(string),
+		bucket,
 		d.Get("object_name").(string),
-		body, -1,
+		body, size,
 		options,
 	)
 
@@ -111,6 +245,24 @@ func minioPutObject(ctx context.Context, d *schema.ResourceData, meta interface{
 		return NewResourceError("putting object failed", d.Id(), err)
 	}
 
+	if enforceEncryption {
+		if err := checkObjectEncrypted(ctx, m.S3Client, uploadInfo); err != nil {
+			return NewResourceError("putting object failed", d.Id(), err)
+		}
+	}
+
+	// Only recorded once uploaded, so a failed upload is retried on the next apply
+	sourceSHA256 := ""
+	if d.Get("source_checksum").(bool) {
+		if _, err := body.Seek(0, io.SeekStart); err != nil {
+			return NewResourceError("putting object failed", d.Id(), err)
+		}
+		if sourceSHA256, err = objectSourceSHA256(body); err != nil {
+			return NewResourceError("putting object failed", d.Id(), err)
+		}
+	}
+	_ = d.Set("source_sha256", sourceSHA256)
+
 	d.SetId(d.Get("object_name").(string))
 
 	return minioReadObject(ctx, d, meta)
@@ -124,7 +276,7 @@ func minioReadObject(ctx context.Context, d *schema.ResourceData, meta interface
 		ctx,
 		d.Get("bucket_name").(string),
 		d.Get("object_name").(string),
-		minio.StatObjectOptions{},
+		minio.StatObjectOptions{Checksum: true},
 	)
 
 	if err != nil {
@@ -144,12 +296,237 @@ func minioReadObject(ctx context.Context, d *schema.ResourceData, meta interface
 	if err := d.Set("content_type", objInfo.ContentType); err != nil {
 		return NewResourceError("reading object failed", d.Id(), err)
 	}
+	if err := d.Set("checksum_sha256", objInfo.ChecksumSHA256); err != nil {
+		return NewResourceError("reading object failed", d.Id(), err)
+	}
+
+	_ = d.Set("legal_hold", objInfo.Metadata.Get("X-Amz-Object-Lock-Legal-Hold") == minio.LegalHoldEnabled.String())
+
+	retention := []interface{}{}
+	if mode := objInfo.Metadata.Get("X-Amz-Object-Lock-Mode"); mode != "" {
+		retention = append(retention, map[string]interface{}{
+			"mode":              mode,
+			"retain_until_date": objInfo.Metadata.Get("X-Amz-Object-Lock-Retain-Until-Date"),
+			"governance_bypass": d.Get("retention.0.governance_bypass").(bool),
+		})
+	}
+	if err := d.Set("retention", retention); err != nil {
+		return NewResourceError("reading object failed", d.Id(), err)
+	}
 
 	return nil
 }
 
 func minioUpdateObject(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	return minioPutObject(ctx, d, meta)
+	if d.HasChanges("content", "content_base64", "content_type", "etag", "version_id", "checksum_algorithm", "checksum_sha256", "source_sha256") {
+		return minioPutObject(ctx, d, meta)
+	}
+	if d.HasChange("source") && !d.Get("source_checksum").(bool) {
+		// Without its checksum, a new source file is always uploaded
+		return minioPutObject(ctx, d, meta)
+	}
+
+	m := meta.(*S3MinioClient)
+	bucketName := d.Get("bucket_name").(string)
+	objectName := d.Get("object_name").(string)
+	versionID := d.Get("version_id").(string)
+
+	if d.HasChange("legal_hold") {
+		status := minio.LegalHoldDisabled
+		if d.Get("legal_hold").(bool) {
+			status = minio.LegalHoldEnabled
+		}
+		err := m.S3Client.PutObjectLegalHold(ctx, bucketName, objectName, minio.PutObjectLegalHoldOptions{
+			VersionID: versionID,
+			Status:    &status,
+		})
+		if err != nil {
+			return NewResourceError("updating object legal hold failed", d.Id(), err)
+		}
+	}
+
+	if d.HasChange("retention") {
+		opts := minio.PutObjectRetentionOptions{
+			VersionID:        versionID,
+			GovernanceBypass: d.Get("retention.0.governance_bypass").(bool),
+		}
+		if mode, retainUntilDate, ok := getObjectRetention(d); ok {
+			opts.Mode = &mode
+			opts.RetainUntilDate = &retainUntilDate
+		} else {
+			// The retention is being removed, use the bypass setting it was configured with
+			oldRetention, _ := d.GetChange("retention.0.governance_bypass")
+			opts.GovernanceBypass = oldRetention.(bool)
+		}
+		if err := m.S3Client.PutObjectRetention(ctx, bucketName, objectName, opts); err != nil {
+			return NewResourceError("updating object retention failed", d.Id(), err)
+		}
+	}
+
+	return minioReadObject(ctx, d, meta)
+}
+
+// Part sizes accepted by the server in a multipart upload
+const (
+	objectMinPartSize = 5 << 20
+	objectMaxPartSize = 5 << 30
+)
+
+// objectChecksumAlgorithms are the algorithms of the x-amz-checksum headers
+var objectChecksumAlgorithms = []string{"CRC32", "CRC32C", "SHA1", "SHA256"}
+
+// objectContent returns the content of the object to upload
+func objectContent(d resourceDataGetter) ([]byte, error) {
+	if v, ok := d.GetOk("content"); ok {
+		return []byte(v.(string)), nil
+	} else if v, ok := d.GetOk("content_base64"); ok {
+		content, err := base64.StdEncoding.DecodeString(v.(string))
+		if err != nil {
+			return nil, fmt.Errorf("error decoding content_base64: %w", err)
+		}
+		return content, nil
+	}
+	return nil, errors.New("one of source / content / content_base64 is not set")
+}
+
+type resourceDataGetter interface {
+	GetOk(string) (interface{}, bool)
+}
+
+// objectChecksum returns the base64 encoded checksum of the content, as sent in the x-amz-checksum headers
+func objectChecksum(algorithm string, content io.Reader) (string, error) {
+	var h hash.Hash
+	switch algorithm {
+	case "CRC32":
+		h = crc32.NewIEEE()
+	case "CRC32C":
+		h = crc32.New(crc32.MakeTable(crc32.Castagnoli))
+	case "SHA1":
+		h = sha1.New()
+	case "SHA256":
+		h = sha256.New()
+	default:
+		return "", fmt.Errorf("unsupported checksum algorithm %q", algorithm)
+	}
+	if _, err := io.Copy(h, content); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(h.Sum(nil)), nil
+}
+
+// sourceFileSHA256 returns the hex encoded SHA256 of a file, read as a stream
+func sourceFileSHA256(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	return objectSourceSHA256(file)
+}
+
+func objectSourceSHA256(r io.Reader) (string, error) {
+	h := sha256.New()
+	if _, err := io.Copy(h, r); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// minioObjectSourceDiff plans a new upload of the source file when its content changed, even if its path
+// did not. A file whose checksum still matches the state is not uploaded again.
+func minioObjectSourceDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.Get("source_checksum").(bool) {
+		if d.Get("source_sha256").(string) != "" {
+			return d.SetNew("source_sha256", "")
+		}
+		return nil
+	}
+	if !d.NewValueKnown("source") {
+		return d.SetNewComputed("source_sha256")
+	}
+
+	sourceSHA256, err := sourceFileSHA256(d.Get("source").(string))
+	if err != nil {
+		return fmt.Errorf("unable to read source: %w", err)
+	}
+	if sourceSHA256 != d.Get("source_sha256").(string) {
+		return d.SetNew("source_sha256", sourceSHA256)
+	}
+	return nil
+}
+
+// minioObjectChecksumDiff plans a new upload when the SHA256 checksum stored on the server no longer
+// matches the content, e.g. when the object was overwritten outside of Terraform.
+func minioObjectChecksumDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" || d.Get("checksum_algorithm").(string) != "SHA256" || d.HasChanges("content", "content_base64", "checksum_algorithm") {
+		return nil
+	}
+	stored := d.Get("checksum_sha256").(string)
+	if stored == "" {
+		return nil
+	}
+
+	content, err := objectContent(d)
+	if err != nil {
+		// The content is unknown until apply or comes from a source, it cannot be compared
+		return nil
+	}
+	expected, _ := objectChecksum("SHA256", bytes.NewReader(content))
+	if expected != stored {
+		log.Printf("[DEBUG] Content of object %s changed outside of Terraform (SHA256 %s instead of %s)", d.Id(), stored, expected)
+		return d.SetNew("checksum_sha256", expected)
+	}
+	return nil
+}
+
+// bucketObjectEncryption returns the server side encryption to request for the uploads into a bucket, from its
+// encryption configuration. It fails when the bucket has none, as the objects would be stored unencrypted.
+func bucketObjectEncryption(ctx context.Context, client *minio.Client, bucket string) (encrypt.ServerSide, error) {
+	config, err := client.GetBucketEncryption(ctx, bucket)
+	if err != nil {
+		if minio.ToErrorResponse(err).Code == "ServerSideEncryptionConfigurationNotFoundError" {
+			return nil, fmt.Errorf("bucket %s enforces object encryption but has no encryption configuration", bucket)
+		}
+		return nil, err
+	}
+
+	for _, rule := range config.Rules {
+		switch rule.Apply.SSEAlgorithm {
+		case "AES256":
+			return encrypt.NewSSE(), nil
+		case "aws:kms":
+			return encrypt.NewSSEKMS(rule.Apply.KmsMasterKeyID, nil)
+		}
+	}
+	return nil, fmt.Errorf("bucket %s enforces object encryption but its encryption configuration has no supported algorithm", bucket)
+}
+
+// checkObjectEncrypted removes an uploaded object the server stored unencrypted, e.g. without a KMS configured
+func checkObjectEncrypted(ctx context.Context, client *minio.Client, upload minio.UploadInfo) error {
+	objInfo, err := client.StatObject(ctx, upload.Bucket, upload.Key, minio.StatObjectOptions{VersionID: upload.VersionID})
+	if err != nil {
+		return err
+	}
+	if objInfo.Metadata.Get("X-Amz-Server-Side-Encryption") != "" {
+		return nil
+	}
+
+	log.Printf("[DEBUG] Removing object %s/%s stored unencrypted", upload.Bucket, upload.Key)
+	if err := client.RemoveObject(ctx, upload.Bucket, upload.Key, minio.RemoveObjectOptions{VersionID: upload.VersionID}); err != nil {
+		return fmt.Errorf("object was stored unencrypted and could not be removed: %w", err)
+	}
+	return fmt.Errorf("object was stored unencrypted in bucket %s, which enforces object encryption", upload.Bucket)
+}
+
+func getObjectRetention(d *schema.ResourceData) (minio.RetentionMode, time.Time, bool) {
+	if _, ok := d.GetOk("retention.0"); !ok {
+		return "", time.Time{}, false
+	}
+
+	// Validated by the schema
+	retainUntilDate, _ := time.Parse(time.RFC3339, d.Get("retention.0.retain_until_date").(string))
+	return minio.RetentionMode(d.Get("retention.0.mode").(string)), retainUntilDate, true
 }
 
 func minioDeleteObject(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -0,0 +1,168 @@
+package minio
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/minio/madmin-go"
+)
+
+// objectLambdaWebhookSubSys is the configuration subsystem of the object lambda webhooks, which madmin does not
+// list as it is only known to the recent servers
+const objectLambdaWebhookSubSys = "lambda_webhook"
+
+func resourceMinioS3ObjectLambdaWebhook() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: minioPutObjectLambdaWebhook,
+		ReadContext:   minioReadObjectLambdaWebhook,
+		UpdateContext: minioPutObjectLambdaWebhook,
+		DeleteContext: minioDeleteObjectLambdaWebhook,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		CustomizeDiff: minioValidateObjectLambdaSupport,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[A-Za-z0-9_-]+$`), "must only contain alphanumeric characters, hyphens and underscores"),
+				Description:  "Name of the function, which GetObject requests select with the arn",
+			},
+			"endpoint": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+				Description:  "URL of the webhook transforming the objects, called by the server with a presigned URL of the original object",
+			},
+			"auth_token": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Token sent by the server in the Authorization header of the webhook calls",
+			},
+			"arn": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ARN to set as the lambdaArn parameter of the GetObject requests to read the transformed objects",
+			},
+		},
+	}
+}
+
+func minioPutObjectLambdaWebhook(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	admin := meta.(*S3MinioClient).S3Admin
+	name := d.Get("name").(string)
+
+	cfgData := objectLambdaWebhookConfig(name, d.Get("endpoint").(string), d.Get("auth_token").(string))
+
+	log.Printf("[DEBUG] Setting object lambda webhook %s to %s", name, d.Get("endpoint").(string))
+	restart, err := admin.SetConfigKV(ctx, cfgData)
+	if err != nil {
+		return NewResourceError("unable to set object lambda webhook", name, err)
+	}
+
+	d.SetId(name)
+
+	diags := minioReadObjectLambdaWebhook(ctx, d, meta)
+	if restart {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "MinIO restart required",
+			Detail:   fmt.Sprintf("The object lambda webhook %s was saved, the server must be restarted to apply it", name),
+		})
+	}
+	return diags
+}
+
+func minioReadObjectLambdaWebhook(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	admin := meta.(*S3MinioClient).S3Admin
+
+	output, err := admin.GetConfigKV(ctx, objectLambdaWebhookSubSys+":"+d.Id())
+	if err != nil {
+		return NewResourceError("unable to read object lambda webhook", d.Id(), err)
+	}
+
+	config, err := madmin.ParseServerConfigOutput(string(output))
+	if err != nil {
+		return NewResourceError("unable to parse object lambda webhook", d.Id(), err)
+	}
+
+	endpoint, found := objectLambdaWebhookEndpoint(config, d.Id())
+	if !found {
+		log.Printf("[WARN] Object lambda webhook %s not found, removing it from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	// The auth token is not read back, the server does not return secrets
+	_ = d.Set("name", d.Id())
+	_ = d.Set("endpoint", endpoint)
+	_ = d.Set("arn", objectLambdaWebhookARN(d.Id()))
+
+	return nil
+}
+
+func minioDeleteObjectLambdaWebhook(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	admin := meta.(*S3MinioClient).S3Admin
+
+	log.Printf("[DEBUG] Removing object lambda webhook %s", d.Id())
+	if _, err := admin.DelConfigKV(ctx, objectLambdaWebhookSubSys+":"+d.Id()); err != nil {
+		return NewResourceError("unable to remove object lambda webhook", d.Id(), err)
+	}
+
+	return nil
+}
+
+// minioValidateObjectLambdaSupport fails the plan of a new webhook when the server has no object lambda
+// configuration, rather than in the middle of the apply
+func minioValidateObjectLambdaSupport(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	m, ok := meta.(*S3MinioClient)
+	if !ok || d.Id() != "" {
+		return nil
+	}
+
+	if _, err := m.S3Admin.HelpConfigKV(ctx, objectLambdaWebhookSubSys, "", false); err != nil {
+		return fmt.Errorf("the server does not support object lambda webhooks, its %s configuration cannot be read: %w", objectLambdaWebhookSubSys, err)
+	}
+	return nil
+}
+
+// objectLambdaWebhookConfig returns the configuration of a webhook to set on the server
+func objectLambdaWebhookConfig(name string, endpoint string, authToken string) string {
+	args := []string{
+		fmt.Sprintf("%s:%s", objectLambdaWebhookSubSys, name),
+		fmt.Sprintf("%s=%s", madmin.EnableKey, madmin.EnableOn),
+		fmt.Sprintf("endpoint=%q", endpoint),
+	}
+	if authToken != "" {
+		args = append(args, fmt.Sprintf("auth_token=%q", authToken))
+	}
+	return strings.Join(args, " ")
+}
+
+// objectLambdaWebhookEndpoint returns the endpoint of an enabled webhook
+func objectLambdaWebhookEndpoint(config []madmin.SubsysConfig, name string) (string, bool) {
+	for _, subsys := range config {
+		if subsys.SubSystem != objectLambdaWebhookSubSys || subsys.Target != name {
+			continue
+		}
+		if enable, ok := subsys.Lookup(madmin.EnableKey); ok && enable != madmin.EnableOn {
+			return "", false
+		}
+		endpoint, ok := subsys.Lookup("endpoint")
+		return endpoint, ok && endpoint != ""
+	}
+	return "", false
+}
+
+func objectLambdaWebhookARN(name string) string {
+	return fmt.Sprintf("arn:minio:s3-object-lambda::%s:webhook", name)
+}
@@ -0,0 +1,41 @@
+package minio
+
+import (
+	"testing"
+
+	"github.com/minio/madmin-go"
+)
+
+func TestObjectLambdaWebhookConfig(t *testing.T) {
+	expected := `lambda_webhook:redact enable=on endpoint="http://redactor:8080/transform"`
+	if config := objectLambdaWebhookConfig("redact", "http://redactor:8080/transform", ""); config != expected {
+		t.Errorf("expected %s, got %s", expected, config)
+	}
+
+	expected += ` auth_token="secret token"`
+	if config := objectLambdaWebhookConfig("redact", "http://redactor:8080/transform", "secret token"); config != expected {
+		t.Errorf("expected %s, got %s", expected, config)
+	}
+}
+
+func TestObjectLambdaWebhookEndpoint(t *testing.T) {
+	config, err := madmin.ParseServerConfigOutput(`lambda_webhook:redact enable=on endpoint=http://redactor:8080/transform auth_token=
+lambda_webhook:disabled enable=off endpoint=http://disabled:8080/`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if endpoint, found := objectLambdaWebhookEndpoint(config, "redact"); !found || endpoint != "http://redactor:8080/transform" {
+		t.Errorf("expected the endpoint of redact, got %q (%t)", endpoint, found)
+	}
+	if _, found := objectLambdaWebhookEndpoint(config, "disabled"); found {
+		t.Error("expected a disabled webhook not to be found")
+	}
+	if _, found := objectLambdaWebhookEndpoint(config, "missing"); found {
+		t.Error("expected a missing webhook not to be found")
+	}
+
+	if arn := objectLambdaWebhookARN("redact"); arn != "arn:minio:s3-object-lambda::redact:webhook" {
+		t.Errorf("unexpected arn %s", arn)
+	}
+}
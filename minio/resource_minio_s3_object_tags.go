@@ -0,0 +1,123 @@
+package minio
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/tags"
+)
+
+func resourceMinioObjectTags() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: minioPutObjectTags,
+		ReadContext:   minioReadObjectTags,
+		UpdateContext: minioPutObjectTags,
+		DeleteContext: minioDeleteObjectTags,
+		Importer: &schema.ResourceImporter{
+			StateContext: minioImportObjectTags,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"bucket": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateMinioBucketName,
+			},
+			"key": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"tags": {
+				Type:        schema.TypeMap,
+				Required:    true,
+				Description: "Tags of the object. They replace the tags set when the object was uploaded",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func minioPutObjectTags(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	m := meta.(*S3MinioClient)
+	bucket := d.Get("bucket").(string)
+	key := d.Get("key").(string)
+	id := fmt.Sprintf("%s/%s", bucket, key)
+
+	tagMap := map[string]string{}
+	for k, v := range d.Get("tags").(map[string]interface{}) {
+		tagMap[k] = v.(string)
+	}
+
+	objectTags, err := tags.NewTags(tagMap, true)
+	if err != nil {
+		return NewResourceError("invalid object tags", id, err)
+	}
+
+	log.Printf("[DEBUG] Setting tags of object %s: %v", id, tagMap)
+	if err := m.S3Client.PutObjectTagging(ctx, bucket, key, objectTags, minio.PutObjectTaggingOptions{}); err != nil {
+		return NewResourceError("setting object tags failed", id, err)
+	}
+
+	d.SetId(id)
+
+	return minioReadObjectTags(ctx, d, meta)
+}
+
+func minioReadObjectTags(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	m := meta.(*S3MinioClient)
+	bucket := d.Get("bucket").(string)
+	key := d.Get("key").(string)
+
+	objectTags, err := m.S3Client.GetObjectTagging(ctx, bucket, key, minio.GetObjectTaggingOptions{})
+	if err != nil {
+		if code := minio.ToErrorResponse(err).Code; code == "NoSuchKey" || code == "NoSuchBucket" {
+			log.Printf("[WARN] Object %s not found, removing its tags from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return NewResourceError("reading object tags failed", d.Id(), err)
+	}
+
+	if err := d.Set("tags", objectTags.ToMap()); err != nil {
+		return NewResourceError("reading object tags failed", d.Id(), err)
+	}
+
+	return nil
+}
+
+func minioDeleteObjectTags(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	m := meta.(*S3MinioClient)
+	bucket := d.Get("bucket").(string)
+	key := d.Get("key").(string)
+
+	err := m.S3Client.RemoveObjectTagging(ctx, bucket, key, minio.RemoveObjectTaggingOptions{})
+	if err != nil {
+		if code := minio.ToErrorResponse(err).Code; code == "NoSuchKey" || code == "NoSuchBucket" {
+			return nil
+		}
+		return NewResourceError("removing object tags failed", d.Id(), err)
+	}
+
+	return nil
+}
+
+func minioImportObjectTags(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	idParts := strings.SplitN(d.Id(), "/", 2)
+	if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
+		return nil, fmt.Errorf("unexpected format of ID (%q), expected <bucket>/<key>", d.Id())
+	}
+
+	_ = d.Set("bucket", idParts[0])
+	_ = d.Set("key", idParts[1])
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,109 @@
+package minio
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/minio/minio-go/v7"
+)
+
+func TestAccMinioS3ObjectTags_basic(t *testing.T) {
+	bucketName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "minio_s3_object_tags.tags"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckMinioS3ObjectTagsDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMinioS3ObjectTagsConfig(bucketName, `{ tier = "hot" }`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMinioS3ObjectTags(resourceName, map[string]string{"tier": "hot"}),
+					resource.TestCheckResourceAttr(resourceName, "id", bucketName+"/data/report.csv"),
+					resource.TestCheckResourceAttr(resourceName, "tags.tier", "hot"),
+				),
+			},
+			{
+				Config: testAccMinioS3ObjectTagsConfig(bucketName, `{ tier = "cold", owner = "finance" }`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMinioS3ObjectTags(resourceName, map[string]string{"tier": "cold", "owner": "finance"}),
+					resource.TestCheckResourceAttr(resourceName, "tags.%", "2"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccMinioS3ObjectTagsConfig(bucketName string, tags string) string {
+	return fmt.Sprintf(`
+resource "minio_s3_bucket" "bucket" {
+  bucket = %q
+}
+
+resource "minio_s3_object" "object" {
+  bucket_name = minio_s3_bucket.bucket.id
+  object_name = "data/report.csv"
+  content     = "id,amount"
+}
+
+resource "minio_s3_object_tags" "tags" {
+  bucket = minio_s3_object.object.bucket_name
+  key    = minio_s3_object.object.object_name
+  tags   = %s
+}
+`, bucketName, tags)
+}
+
+func testAccCheckMinioS3ObjectTags(n string, expected map[string]string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+
+		minioC := testAccProvider.Meta().(*S3MinioClient).S3Client
+		objectTags, err := minioC.GetObjectTagging(context.Background(), rs.Primary.Attributes["bucket"], rs.Primary.Attributes["key"], minio.GetObjectTaggingOptions{})
+		if err != nil {
+			return fmt.Errorf("unable to read tags of %s: %v", rs.Primary.ID, err)
+		}
+
+		actual := objectTags.ToMap()
+		if len(actual) != len(expected) {
+			return fmt.Errorf("object %s has tags %v instead of %v", rs.Primary.ID, actual, expected)
+		}
+		for k, v := range expected {
+			if actual[k] != v {
+				return fmt.Errorf("object %s has tags %v instead of %v", rs.Primary.ID, actual, expected)
+			}
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckMinioS3ObjectTagsDestroy(s *terraform.State) error {
+	minioC := testAccProvider.Meta().(*S3MinioClient).S3Client
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "minio_s3_object_tags" {
+			continue
+		}
+
+		objectTags, err := minioC.GetObjectTagging(context.Background(), rs.Primary.Attributes["bucket"], rs.Primary.Attributes["key"], minio.GetObjectTaggingOptions{})
+		if err == nil && len(objectTags.ToMap()) != 0 {
+			return fmt.Errorf("object %s still has tags %v", rs.Primary.ID, objectTags.ToMap())
+		}
+	}
+
+	return nil
+}
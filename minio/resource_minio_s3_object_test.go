@@ -0,0 +1,441 @@
+package minio
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/minio/minio-go/v7/pkg/encrypt"
+)
+
+func TestAccMinioS3Object_checksum(t *testing.T) {
+	bucketName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "minio_s3_object.object"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMinioS3ObjectConfigChecksum(bucketName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "checksum_sha256", "LPJNul+wow4m6DsqxbninhsWHlwfp0JecwQzYpOLmCQ="),
+					resource.TestCheckResourceAttrSet(resourceName, "etag"),
+				),
+			},
+			{
+				// The object is overwritten outside of Terraform, the checksum shows the drift
+				PreConfig: func() {
+					_, err := testAccProvider.Meta().(*S3MinioClient).S3Client.PutObject(context.Background(), bucketName, "hello.txt", strings.NewReader("drift"), 5, minio.PutObjectOptions{})
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config:             testAccMinioS3ObjectConfigChecksum(bucketName),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccMinioS3ObjectConfigChecksum(bucketName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "checksum_sha256", "LPJNul+wow4m6DsqxbninhsWHlwfp0JecwQzYpOLmCQ="),
+					testAccCheckMinioS3ObjectContent(resourceName, "hello"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccMinioS3Object_source(t *testing.T) {
+	bucketName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "minio_s3_object.object"
+	source := filepath.Join(t.TempDir(), "source.txt")
+	if err := os.WriteFile(source, []byte("hello"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMinioS3ObjectConfigSource(bucketName, source),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "source_sha256", "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"),
+					testAccCheckMinioS3ObjectContent(resourceName, "hello"),
+				),
+			},
+			{
+				// The source file changes while its path stays the same
+				PreConfig: func() {
+					if err := os.WriteFile(source, []byte("hello again"), 0o600); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: testAccMinioS3ObjectConfigSource(bucketName, source),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMinioS3ObjectContent(resourceName, "hello again"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccMinioS3Object_legalHoldAndRetention(t *testing.T) {
+	bucketName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "minio_s3_object.object"
+	retainUntilDate := time.Now().Add(24 * time.Hour).UTC().Format(time.RFC3339)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMinioS3ObjectConfigLock(bucketName, true, retainUntilDate),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "legal_hold", "true"),
+					resource.TestCheckResourceAttr(resourceName, "retention.0.mode", "GOVERNANCE"),
+					resource.TestCheckResourceAttr(resourceName, "retention.0.retain_until_date", retainUntilDate),
+					testAccCheckMinioS3ObjectLock(resourceName, minio.LegalHoldEnabled, minio.Governance),
+				),
+			},
+			{
+				// The legal hold and the GOVERNANCE retention are released in place, so the object can be destroyed
+				Config: testAccMinioS3ObjectConfigLock(bucketName, false, ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "legal_hold", "false"),
+					resource.TestCheckResourceAttr(resourceName, "retention.#", "0"),
+					testAccCheckMinioS3ObjectLock(resourceName, minio.LegalHoldDisabled, ""),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckMinioS3ObjectLock(n string, legalHold minio.LegalHoldStatus, mode minio.RetentionMode) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+
+		client := testAccProvider.Meta().(*S3MinioClient).S3Client
+		bucketName, objectName := rs.Primary.Attributes["bucket_name"], rs.Primary.Attributes["object_name"]
+
+		status, err := client.GetObjectLegalHold(context.Background(), bucketName, objectName, minio.GetObjectLegalHoldOptions{})
+		if err != nil {
+			return err
+		}
+		if *status != legalHold {
+			return fmt.Errorf("expected legal hold %s, got %s", legalHold, *status)
+		}
+
+		retention, _, err := client.GetObjectRetention(context.Background(), bucketName, objectName, "")
+		if err != nil && minio.ToErrorResponse(err).Code != "NoSuchObjectLockConfiguration" {
+			return err
+		}
+		actual := minio.RetentionMode("")
+		if retention != nil {
+			actual = *retention
+		}
+		if actual != mode {
+			return fmt.Errorf("expected retention mode %q, got %q", mode, actual)
+		}
+		return nil
+	}
+}
+
+func testAccMinioS3ObjectConfigLock(bucketName string, locked bool, retainUntilDate string) string {
+	retention := ""
+	if retainUntilDate != "" {
+		retention = fmt.Sprintf(`
+  retention {
+    mode              = "GOVERNANCE"
+    retain_until_date = %q
+    governance_bypass = true
+  }`, retainUntilDate)
+	}
+
+	return fmt.Sprintf(`
+resource "minio_s3_bucket" "bucket" {
+  bucket         = %q
+  object_locking = true
+  force_destroy  = true
+}
+
+resource "minio_s3_object" "object" {
+  bucket_name = minio_s3_bucket.bucket.id
+  object_name = "locked.txt"
+  content     = "locked"
+  legal_hold  = %t
+%s
+}
+`, bucketName, locked, retention)
+}
+
+func TestGetObjectRetention(t *testing.T) {
+	d := resourceMinioObject().TestResourceData()
+	if _, _, ok := getObjectRetention(d); ok {
+		t.Error("expected no retention without a retention block")
+	}
+
+	d = schema.TestResourceDataRaw(t, resourceMinioObject().Schema, map[string]interface{}{
+		"bucket_name": "bucket",
+		"object_name": "object",
+		"retention": []interface{}{
+			map[string]interface{}{
+				"mode":              "COMPLIANCE",
+				"retain_until_date": "2099-01-01T00:00:00Z",
+			},
+		},
+	})
+	mode, retainUntilDate, ok := getObjectRetention(d)
+	if !ok || mode != minio.Compliance || !retainUntilDate.Equal(time.Date(2099, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected a COMPLIANCE retention until 2099-01-01, got %q until %s (%t)", mode, retainUntilDate, ok)
+	}
+}
+
+func TestAccMinioS3Object_withoutBucketTaggingPermission(t *testing.T) {
+	name := fmt.Sprintf("tf-test-project-%d", acctest.RandInt())
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckMinioProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				// The project credentials are only granted the object actions
+				Config: testAccMinioProjectConfig(name),
+				Check:  testAccCheckMinioS3ObjectUploadWithProjectCredentials("minio_project.project"),
+			},
+		},
+	})
+}
+
+// testAccCheckMinioS3ObjectUploadWithProjectCredentials uploads an object with the credentials of a project,
+// which cannot read the bucket tags
+func testAccCheckMinioS3ObjectUploadWithProjectCredentials(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+
+		config := &S3MinioConfig{
+			S3HostPort:     os.Getenv("MINIO_ENDPOINT"),
+			S3Region:       "us-east-1",
+			S3UserAccess:   rs.Primary.Attributes["access_key"],
+			S3UserSecret:   rs.Primary.Attributes["secret_key"],
+			S3APISignature: "v4",
+			S3SSL:          map[string]bool{"true": true, "false": false}[os.Getenv("MINIO_ENABLE_HTTPS")],
+		}
+		client, err := config.NewClient()
+		if err != nil {
+			return err
+		}
+
+		// The credentials are picked up by IAM asynchronously
+		return retry.RetryContext(context.Background(), time.Minute, func() *retry.RetryError {
+			r := resourceMinioObject()
+			d := r.TestResourceData()
+			_ = d.Set("bucket_name", rs.Primary.ID)
+			_ = d.Set("object_name", "object")
+			_ = d.Set("content", "uploaded without s3:GetBucketTagging")
+			if diags := r.CreateContext(context.Background(), d, client); diags.HasError() {
+				return retry.RetryableError(fmt.Errorf("upload failed: %s", diags[0].Summary))
+			}
+			return nil
+		})
+	}
+}
+
+func TestBucketEnforcesObjectEncryptionAccessDenied(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := r.URL.Query()["tagging"]; !ok {
+			w.WriteHeader(http.StatusNotImplemented)
+			return
+		}
+		switch strings.Trim(r.URL.Path, "/") {
+		case "denied":
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, `<Error><Code>AccessDenied</Code><Message>Access Denied.</Message></Error>`)
+		case "enforced":
+			fmt.Fprintf(w, `<Tagging><TagSet><Tag><Key>%s</Key><Value>true</Value></Tag></TagSet></Tagging>`, bucketEnforceEncryptionTag)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `<Error><Code>InvalidRequest</Code><Message>Invalid Request</Message></Error>`)
+		}
+	}))
+	defer server.Close()
+
+	client, err := minio.New(strings.TrimPrefix(server.URL, "http://"), &minio.Options{
+		Creds:  credentials.NewStaticV4("minio", "minio123", ""),
+		Region: "us-east-1",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if enforced, err := bucketEnforcesObjectEncryption(context.Background(), client, "denied"); err != nil || enforced {
+		t.Errorf("expected the uploads not to be checked without access to the bucket tags, got %t (%v)", enforced, err)
+	}
+	if enforced, err := bucketEnforcesObjectEncryption(context.Background(), client, "enforced"); err != nil || !enforced {
+		t.Errorf("expected the encryption to be enforced, got %t (%v)", enforced, err)
+	}
+	if _, err := bucketEnforcesObjectEncryption(context.Background(), client, "failing"); err == nil {
+		t.Error("expected the other errors to fail the upload")
+	}
+}
+
+func testAccCheckMinioS3ObjectContent(n string, expected string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+
+		object, err := testAccProvider.Meta().(*S3MinioClient).S3Client.GetObject(context.Background(), rs.Primary.Attributes["bucket_name"], rs.Primary.Attributes["object_name"], minio.GetObjectOptions{})
+		if err != nil {
+			return err
+		}
+		defer object.Close()
+
+		content, err := io.ReadAll(object)
+		if err != nil {
+			return err
+		}
+		if string(content) != expected {
+			return fmt.Errorf("expected object content %q, got %q", expected, content)
+		}
+		return nil
+	}
+}
+
+func testAccMinioS3ObjectConfigChecksum(bucketName string) string {
+	return fmt.Sprintf(`
+resource "minio_s3_bucket" "bucket" {
+  bucket = %q
+}
+
+resource "minio_s3_object" "object" {
+  bucket_name        = minio_s3_bucket.bucket.id
+  object_name        = "hello.txt"
+  content            = "hello"
+  checksum_algorithm = "SHA256"
+}
+`, bucketName)
+}
+
+func testAccMinioS3ObjectConfigSource(bucketName string, source string) string {
+	return fmt.Sprintf(`
+resource "minio_s3_bucket" "bucket" {
+  bucket = %q
+}
+
+resource "minio_s3_object" "object" {
+  bucket_name        = minio_s3_bucket.bucket.id
+  object_name        = "source.txt"
+  source             = %q
+  source_checksum    = true
+  part_size          = 5242880
+  upload_concurrency = 2
+}
+`, bucketName, source)
+}
+
+func TestSourceFileSHA256(t *testing.T) {
+	source := filepath.Join(t.TempDir(), "source.txt")
+	if err := os.WriteFile(source, []byte("hello"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	sum, err := sourceFileSHA256(source)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sum != "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824" {
+		t.Errorf("unexpected SHA256 %s", sum)
+	}
+
+	if _, err := sourceFileSHA256(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
+
+func TestObjectChecksum(t *testing.T) {
+	expected := map[string]string{
+		"CRC32":  "NhCmhg==",
+		"CRC32C": "mnG7TA==",
+		"SHA1":   "qvTGHdzF6KLavt4PO0gs2a6pQ00=",
+		"SHA256": "LPJNul+wow4m6DsqxbninhsWHlwfp0JecwQzYpOLmCQ=",
+	}
+
+	for _, algorithm := range objectChecksumAlgorithms {
+		checksum, err := objectChecksum(algorithm, strings.NewReader("hello"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if checksum != expected[algorithm] {
+			t.Errorf("%s: expected %s, got %s", algorithm, expected[algorithm], checksum)
+		}
+	}
+
+	if _, err := objectChecksum("MD5", strings.NewReader("hello")); err == nil {
+		t.Error("expected an error for an unsupported algorithm")
+	}
+}
+
+func TestBucketObjectEncryption(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := r.URL.Query()["encryption"]; !ok {
+			w.WriteHeader(http.StatusNotImplemented)
+			return
+		}
+		switch strings.Trim(r.URL.Path, "/") {
+		case "sse-s3":
+			fmt.Fprint(w, `<ServerSideEncryptionConfiguration><Rule><ApplyServerSideEncryptionByDefault><SSEAlgorithm>AES256</SSEAlgorithm></ApplyServerSideEncryptionByDefault></Rule></ServerSideEncryptionConfiguration>`)
+		case "sse-kms":
+			fmt.Fprint(w, `<ServerSideEncryptionConfiguration><Rule><ApplyServerSideEncryptionByDefault><SSEAlgorithm>aws:kms</SSEAlgorithm><KMSMasterKeyID>my-key</KMSMasterKeyID></ApplyServerSideEncryptionByDefault></Rule></ServerSideEncryptionConfiguration>`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `<Error><Code>ServerSideEncryptionConfigurationNotFoundError</Code><Message>The server side encryption configuration was not found</Message></Error>`)
+		}
+	}))
+	defer server.Close()
+
+	client, err := minio.New(strings.TrimPrefix(server.URL, "http://"), &minio.Options{
+		Creds:  credentials.NewStaticV4("minio", "minio123", ""),
+		Region: "us-east-1",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sse, err := bucketObjectEncryption(context.Background(), client, "sse-s3")
+	if err != nil || sse.Type() != encrypt.S3 {
+		t.Errorf("expected SSE-S3, got %v (%v)", sse, err)
+	}
+
+	sse, err = bucketObjectEncryption(context.Background(), client, "sse-kms")
+	if err != nil || sse.Type() != encrypt.KMS {
+		t.Errorf("expected SSE-KMS, got %v (%v)", sse, err)
+	}
+
+	if _, err := bucketObjectEncryption(context.Background(), client, "unencrypted"); err == nil || !strings.Contains(err.Error(), "no encryption configuration") {
+		t.Errorf("expected an error for a bucket without encryption, got %v", err)
+	}
+}
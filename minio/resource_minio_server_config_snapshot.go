@@ -0,0 +1,234 @@
+package minio
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/minio/madmin-go"
+	"golang.org/x/exp/slices"
+)
+
+// serverConfigSnapshotID is the ID of minio_server_config_snapshot, as a server has a single configuration
+const serverConfigSnapshotID = "server-config"
+
+// serverConfigRedacted replaces the sensitive values of the snapshot
+const serverConfigRedacted = "REDACTED"
+
+// serverConfigSensitiveKeys are the configuration keys holding credentials, whatever their subsystem
+var serverConfigSensitiveKeys = []string{
+	"api_key",
+	"auth_token",
+	"client_cert_key",
+	"client_key",
+	"client_secret",
+	"connection_string",
+	"dsn_string",
+	"license",
+	"lookup_bind_password",
+	"password",
+	"sasl_password",
+	"secret_key",
+	"token",
+}
+
+func resourceMinioServerConfigSnapshot() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: minioPutServerConfigSnapshot,
+		ReadContext:   minioReadServerConfigSnapshot,
+		UpdateContext: minioPutServerConfigSnapshot,
+		DeleteContext: minioDeleteServerConfigSnapshot,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"config": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Sensitive:        true,
+				ValidateFunc:     validateServerConfig,
+				DiffSuppressFunc: suppressEquivalentServerConfig,
+				Description:      "Configuration to import on the server, in the format of `mc admin config export`. It replaces the whole configuration: the subsystems it does not set go back to their defaults. Only its keys are compared to the server to detect drift",
+			},
+			"scrub_sensitive_values": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Replace the credentials found in snapshot (passwords, tokens, client secrets...) with " + serverConfigRedacted,
+			},
+			"snapshot": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Configuration exported from the server, in the format of `mc admin config export`. Environment variables overriding it are left out",
+			},
+		},
+	}
+}
+
+func minioPutServerConfigSnapshot(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	admin := meta.(*S3MinioClient).S3Admin
+
+	var diags diag.Diagnostics
+	if config, ok := d.GetOk("config"); ok && (d.IsNewResource() || d.HasChange("config")) {
+		log.Printf("[DEBUG] Importing server configuration")
+		if err := admin.SetConfig(ctx, bytes.NewBufferString(config.(string))); err != nil {
+			return NewResourceError("unable to import server configuration", serverConfigSnapshotID, err)
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "MinIO restart required",
+			Detail:   "The server configuration was imported, the server must be restarted to apply it",
+		})
+	}
+
+	d.SetId(serverConfigSnapshotID)
+
+	return append(diags, minioReadServerConfigSnapshot(ctx, d, meta)...)
+}
+
+func minioReadServerConfigSnapshot(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	admin := meta.(*S3MinioClient).S3Admin
+
+	output, err := admin.GetConfig(ctx)
+	if err != nil {
+		return NewResourceError("unable to export server configuration", d.Id(), err)
+	}
+
+	serverConfig, err := madmin.ParseServerConfigOutput(string(output))
+	if err != nil {
+		return NewResourceError("unable to parse server configuration", d.Id(), err)
+	}
+
+	_ = d.Set("snapshot", renderServerConfig(serverConfig, nil, d.Get("scrub_sensitive_values").(bool)))
+
+	if config, ok := d.GetOk("config"); ok {
+		wanted, err := madmin.ParseServerConfigOutput(config.(string))
+		if err != nil {
+			return NewResourceError("unable to parse server configuration", d.Id(), err)
+		}
+		// Only the keys managed by the resource are reported, with their value on the server
+		_ = d.Set("config", renderServerConfig(serverConfig, wanted, false))
+	}
+
+	return nil
+}
+
+func minioDeleteServerConfigSnapshot(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// The configuration of the server is left as is, there is nothing to go back to
+	log.Printf("[DEBUG] Removing server configuration snapshot from state, the server configuration is unchanged")
+	d.SetId("")
+	return nil
+}
+
+// renderServerConfig renders configuration subsystems in the format of `mc admin config export`. When keys is
+// set, only its subsystems and keys are rendered, in its order, a key missing on the server being left out.
+func renderServerConfig(config []madmin.SubsysConfig, keys []madmin.SubsysConfig, scrub bool) string {
+	if keys == nil {
+		keys = config
+	}
+
+	lines := []string{}
+	for _, wanted := range keys {
+		idx := slices.IndexFunc(config, func(c madmin.SubsysConfig) bool {
+			return c.SubSystem == wanted.SubSystem && c.Target == wanted.Target
+		})
+		if idx < 0 {
+			continue
+		}
+
+		line := wanted.SubSystem
+		if wanted.Target != "" {
+			line += madmin.SubSystemSeparator + wanted.Target
+		}
+		for _, kv := range wanted.KV {
+			serverKV := slices.IndexFunc(config[idx].KV, func(c madmin.ConfigKV) bool { return c.Key == kv.Key })
+			if serverKV < 0 || isServerConfigEnvOnly(config[idx].KV[serverKV]) {
+				continue
+			}
+			value := config[idx].KV[serverKV].Value
+			if scrub && value != "" && slices.Contains(serverConfigSensitiveKeys, kv.Key) {
+				value = serverConfigRedacted
+			}
+			if value == "" || madmin.HasSpace(value) {
+				value = madmin.KvDoubleQuote + value + madmin.KvDoubleQuote
+			}
+			line += madmin.KvSpaceSeparator + kv.Key + madmin.KvSeparator + value
+		}
+		lines = append(lines, line)
+	}
+
+	return strings.Join(lines, madmin.KvNewline)
+}
+
+// serverConfigValues flattens configuration subsystems into "subsystem[:target] key" to value
+func serverConfigValues(config []madmin.SubsysConfig) map[string]string {
+	values := map[string]string{}
+	for _, subsys := range config {
+		name := subsys.SubSystem
+		if subsys.Target != "" {
+			name += madmin.SubSystemSeparator + subsys.Target
+		}
+		for _, kv := range subsys.KV {
+			if !isServerConfigEnvOnly(kv) {
+				values[name+madmin.KvSpaceSeparator+kv.Key] = kv.Value
+			}
+		}
+	}
+	return values
+}
+
+// isServerConfigEnvOnly returns whether a key is only set through an environment variable of the server, which
+// the configuration cannot override
+func isServerConfigEnvOnly(kv madmin.ConfigKV) bool {
+	return kv.EnvOverride != nil && kv.Value == ""
+}
+
+func suppressEquivalentServerConfig(k, old, new string, d *schema.ResourceData) bool {
+	oldConfig, err := madmin.ParseServerConfigOutput(old)
+	if err != nil {
+		return false
+	}
+	newConfig, err := madmin.ParseServerConfigOutput(new)
+	if err != nil {
+		return false
+	}
+
+	oldValues := serverConfigValues(oldConfig)
+	newValues := serverConfigValues(newConfig)
+	if len(oldValues) != len(newValues) {
+		return false
+	}
+	for key, value := range newValues {
+		if oldValue, ok := oldValues[key]; !ok || oldValue != value {
+			return false
+		}
+	}
+	return true
+}
+
+func validateServerConfig(v interface{}, k string) (ws []string, errors []error) {
+	config, err := madmin.ParseServerConfigOutput(v.(string))
+	if err != nil {
+		errors = append(errors, fmt.Errorf("%q: invalid server configuration: %w", k, err))
+		return
+	}
+
+	unknown := []string{}
+	for _, subsys := range config {
+		if !madmin.SubSystems.Contains(subsys.SubSystem) && !slices.Contains(unknown, subsys.SubSystem) {
+			unknown = append(unknown, subsys.SubSystem)
+		}
+	}
+	if len(unknown) != 0 {
+		sort.Strings(unknown)
+		// Newer servers may have subsystems this provider does not know about
+		ws = append(ws, fmt.Sprintf("%q: unknown configuration subsystems: %s", k, strings.Join(unknown, ", ")))
+	}
+	return
+}
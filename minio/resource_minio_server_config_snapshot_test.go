@@ -0,0 +1,88 @@
+package minio
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/minio/madmin-go"
+)
+
+func TestAccMinioServerConfigSnapshot_basic(t *testing.T) {
+	resourceName := "minio_server_config_snapshot.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `resource "minio_server_config_snapshot" "test" {}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", serverConfigSnapshotID),
+					resource.TestMatchResourceAttr(resourceName, "snapshot", regexp.MustCompile(`(?m)^region `)),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestRenderServerConfig(t *testing.T) {
+	config, err := madmin.ParseServerConfigOutput(`
+# MINIO_NOTIFY_WEBHOOK_ENDPOINT_PRIMARY=http://localhost:8080
+notify_webhook:primary enable=on endpoint=http://localhost:8080 auth_token=secret queue_dir=
+region name=us-east-1 comment="main region"
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `notify_webhook:primary enable=on endpoint=http://localhost:8080 auth_token=REDACTED queue_dir=""
+region name=us-east-1 comment="main region"`
+	if actual := renderServerConfig(config, nil, true); actual != expected {
+		t.Errorf("expected %s, got %s", expected, actual)
+	}
+
+	wanted, err := madmin.ParseServerConfigOutput("region comment=\"\" missing=value\nsite name=dc1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if actual := renderServerConfig(config, wanted, false); actual != `region comment="main region"` {
+		t.Errorf("expected only the wanted keys to be rendered, got %s", actual)
+	}
+}
+
+func TestSuppressEquivalentServerConfig(t *testing.T) {
+	cases := []struct {
+		old      string
+		new      string
+		expected bool
+	}{
+		{"region name=us-east-1", `region name="us-east-1"`, true},
+		{"region name=us-east-1\napi requests_max=0", "# comment\napi requests_max=0\nregion name=us-east-1", true},
+		{"region name=us-east-1", "region name=us-west-1", false},
+		{"region name=us-east-1", "region name=us-east-1 comment=main", false},
+	}
+
+	for _, c := range cases {
+		if actual := suppressEquivalentServerConfig("config", c.old, c.new, nil); actual != c.expected {
+			t.Errorf("expected %t comparing %q and %q, got %t", c.expected, c.old, c.new, actual)
+		}
+	}
+}
+
+func TestValidateServerConfig(t *testing.T) {
+	if ws, errs := validateServerConfig("region name=us-east-1", "config"); len(ws) != 0 || len(errs) != 0 {
+		t.Errorf("expected a valid configuration, got %v, %v", ws, errs)
+	}
+	if ws, _ := validateServerConfig("unknown_subsys key=value", "config"); len(ws) != 1 {
+		t.Errorf("expected a warning for unknown subsystems, got %v", ws)
+	}
+	if _, errs := validateServerConfig("region name", "config"); len(errs) != 1 {
+		t.Errorf("expected an error for invalid key values, got %v", errs)
+	}
+}
@@ -39,6 +39,19 @@ func resourceMinioServiceAccount() *schema.Resource {
 				Default:     false,
 				Description: "rotate secret key",
 			},
+			"policy": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Description:      "Session policy restricting the service account, in JSON. A policy attached outside of Terraform is removed unless ignore_external_policy is set",
+				ValidateFunc:     validateIAMPolicyJSON,
+				DiffSuppressFunc: suppressEquivalentAwsPolicyDiffs,
+			},
+			"ignore_external_policy": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Ignore a session policy attached outside of Terraform when policy is not set",
+			},
 			"status": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -63,8 +76,13 @@ func minioCreateServiceAccount(ctx context.Context, d *schema.ResourceData, meta
 	var err error
 	targetUser := serviceAccountConfig.MinioTargetUser
 
+	var policy []byte
+	if serviceAccountConfig.MinioPolicy != "" {
+		policy = []byte(serviceAccountConfig.MinioPolicy)
+	}
+
 	serviceAccount, err := serviceAccountConfig.MinioAdmin.AddServiceAccount(ctx, madmin.AddServiceAccountReq{
-		Policy:     nil,
+		Policy:     policy,
 		TargetUser: targetUser,
 	})
 	if err != nil {
@@ -111,6 +129,20 @@ func minioUpdateServiceAccount(ctx context.Context, d *schema.ResourceData, meta
 		}
 	}
 
+	if d.HasChange("policy") {
+		// An empty policy removes the session policy, while a nil one leaves it unchanged
+		policy := serviceAccountConfig.MinioPolicy
+		if policy == "" {
+			policy = "{}"
+		}
+		err := serviceAccountConfig.MinioAdmin.UpdateServiceAccount(ctx, serviceAccountConfig.MinioAccessKey, madmin.UpdateServiceAccountReq{
+			NewPolicy: []byte(policy),
+		})
+		if err != nil {
+			return NewResourceError("error updating service account policy", d.Id(), err)
+		}
+	}
+
 	wantedSecret := serviceAccountConfig.MinioAccessKey
 	if serviceAccountConfig.MinioUpdateKey {
 		if secretKey, err := generateSecretAccessKey(); err != nil {
@@ -154,6 +186,18 @@ func minioReadServiceAccount(ctx context.Context, d *schema.ResourceData, meta i
 		return NewResourceError("reading service account failed", d.Id(), err)
 	}
 
+	// The server reports the policy of the parent user when there is no session policy
+	policy := output.Policy
+	if output.ImpliedPolicy {
+		policy = ""
+	} else if serviceAccountConfig.MinioPolicy == "" && d.Get("ignore_external_policy").(bool) {
+		log.Printf("[DEBUG] Ignoring the session policy of service account %s attached outside of Terraform", d.Id())
+		policy = ""
+	}
+	if err := d.Set("policy", policy); err != nil {
+		return NewResourceError("reading service account failed", d.Id(), err)
+	}
+
 	return nil
 }
 
@@ -88,6 +88,50 @@ func TestServiceAccount_RotateAccessKey(t *testing.T) {
 	})
 }
 
+func TestServiceAccount_Policy(t *testing.T) {
+	var serviceAccount madmin.InfoServiceAccountResp
+
+	targetUser := "minio"
+	resourceName := "minio_iam_service_account.test4"
+	policy := `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["s3:ListAllMyBuckets"],"Resource":["arn:aws:s3:::*"]}]}`
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckMinioServiceAccountDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMinioServiceAccountConfigPolicy(targetUser, fmt.Sprintf("policy = %q", policy)),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMinioServiceAccountExists(resourceName, &serviceAccount),
+					testAccCheckMinioServiceAccountPolicy(resourceName, true),
+				),
+			},
+			{
+				Config: testAccMinioServiceAccountConfigPolicy(targetUser, ""),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMinioServiceAccountPolicy(resourceName, false),
+					resource.TestCheckResourceAttr(resourceName, "policy", ""),
+					// Attach a policy outside of Terraform for the next steps
+					testAccCheckMinioServiceAccountSetPolicy(resourceName, policy),
+				),
+			},
+			{
+				Config:             testAccMinioServiceAccountConfigPolicy(targetUser, ""),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccMinioServiceAccountConfigPolicy(targetUser, "ignore_external_policy = true"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMinioServiceAccountPolicy(resourceName, true),
+					resource.TestCheckResourceAttr(resourceName, "policy", ""),
+				),
+			},
+		},
+	})
+}
+
 func testAccMinioServiceAccountConfig(rName string) string {
 	return fmt.Sprintf(`
 	resource "minio_iam_service_account" "test" {
@@ -119,6 +163,15 @@ resource "minio_iam_service_account" "test3" {
 `, rName)
 }
 
+func testAccMinioServiceAccountConfigPolicy(rName string, extra string) string {
+	return fmt.Sprintf(`
+resource "minio_iam_service_account" "test4" {
+  target_user = %q
+  %s
+}
+`, rName, extra)
+}
+
 func testAccCheckMinioServiceAccountExists(n string, res *madmin.InfoServiceAccountResp) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -238,3 +291,38 @@ func testAccCheckMinioServiceAccountRotatesAccessKey(n string, oldAccessKey *str
 		return nil
 	}
 }
+
+func testAccCheckMinioServiceAccountPolicy(n string, sessionPolicy bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+
+		minioIam := testAccProvider.Meta().(*S3MinioClient).S3Admin
+
+		resp, err := minioIam.InfoServiceAccount(context.Background(), rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("error getting service account %s", err)
+		}
+
+		if resp.ImpliedPolicy == sessionPolicy {
+			return fmt.Errorf("expected the service account session policy to be %t, got %t", sessionPolicy, !resp.ImpliedPolicy)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckMinioServiceAccountSetPolicy(n string, policy string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+
+		minioIam := testAccProvider.Meta().(*S3MinioClient).S3Admin
+
+		return minioIam.UpdateServiceAccount(context.Background(), rs.Primary.ID, madmin.UpdateServiceAccountReq{NewPolicy: []byte(policy)})
+	}
+}
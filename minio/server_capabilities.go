@@ -0,0 +1,135 @@
+package minio
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/minio/madmin-go"
+)
+
+// minioReleaseTagFormat is the time layout of the release tags, after their RELEASE. prefix
+const minioReleaseTagFormat = "2006-01-02T15-04-05Z"
+
+// minioFeature is a server feature only available from a given release
+type minioFeature struct {
+	Name    string
+	Release time.Time
+}
+
+// Features checked at plan time, so an older server fails with the release to upgrade to rather than with an
+// API error in the middle of an apply
+var (
+	minioFeatureExistingObjectReplication = minioFeature{
+		Name:    "existing_object_replication",
+		Release: time.Date(2021, time.June, 7, 21, 40, 51, 0, time.UTC),
+	}
+	minioFeatureVersioningExcludedPrefixes = minioFeature{
+		Name:    "excluded_prefixes",
+		Release: time.Date(2022, time.July, 8, 0, 5, 23, 0, time.UTC),
+	}
+	minioFeatureVersioningExcludeFolders = minioFeature{
+		Name:    "exclude_folders",
+		Release: time.Date(2022, time.August, 2, 23, 59, 16, 0, time.UTC),
+	}
+)
+
+// check returns an error when the release predates the feature. An unknown release passes.
+func (f minioFeature) check(release time.Time) error {
+	if release.IsZero() || !release.Before(f.Release) {
+		return nil
+	}
+	return fmt.Errorf("%s requires MinIO >= %s, the server runs %s", f.Name, minioReleaseTag(f.Release), minioReleaseTag(release))
+}
+
+// serverRelease reads the release of the cluster once, and shares it between the resources of a provider run
+type serverRelease struct {
+	admin *madmin.AdminClient
+
+	mu      sync.Mutex
+	release time.Time
+	fetched bool
+}
+
+func newServerRelease(admin *madmin.AdminClient) *serverRelease {
+	return &serverRelease{admin: admin}
+}
+
+// Get returns the release of the oldest server of the cluster, or a zero time for development builds. Errors
+// are not cached, the next call reads the release again.
+func (s *serverRelease) Get(ctx context.Context) (time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.fetched {
+		return s.release, nil
+	}
+
+	info, err := s.admin.ServerInfo(ctx)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	s.release = oldestMinioRelease(info.Servers)
+	s.fetched = true
+	return s.release, nil
+}
+
+// requireMinioFeatures returns an error naming the first feature the server release does not support. The
+// check is skipped when the release cannot be read, e.g. without admin permissions, and the API has the
+// final say.
+func requireMinioFeatures(ctx context.Context, meta interface{}, features ...minioFeature) error {
+	m, ok := meta.(*S3MinioClient)
+	if len(features) == 0 || !ok || m.ServerRelease == nil {
+		return nil
+	}
+
+	release, err := m.ServerRelease.Get(ctx)
+	if err != nil {
+		log.Printf("[WARN] Unable to read the server version, skipping the feature checks: %v", err)
+		return nil
+	}
+
+	for _, feature := range features {
+		if err := feature.check(release); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// oldestMinioRelease returns the release of the oldest server. In a cluster being upgraded, it decides which
+// features are available. Servers of an unknown version are ignored.
+func oldestMinioRelease(servers []madmin.ServerProperties) time.Time {
+	var release time.Time
+	for _, server := range servers {
+		serverRelease := parseMinioRelease(server.Version)
+		if !serverRelease.IsZero() && (release.IsZero() || serverRelease.Before(release)) {
+			release = serverRelease
+		}
+	}
+	return release
+}
+
+// parseMinioRelease parses a server version, either a release time (2023-03-20T20:16:18Z) or a release tag
+// (RELEASE.2023-03-20T20-16-18Z). It returns a zero time for development builds or unknown formats.
+func parseMinioRelease(version string) time.Time {
+	if release, err := time.Parse(time.RFC3339, version); err == nil {
+		return release
+	}
+	if release, err := time.Parse(minioReleaseTagFormat, strings.TrimPrefix(version, "RELEASE.")); err == nil {
+		return release
+	}
+	return time.Time{}
+}
+
+// minioReleaseTag formats a release time as a release tag, e.g. RELEASE.2023-03-20T20-16-18Z
+func minioReleaseTag(release time.Time) string {
+	if release.IsZero() {
+		return ""
+	}
+	return "RELEASE." + release.UTC().Format(minioReleaseTagFormat)
+}
@@ -0,0 +1,61 @@
+package minio
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/minio/madmin-go"
+)
+
+func TestOldestMinioRelease(t *testing.T) {
+	servers := []madmin.ServerProperties{
+		{Version: "2023-03-20T20:16:18Z"},
+		{Version: "DEVELOPMENT.GOGET"},
+		{Version: "RELEASE.2022-10-24T18-35-07Z"},
+	}
+	if got, want := minioReleaseTag(oldestMinioRelease(servers)), "RELEASE.2022-10-24T18-35-07Z"; got != want {
+		t.Errorf("oldestMinioRelease() = %q, want %q", got, want)
+	}
+	if got := oldestMinioRelease([]madmin.ServerProperties{{Version: "DEVELOPMENT.GOGET"}}); !got.IsZero() {
+		t.Errorf("oldestMinioRelease() of a development build = %v, want a zero time", got)
+	}
+}
+
+func TestRequireMinioFeatures(t *testing.T) {
+	cases := []struct {
+		release string
+		wantErr string
+	}{
+		{release: "RELEASE.2022-06-30T20-58-09Z", wantErr: "excluded_prefixes requires MinIO >= RELEASE.2022-07-08T00-05-23Z, the server runs RELEASE.2022-06-30T20-58-09Z"},
+		{release: "RELEASE.2022-07-08T00-05-23Z"},
+		{release: "2023-03-20T20:16:18Z"},
+		{release: "DEVELOPMENT.GOGET"},
+	}
+
+	for _, c := range cases {
+		client := &S3MinioClient{ServerRelease: &serverRelease{release: parseMinioRelease(c.release), fetched: true}}
+
+		err := requireMinioFeatures(context.Background(), client, minioFeatureExistingObjectReplication, minioFeatureVersioningExcludedPrefixes)
+		if c.wantErr == "" && err != nil {
+			t.Errorf("%s: unexpected error: %v", c.release, err)
+		}
+		if c.wantErr != "" && (err == nil || err.Error() != c.wantErr) {
+			t.Errorf("%s: got error %v, want %q", c.release, err, c.wantErr)
+		}
+	}
+
+	// Clients without a known release do not block the plan
+	if err := requireMinioFeatures(context.Background(), &S3MinioClient{}, minioFeatureVersioningExcludeFolders); err != nil {
+		t.Errorf("unexpected error without a server release: %v", err)
+	}
+}
+
+func TestParseMinioRelease(t *testing.T) {
+	want := time.Date(2023, time.March, 20, 20, 16, 18, 0, time.UTC)
+	for _, version := range []string{"2023-03-20T20:16:18Z", "RELEASE.2023-03-20T20-16-18Z"} {
+		if got := parseMinioRelease(version); !got.Equal(want) {
+			t.Errorf("parseMinioRelease(%q) = %v, want %v", version, got, want)
+		}
+	}
+}
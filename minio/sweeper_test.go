@@ -0,0 +1,276 @@
+package minio
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/minio/madmin-go"
+)
+
+// sweepPrefixes are the name prefixes of the objects created by the acceptance tests. Only them are swept, so
+// the sweepers can run on a shared cluster.
+var sweepPrefixes = []string{
+	"tf-acc-",
+	"tf-test-",
+	"tf-notification-test",
+	"test-user-",
+	"test-ilm-",
+}
+
+// TestMain runs the sweepers instead of the tests when -sweep is given, e.g.
+// go test ./minio -v -sweep=us-east-1 -sweep-run=minio_s3_bucket
+func TestMain(m *testing.M) {
+	resource.TestMain(m)
+}
+
+func init() {
+	resource.AddTestSweepers("minio_s3_bucket_replication", &resource.Sweeper{
+		Name: "minio_s3_bucket_replication",
+		F:    sweepBucketReplications,
+	})
+	resource.AddTestSweepers("minio_s3_bucket", &resource.Sweeper{
+		Name:         "minio_s3_bucket",
+		Dependencies: []string{"minio_s3_bucket_replication"},
+		F:            sweepBuckets,
+	})
+	resource.AddTestSweepers("minio_iam_service_account", &resource.Sweeper{
+		Name: "minio_iam_service_account",
+		F:    sweepServiceAccounts,
+	})
+	resource.AddTestSweepers("minio_iam_user", &resource.Sweeper{
+		Name:         "minio_iam_user",
+		Dependencies: []string{"minio_iam_service_account"},
+		F:            sweepUsers,
+	})
+	resource.AddTestSweepers("minio_iam_policy", &resource.Sweeper{
+		Name:         "minio_iam_policy",
+		Dependencies: []string{"minio_iam_user"},
+		F:            sweepPolicies,
+	})
+}
+
+// sweepClient returns a client configured from the environment, as the provider of the acceptance tests
+func sweepClient(region string) (*S3MinioClient, error) {
+	provider := Provider()
+	diags := provider.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"minio_region": region,
+	}))
+	if diags.HasError() {
+		return nil, fmt.Errorf("unable to configure the provider: %v", diags)
+	}
+	return provider.Meta().(*S3MinioClient), nil
+}
+
+// sweepError returns the errors of a sweeper as one, so every object is tried before failing
+func sweepError(errs []error) error {
+	if len(errs) == 0 {
+		return nil
+	}
+
+	messages := make([]string, len(errs))
+	for i, err := range errs {
+		messages[i] = err.Error()
+	}
+	return fmt.Errorf("%d errors occurred:\n%s", len(errs), strings.Join(messages, "\n"))
+}
+
+func isSweepable(name string) bool {
+	for _, prefix := range sweepPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// sweepableBuckets returns the buckets created by the acceptance tests
+func sweepableBuckets(ctx context.Context, client *S3MinioClient) ([]string, error) {
+	buckets, err := client.S3Client.ListBuckets(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list buckets: %w", err)
+	}
+
+	names := []string{}
+	for _, bucket := range buckets {
+		if isSweepable(bucket.Name) {
+			names = append(names, bucket.Name)
+		}
+	}
+	return names, nil
+}
+
+func sweepBucketReplications(region string) error {
+	ctx := context.Background()
+	client, err := sweepClient(region)
+	if err != nil {
+		return err
+	}
+
+	buckets, err := sweepableBuckets(ctx, client)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, bucket := range buckets {
+		log.Printf("[INFO] Sweeping replication of bucket %s", bucket)
+		if err := client.S3Client.RemoveBucketReplication(ctx, bucket); err != nil {
+			errs = append(errs, fmt.Errorf("unable to remove the replication of %s: %w", bucket, err))
+			continue
+		}
+
+		targets, err := client.S3Admin.ListRemoteTargets(ctx, bucket, "")
+		if err != nil {
+			errs = append(errs, fmt.Errorf("unable to list the remote targets of %s: %w", bucket, err))
+			continue
+		}
+		for _, target := range targets {
+			if err := client.S3Admin.RemoveRemoteTarget(ctx, bucket, target.Arn); err != nil {
+				errs = append(errs, fmt.Errorf("unable to remove the remote target %s of %s: %w", target.Arn, bucket, err))
+			}
+		}
+	}
+	return sweepError(errs)
+}
+
+func sweepBuckets(region string) error {
+	ctx := context.Background()
+	client, err := sweepClient(region)
+	if err != nil {
+		return err
+	}
+
+	buckets, err := sweepableBuckets(ctx, client)
+	if err != nil {
+		return err
+	}
+
+	bucketConfig := &S3MinioBucket{
+		MinioClient:                       client.S3Client,
+		MinioForceDestroyWorkers:          4,
+		MinioForceDestroyBypassGovernance: true,
+	}
+
+	var errs []error
+	for _, bucket := range buckets {
+		log.Printf("[INFO] Sweeping bucket %s", bucket)
+		if err := minioEmptyBucket(ctx, bucketConfig, bucket); err != nil {
+			errs = append(errs, fmt.Errorf("unable to empty bucket %s: %w", bucket, err))
+			continue
+		}
+		if err := client.S3Client.RemoveBucket(ctx, bucket); err != nil {
+			errs = append(errs, fmt.Errorf("unable to remove bucket %s: %w", bucket, err))
+		}
+	}
+	return sweepError(errs)
+}
+
+// sweepableUsers returns the users created by the acceptance tests
+func sweepableUsers(ctx context.Context, client *S3MinioClient) ([]string, error) {
+	users, err := client.S3Admin.ListUsers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list users: %w", err)
+	}
+
+	names := []string{}
+	for name := range users {
+		if isSweepable(name) {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+func sweepServiceAccounts(region string) error {
+	ctx := context.Background()
+	client, err := sweepClient(region)
+	if err != nil {
+		return err
+	}
+
+	users, err := sweepableUsers(ctx, client)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, user := range users {
+		serviceAccounts, err := client.S3Admin.ListServiceAccounts(ctx, user)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("unable to list the service accounts of %s: %w", user, err))
+			continue
+		}
+		for _, accessKey := range serviceAccounts.Accounts {
+			log.Printf("[INFO] Sweeping service account %s of %s", accessKey, user)
+			if err := client.S3Admin.DeleteServiceAccount(ctx, accessKey); err != nil {
+				errs = append(errs, fmt.Errorf("unable to remove service account %s: %w", accessKey, err))
+			}
+		}
+	}
+	return sweepError(errs)
+}
+
+func sweepUsers(region string) error {
+	ctx := context.Background()
+	client, err := sweepClient(region)
+	if err != nil {
+		return err
+	}
+
+	users, err := sweepableUsers(ctx, client)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, user := range users {
+		log.Printf("[INFO] Sweeping user %s", user)
+		if err := client.S3Admin.RemoveUser(ctx, user); err != nil {
+			errs = append(errs, fmt.Errorf("unable to remove user %s: %w", user, err))
+		}
+	}
+	return sweepError(errs)
+}
+
+func sweepPolicies(region string) error {
+	ctx := context.Background()
+	client, err := sweepClient(region)
+	if err != nil {
+		return err
+	}
+
+	policies, err := client.S3Admin.ListCannedPolicies(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to list policies: %w", err)
+	}
+
+	var errs []error
+	for name := range policies {
+		if !isSweepable(name) {
+			continue
+		}
+		log.Printf("[INFO] Sweeping policy %s", name)
+		if err := client.S3Admin.RemoveCannedPolicy(ctx, name); err != nil && madmin.ToErrorResponse(err).Code != "XMinioAdminNoSuchPolicy" {
+			errs = append(errs, fmt.Errorf("unable to remove policy %s: %w", name, err))
+		}
+	}
+	return sweepError(errs)
+}
+
+func TestIsSweepable(t *testing.T) {
+	for _, name := range []string{"tf-acc-test-123", "tf-test-bucket-1", "test-user-42"} {
+		if !isSweepable(name) {
+			t.Errorf("expected %q to be sweepable", name)
+		}
+	}
+	for _, name := range []string{"production", "terraform-state", "minio"} {
+		if isSweepable(name) {
+			t.Errorf("expected %q to be left untouched", name)
+		}
+	}
+}
@@ -1,7 +1,6 @@
 package minio
 
 import (
-	"fmt"
 	"github.com/minio/minio-go/v7/pkg/policy"
 
 	"github.com/minio/minio-go/v7/pkg/set"
@@ -17,14 +16,14 @@ func WriteOnlyPolicy(bucket *S3MinioBucket) BucketPolicy {
 				Actions:   readOnlyBucketActions,
 				Effect:    "Allow",
 				Principal: policy.User{AWS: set.CreateStringSet("*")},
-				Resources: set.CreateStringSet([]string{fmt.Sprintf("%s%s", awsResourcePrefix, bucket.MinioBucket)}...),
+				Resources: set.CreateStringSet([]string{bucketArn(bucket.MinioBucket)}...),
 			},
 			{
 				Sid:       "AllObjectActionsMyBuckets",
 				Actions:   writeOnlyObjectActions,
 				Effect:    "Allow",
 				Principal: policy.User{AWS: set.CreateStringSet("*")},
-				Resources: set.CreateStringSet([]string{fmt.Sprintf("%s%s/*", awsResourcePrefix, bucket.MinioBucket)}...),
+				Resources: set.CreateStringSet([]string{objectArn(bucket.MinioBucket, "*")}...),
 			},
 		},
 	}